@@ -4,12 +4,20 @@ import (
 	"fmt"
 	"math"
 	"strings"
+
+	"github.com/paulmach/go.geojson"
 )
 
 // A Bound represents an enclosed "box" in the 2D Euclidean or Cartesian plane.
-// It does not know anything about the anti-meridian.
+// Bounds of longitude and latitude degrees created by NewGeoBound or NewGeoBoundAroundPoint,
+// where the west side is greater than the east side, cross the anti-meridian.
+// See CrossesAntimeridian. Other bounds are always planar.
 type Bound struct {
 	sw, ne *Point
+
+	// geo is set for bounds of longitude and latitude degrees that can
+	// cross the anti-meridian.
+	geo bool
 }
 
 // NewBound creates a new bound given the parameters.
@@ -20,6 +28,17 @@ func NewBound(west, east, south, north float64) *Bound {
 	}
 }
 
+// NewGeoBound creates a new bound of longitude and latitude degrees.
+// Unlike NewBound the west and east values are not swapped, so the bound
+// crosses the anti-meridian if west is greater than east.
+func NewGeoBound(west, east, south, north float64) *Bound {
+	return &Bound{
+		sw:  &Point{west, math.Min(north, south)},
+		ne:  &Point{east, math.Max(north, south)},
+		geo: true,
+	}
+}
+
 // NewBoundFromPoints creates a new bound given two opposite corners.
 // These corners can be either sw/ne or se/nw.
 func NewBoundFromPoints(corner, oppositeCorner *Point) *Bound {
@@ -132,8 +151,9 @@ func geoBoundAroundPoint(center *Point, distance float64) *Bound {
 		maxLon = maxLongitude
 	}
 	return &Bound{
-		sw: &Point{rad2deg(minLon), rad2deg(minLat)},
-		ne: &Point{rad2deg(maxLon), rad2deg(maxLat)},
+		sw:  &Point{rad2deg(minLon), rad2deg(minLat)},
+		ne:  &Point{rad2deg(maxLon), rad2deg(maxLat)},
+		geo: true,
 	}
 }
 
@@ -176,6 +196,9 @@ func (b *Bound) Set(west, east, south, north float64) {
 }
 
 // Extend grows the bound to include the new point.
+// A geo bound, created by NewGeoBound or NewGeoBoundAroundPoint, is extended
+// to the west or east, whichever is closer to the point, so it stays as small
+// as possible and may end up crossing the anti-meridian.
 func (b *Bound) Extend(point *Point) *Bound {
 
 	// already included, no big deal
@@ -183,6 +206,21 @@ func (b *Bound) Extend(point *Point) *Bound {
 		return b
 	}
 
+	if b.geo {
+		b.sw[1] = math.Min(b.sw[1], point[1])
+		b.ne[1] = math.Max(b.ne[1], point[1])
+
+		if !b.containsLng(point[0]) {
+			if math.Mod(b.sw[0]-point[0]+720, 360) < math.Mod(point[0]-b.ne[0]+720, 360) {
+				b.sw[0] = point[0]
+			} else {
+				b.ne[0] = point[0]
+			}
+		}
+
+		return b
+	}
+
 	b.sw.SetX(math.Min(b.sw.X(), point.X()))
 	b.ne.SetX(math.Max(b.ne.X(), point.X()))
 
@@ -193,7 +231,37 @@ func (b *Bound) Extend(point *Point) *Bound {
 }
 
 // Union extends this bounds to contain the union of this and the given bounds.
+// If either bound is a geo bound the result is the smallest geo bound,
+// going either way around the earth, that contains both.
 func (b *Bound) Union(other *Bound) *Bound {
+	if b.geo || other.geo {
+		b.geo = true
+		b.sw[1] = math.Min(b.sw[1], other.sw[1])
+		b.ne[1] = math.Max(b.ne[1], other.ne[1])
+
+		switch {
+		case b.containsLngs(other):
+		case other.containsLngs(b):
+			b.sw[0], b.ne[0] = other.sw[0], other.ne[0]
+		case b.containsLng(other.sw[0]) && other.containsLng(b.sw[0]):
+			// overlapping on both sides, so all the way around
+			b.sw[0], b.ne[0] = -180, 180
+		case b.containsLng(other.sw[0]):
+			b.ne[0] = other.ne[0]
+		case other.containsLng(b.sw[0]):
+			b.sw[0] = other.sw[0]
+		default:
+			// disjoint, close the smaller gap
+			if math.Mod(other.sw[0]-b.ne[0]+720, 360) <= math.Mod(b.sw[0]-other.ne[0]+720, 360) {
+				b.ne[0] = other.ne[0]
+			} else {
+				b.sw[0] = other.sw[0]
+			}
+		}
+
+		return b
+	}
+
 	b.Extend(other.SouthWest())
 	b.Extend(other.NorthWest())
 	b.Extend(other.SouthEast())
@@ -210,16 +278,38 @@ func (b *Bound) Contains(point *Point) bool {
 		return false
 	}
 
-	if point.X() < b.sw.X() || b.ne.X() < point.X() {
+	return b.containsLng(point.X())
+}
+
+// containsLng checks if the x value, or longitude, is within the bound.
+func (b *Bound) containsLng(lng float64) bool {
+	if b.CrossesAntimeridian() {
+		return lng >= b.sw[0] || lng <= b.ne[0]
+	}
+
+	return b.sw[0] <= lng && lng <= b.ne[0]
+}
+
+// containsLngs checks if the x values, or longitudes, of the other bound are within the bound.
+func (b *Bound) containsLngs(other *Bound) bool {
+	if !b.containsLng(other.sw[0]) {
 		return false
 	}
 
-	return true
+	// the distance from the west side of this bound to the east side of the other
+	return math.Mod(other.sw[0]-b.sw[0]+720, 360)+other.Width() <= b.Width()
 }
 
 // Intersects determines if two bounds intersect.
 // Returns true if they are touching.
 func (b *Bound) Intersects(bound *Bound) bool {
+	if b.CrossesAntimeridian() || bound.CrossesAntimeridian() {
+		if b.ne[1] < bound.sw[1] || b.sw[1] > bound.ne[1] {
+			return false
+		}
+
+		return b.containsLng(bound.sw[0]) || bound.containsLng(b.sw[0])
+	}
 
 	if (b.ne[0] < bound.sw[0]) ||
 		(b.sw[0] > bound.ne[0]) ||
@@ -232,27 +322,75 @@ func (b *Bound) Intersects(bound *Bound) bool {
 }
 
 // Center returns the center of the bound.
+// For bounds crossing the anti-meridian the longitude is in the range [-180, 180].
 func (b *Bound) Center() *Point {
 	p := &Point{}
 	p.SetX((b.ne.X() + b.sw.X()) / 2.0)
 	p.SetY((b.ne.Y() + b.sw.Y()) / 2.0)
 
+	if b.CrossesAntimeridian() {
+		p.SetX(normalizeLng(b.sw.X() + b.Width()/2.0))
+	}
+
 	return p
 }
 
+// CrossesAntimeridian returns true if the bound wraps around 180 degrees
+// longitude. This is only the case for bounds created by NewGeoBound or
+// NewGeoBoundAroundPoint where the west side is greater than the east side
+// and both are valid longitudes.
+func (b *Bound) CrossesAntimeridian() bool {
+	return b.geo && b.sw[0] > b.ne[0] && b.sw[0] <= 180 && b.ne[0] >= -180
+}
+
+// SplitAtAntimeridian returns two new bounds, one on each side of the anti-meridian,
+// if the bound crosses it. Otherwise a copy of the bound is returned.
+func (b *Bound) SplitAtAntimeridian() []*Bound {
+	if !b.CrossesAntimeridian() {
+		return []*Bound{b.Clone()}
+	}
+
+	return []*Bound{
+		NewBound(b.sw[0], 180, b.sw[1], b.ne[1]),
+		NewBound(-180, b.ne[0], b.sw[1], b.ne[1]),
+	}
+}
+
 // Pad expands the bound in all directions by the amount given. The amount must be
 // in the units of the bounds. Technically one can pad with negative value,
-// but no error checking is done.
+// but no error checking is done. The longitudes of a geo bound are kept
+// within [-180, 180], so padding may make it cross the anti-meridian.
 func (b *Bound) Pad(amount float64) *Bound {
-	b.sw.SetX(b.sw.X() - amount)
-	b.sw.SetY(b.sw.Y() - amount)
+	if b.geo {
+		b.padLngs(amount)
+	} else {
+		b.sw.SetX(b.sw.X() - amount)
+		b.ne.SetX(b.ne.X() + amount)
+	}
 
-	b.ne.SetX(b.ne.X() + amount)
+	b.sw.SetY(b.sw.Y() - amount)
 	b.ne.SetY(b.ne.Y() + amount)
 
 	return b
 }
 
+// padLngs expands the longitudes of a geo bound, wrapping them around the anti-meridian.
+func (b *Bound) padLngs(amount float64) {
+	width := b.Width() + 2*amount
+
+	switch {
+	case width >= 360:
+		b.sw[0], b.ne[0] = -180, 180
+	case width <= 0:
+		// padded away, collapse to the center
+		center := b.Center()[0]
+		b.sw[0], b.ne[0] = center, center
+	default:
+		b.sw[0] = normalizeLng(b.sw[0] - amount)
+		b.ne[0] = normalizeLng(b.ne[0] + amount)
+	}
+}
+
 // GeoPad expands the bound in all directions by the given amount of meters.
 // Only applies if the data is Lng/Lat degrees.
 func (b *Bound) GeoPad(meters float64) *Bound {
@@ -260,10 +398,14 @@ func (b *Bound) GeoPad(meters float64) *Bound {
 	dx := dy / math.Cos(deg2rad(b.ne.Lat()))
 	dx = math.Max(dx, dy/math.Cos(deg2rad(b.sw.Lat())))
 
-	b.sw.SetLng(b.sw.Lng() - dx)
-	b.sw.SetLat(b.sw.Lat() - dy)
+	if b.geo {
+		b.padLngs(dx)
+	} else {
+		b.sw.SetLng(b.sw.Lng() - dx)
+		b.ne.SetLng(b.ne.Lng() + dx)
+	}
 
-	b.ne.SetLng(b.ne.Lng() + dx)
+	b.sw.SetLat(b.sw.Lat() - dy)
 	b.ne.SetLat(b.ne.Lat() + dy)
 
 	return b
@@ -275,7 +417,12 @@ func (b *Bound) Height() float64 {
 }
 
 // Width returns just the difference in the point's X/Longitude.
+// The width of a bound crossing the anti-meridian includes the wrap around.
 func (b *Bound) Width() float64 {
+	if b.CrossesAntimeridian() {
+		return b.ne.X() - b.sw.X() + 360
+	}
+
 	return b.ne.X() - b.sw.X()
 }
 
@@ -292,11 +439,30 @@ func (b *Bound) GeoWidth(haversine ...bool) float64 {
 	c := b.Center()
 
 	A := &Point{b.sw[0], c[1]}
-	B := &Point{b.ne[0], c[1]}
+	B := &Point{b.sw[0] + b.Width(), c[1]}
 
 	return A.GeoDistanceFrom(B, yesHaversine(haversine))
 }
 
+// Area returns the area of the bound in the units of the points squared.
+func (b *Bound) Area() float64 {
+	return b.Width() * b.Height()
+}
+
+// GeoArea returns the area of the bound in square meters on a sphere
+// of radius EarthRadius. Only applies if the data is Lng/Lat degrees.
+func (b *Bound) GeoArea() float64 {
+	return EarthRadius * EarthRadius * deg2rad(b.Width()) *
+		(math.Sin(deg2rad(b.ne.Lat())) - math.Sin(deg2rad(b.sw.Lat())))
+}
+
+// GeodesicArea returns the exact area of the bound in square meters
+// on the WGS84 ellipsoid. Only applies if the data is Lng/Lat degrees.
+func (b *Bound) GeodesicArea() float64 {
+	return WGS84.A * WGS84.A / 2 * deg2rad(b.Width()) *
+		(WGS84.authalicQ(deg2rad(b.ne.Lat())) - WGS84.authalicQ(deg2rad(b.sw.Lat())))
+}
+
 // SouthWest returns the lower left corner of the bound.
 func (b *Bound) SouthWest() *Point { return b.sw.Clone() }
 
@@ -360,13 +526,19 @@ func (b *Bound) Left() float64 {
 // Empty returns true if it contains zero area or if
 // it's in some malformed negative state where the left point is larger than the right.
 // This can be caused by padding too much negative.
+// Bounds crossing the anti-meridian, see CrossesAntimeridian, are not empty.
 func (b *Bound) Empty() bool {
+	if b.CrossesAntimeridian() {
+		return b.sw.Y() >= b.ne.Y()
+	}
+
 	return b.sw.X() >= b.ne.X() || b.sw.Y() >= b.ne.Y()
 }
 
 // Equals returns if two bounds are equal.
+// A geo bound is never equal to a planar bound with the same corners.
 func (b *Bound) Equals(c *Bound) bool {
-	if b.sw.Equals(c.sw) && b.ne.Equals(c.ne) {
+	if b.sw.Equals(c.sw) && b.ne.Equals(c.ne) && b.geo == c.geo {
 		return true
 	}
 
@@ -375,7 +547,17 @@ func (b *Bound) Equals(c *Bound) bool {
 
 // Clone returns a copy of the bound.
 func (b *Bound) Clone() *Bound {
-	return NewBoundFromPoints(b.sw, b.ne)
+	return &Bound{
+		sw:  b.sw.Clone(),
+		ne:  b.ne.Clone(),
+		geo: b.geo,
+	}
+}
+
+// Bound returns a copy of the bound. It allows bounds to be used
+// where something with a bound is expected, such as the rtree package.
+func (b *Bound) Bound() *Bound {
+	return b.Clone()
 }
 
 // ToLine returns a Line from the southwest corner to the northeast.
@@ -383,15 +565,74 @@ func (b *Bound) ToLine() *Line {
 	return NewLine(b.sw, b.ne)
 }
 
+// ToGeoJSON creates a new geojson feature with a polygon geometry
+// of the four corners of the bound. Bounds crossing the anti-meridian
+// are split and have a multi polygon geometry, see SplitAtAntimeridian.
+func (b *Bound) ToGeoJSON() *geojson.Feature {
+	if b.CrossesAntimeridian() {
+		return b.splitPolygons().ToGeoJSON()
+	}
+
+	return NewPolygonFromBound(b).ToGeoJSON()
+}
+
 // String returns the string respentation of the bound in WKT format.
 // POLYGON(west, south, west, north, east, north, east, south, west, south)
+// Bounds crossing the anti-meridian are split and written as a MULTIPOLYGON.
 func (b *Bound) String() string {
+	if b.CrossesAntimeridian() {
+		parts := b.SplitAtAntimeridian()
+		return fmt.Sprintf("MULTIPOLYGON(%s, %s)", parts[0].wktRings(), parts[1].wktRings())
+	}
+
+	return "POLYGON" + b.wktRings()
+}
+
+func (b *Bound) wktRings() string {
 	// west, south, west, north, east, north, east, south, west, south
-	return fmt.Sprintf("POLYGON((%g %g, %g %g, %g %g, %g %g, %g %g))", b.sw[0], b.sw[1], b.sw[0], b.ne[1], b.ne[0], b.ne[1], b.ne[0], b.sw[1], b.sw[0], b.sw[1])
+	return fmt.Sprintf("((%g %g, %g %g, %g %g, %g %g, %g %g))", b.sw[0], b.sw[1], b.sw[0], b.ne[1], b.ne[0], b.ne[1], b.ne[0], b.sw[1], b.sw[0], b.sw[1])
+}
+
+// splitPolygons returns the parts of the bound on either side of
+// the anti-meridian as a multi polygon, see SplitAtAntimeridian.
+func (b *Bound) splitPolygons() *MultiPolygon {
+	parts := b.SplitAtAntimeridian()
+
+	mp := make(MultiPolygon, 0, len(parts))
+	for _, part := range parts {
+		mp = append(mp, *NewPolygonFromBound(part))
+	}
+
+	return &mp
+}
+
+// newBoundFromSplitPolygons returns the bound crossing the anti-meridian
+// written as the multi polygon by Bound.splitPolygons, or nil if
+// the multi polygon is not two parts of such a bound.
+func newBoundFromSplitPolygons(mp MultiPolygon) *Bound {
+	if len(mp) != 2 {
+		return nil
+	}
+
+	west, east := mp[0].Bound(), mp[1].Bound()
+	if west.ne[0] != 180 || east.sw[0] != -180 || west.sw[0] <= east.ne[0] ||
+		west.sw[1] != east.sw[1] || west.ne[1] != east.ne[1] {
+		return nil
+	}
+
+	return NewGeoBound(west.sw[0], east.ne[0], west.sw[1], west.ne[1])
 }
 
 // ToMysqlIntersectsCondition returns a condition defining the intersection
 // of the column and the bound. To be used in a MySQL query.
+// Bounds crossing the anti-meridian are split in two and joined with OR.
 func (b *Bound) ToMysqlIntersectsCondition(column string) string {
+	if b.CrossesAntimeridian() {
+		bounds := b.SplitAtAntimeridian()
+		return fmt.Sprintf("(%s OR %s)",
+			bounds[0].ToMysqlIntersectsCondition(column),
+			bounds[1].ToMysqlIntersectsCondition(column))
+	}
+
 	return fmt.Sprintf("INTERSECTS(%s, GEOMFROMTEXT('%s'))", column, b.String())
 }
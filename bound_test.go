@@ -301,6 +301,20 @@ func TestBoundEquals(t *testing.T) {
 	}
 }
 
+func TestBoundBound(t *testing.T) {
+	bound := NewBound(1, 2, 3, 4)
+
+	b := bound.Bound()
+	if !b.Equals(bound) {
+		t.Errorf("bound, bound expected %v, got %v", bound, b)
+	}
+
+	b.Pad(1)
+	if !bound.Equals(NewBound(1, 2, 3, 4)) {
+		t.Errorf("bound, bound should return a copy")
+	}
+}
+
 func TestBoundSides(t *testing.T) {
 	// NewBound(west, east, south, north)
 	b := NewBound(1, 2, 3, 4)
@@ -388,6 +402,16 @@ func TestBoundEmpty(t *testing.T) {
 	if !bound.Empty() {
 		t.Error("bound, empty exported true, got false")
 	}
+
+	// padded too much negative, does not cross the anti-meridian
+	bound = NewBound(0, 1, 0, 10).Pad(-0.6)
+	if !bound.Empty() {
+		t.Error("bound, empty exported true, got false")
+	}
+
+	if w := bound.Width(); math.Abs(w+0.2) > epsilon {
+		t.Errorf("bound, width expected -0.2, got %v", w)
+	}
 }
 
 func TestBoundString(t *testing.T) {
@@ -397,6 +421,13 @@ func TestBoundString(t *testing.T) {
 	if s := bound.String(); s != answer {
 		t.Errorf("bound, string expected %s, got %s", answer, s)
 	}
+
+	bound = NewGeoBound(170, -170, -10, 10)
+
+	answer = "MULTIPOLYGON(((170 -10, 170 10, 180 10, 180 -10, 170 -10)), ((-180 -10, -180 10, -170 10, -170 -10, -180 -10)))"
+	if s := bound.String(); s != answer {
+		t.Errorf("bound, string expected %s, got %s", answer, s)
+	}
 }
 
 func TestBoundToMysqlIntersectsCondition(t *testing.T) {
@@ -407,3 +438,257 @@ func TestBoundToMysqlIntersectsCondition(t *testing.T) {
 		t.Errorf("bound, incorrect condition, got %v", p)
 	}
 }
+
+func TestBoundArea(t *testing.T) {
+	b := NewBound(0, 4, 0, 3)
+	if a := b.Area(); a != 12 {
+		t.Errorf("bound, area expected 12, got %f", a)
+	}
+
+	world := NewBound(-180, 180, -90, 90)
+	if a, e := world.GeoArea(), 4*math.Pi*EarthRadius*EarthRadius; math.Abs(a-e)/e > 1e-12 {
+		t.Errorf("bound, geoArea expected %f, got %f", e, a)
+	}
+
+	// total surface area of the WGS84 ellipsoid
+	if a := world.GeodesicArea(); math.Abs(a-510065621724088.5)/a > 1e-9 {
+		t.Errorf("bound, geodesicArea expected 510065621724088.5, got %f", a)
+	}
+
+	// halves
+	if a := NewBound(-180, 180, 0, 90).GeodesicArea(); math.Abs(a-510065621724088.5/2)/a > 1e-9 {
+		t.Errorf("bound, geodesicArea of hemisphere incorrect, got %f", a)
+	}
+
+	if a := NewBound(1, 1, 1, 1).GeodesicArea(); a != 0 {
+		t.Errorf("bound, geodesicArea of empty bound should be 0, got %f", a)
+	}
+}
+
+func TestBoundAntimeridian(t *testing.T) {
+	b := NewGeoBound(170, -170, -10, 10)
+
+	if !b.CrossesAntimeridian() {
+		t.Errorf("bound, should cross the anti-meridian")
+	}
+
+	if NewBound(170, -170, -10, 10).CrossesAntimeridian() {
+		t.Errorf("bound, NewBound should not cross the anti-meridian")
+	}
+
+	if !b.Clone().CrossesAntimeridian() {
+		t.Errorf("bound, clone should cross the anti-meridian")
+	}
+
+	// only geo bounds can cross the anti-meridian
+	planar := NewBound(0, 1, 0, 1)
+	planar.Set(1, 0, 0, 1)
+	if planar.CrossesAntimeridian() {
+		t.Errorf("bound, planar bound should not cross the anti-meridian")
+	}
+
+	if w := b.Width(); w != 20 {
+		t.Errorf("bound, width expected 20, got %v", w)
+	}
+
+	if b.Empty() {
+		t.Errorf("bound, should not be empty")
+	}
+
+	if c := b.Center(); !c.Equals(NewPoint(180, 0)) {
+		t.Errorf("bound, center expected 180 0, got %v", c)
+	}
+
+	if c := NewGeoBound(160, -170, 0, 0).Center(); !c.Equals(NewPoint(175, 0)) {
+		t.Errorf("bound, center expected 175 0, got %v", c)
+	}
+
+	expected := NewBound(-10, 10, -10, 10).GeoWidth()
+	if w := b.GeoWidth(); math.Abs(w-expected) > 1e-6 {
+		t.Errorf("bound, geoWidth expected %v, got %v", expected, w)
+	}
+
+	// contains
+	for _, p := range []*Point{NewPoint(175, 0), NewPoint(-175, 5), NewPoint(180, 0), NewPoint(-180, 0), NewPoint(170, 10)} {
+		if !b.Contains(p) {
+			t.Errorf("bound, should contain %v", p)
+		}
+	}
+
+	for _, p := range []*Point{NewPoint(0, 0), NewPoint(165, 0), NewPoint(-165, 0), NewPoint(175, 20)} {
+		if b.Contains(p) {
+			t.Errorf("bound, should not contain %v", p)
+		}
+	}
+
+	// intersects
+	if !b.Intersects(NewBound(175, 178, 0, 1)) {
+		t.Errorf("bound, should intersect bound on the west side")
+	}
+
+	if !b.Intersects(NewBound(-175, -100, 0, 1)) {
+		t.Errorf("bound, should intersect bound on the east side")
+	}
+
+	if !NewBound(-175, -100, 0, 1).Intersects(b) {
+		t.Errorf("bound, should intersect crossing bound")
+	}
+
+	if !b.Intersects(NewGeoBound(179, -179, 0, 1)) {
+		t.Errorf("bound, should intersect other crossing bound")
+	}
+
+	if b.Intersects(NewBound(-160, 160, 0, 1)) {
+		t.Errorf("bound, should not intersect bound in the gap")
+	}
+
+	if b.Intersects(NewBound(175, 178, 20, 30)) {
+		t.Errorf("bound, should not intersect bound to the north")
+	}
+
+	// split
+	bounds := b.SplitAtAntimeridian()
+	if len(bounds) != 2 ||
+		!bounds[0].Equals(NewBound(170, 180, -10, 10)) ||
+		!bounds[1].Equals(NewBound(-180, -170, -10, 10)) {
+		t.Errorf("bound, split incorrect, got %v", bounds)
+	}
+
+	if bounds := NewBound(1, 2, 3, 4).SplitAtAntimeridian(); len(bounds) != 1 {
+		t.Errorf("bound, split should not split a normal bound, got %v", bounds)
+	}
+
+	// mysql
+	condition := b.ToMysqlIntersectsCondition("column")
+	expectedCondition := "(INTERSECTS(column, GEOMFROMTEXT('POLYGON((170 -10, 170 10, 180 10, 180 -10, 170 -10))')) OR " +
+		"INTERSECTS(column, GEOMFROMTEXT('POLYGON((-180 -10, -180 10, -170 10, -170 -10, -180 -10))')))"
+	if condition != expectedCondition {
+		t.Errorf("bound, incorrect condition, got %v", condition)
+	}
+}
+
+func TestBoundAntimeridianExtend(t *testing.T) {
+	b := NewGeoBound(170, -170, -10, 10)
+
+	b.Extend(NewPoint(-160, 20))
+	if !b.Equals(NewGeoBound(170, -160, -10, 20)) {
+		t.Errorf("bound, extend east incorrect, got %v", b)
+	}
+
+	b.Extend(NewPoint(150, 0))
+	if !b.Equals(NewGeoBound(150, -160, -10, 20)) {
+		t.Errorf("bound, extend west incorrect, got %v", b)
+	}
+
+	b.Extend(NewPoint(175, 0))
+	if !b.Equals(NewGeoBound(150, -160, -10, 20)) {
+		t.Errorf("bound, extend with contained point should not change, got %v", b)
+	}
+
+	b = NewGeoBoundAroundPoint(NewPoint(179.5, 0), 1000)
+	b.Extend(NewPoint(-179.5, 0))
+	if !b.CrossesAntimeridian() {
+		t.Errorf("bound, extend across the anti-meridian should cross, got %v", b)
+	}
+
+	if w := b.Width(); w > 1.1 {
+		t.Errorf("bound, extend across the anti-meridian expected width about 1, got %v", w)
+	}
+}
+
+func TestBoundAntimeridianUnion(t *testing.T) {
+	cases := []struct {
+		b, other, expected *Bound
+	}{
+		{
+			// normal bound to the east
+			NewGeoBound(170, -170, 0, 1), NewBound(-160, -150, 0, 1), NewGeoBound(170, -150, 0, 1),
+		},
+		{
+			// normal bound to the west
+			NewGeoBound(170, -170, 0, 1), NewBound(150, 160, 0, 1), NewGeoBound(150, -170, 0, 1),
+		},
+		{
+			// overlapping
+			NewGeoBound(170, -170, 0, 1), NewBound(-175, 0, -1, 0), NewGeoBound(170, 0, -1, 1),
+		},
+		{
+			// normal bound becoming a crossing bound
+			NewBound(160, 175, 0, 1), NewGeoBound(178, -175, 0, 1), NewGeoBound(160, -175, 0, 1),
+		},
+		{
+			// contained
+			NewGeoBound(170, -170, 0, 1), NewBound(175, 179, 0, 1), NewGeoBound(170, -170, 0, 1),
+		},
+		{
+			// containing
+			NewBound(175, 179, 0, 1), NewGeoBound(170, -170, 0, 1), NewGeoBound(170, -170, 0, 1),
+		},
+		{
+			// neither crossing, shorter across the anti-meridian
+			NewGeoBound(170, 179, 0, 1), NewGeoBound(-179, -170, 0, 1), NewGeoBound(170, -170, 0, 1),
+		},
+		{
+			// neither crossing, shorter the normal way
+			NewGeoBound(-10, 0, 0, 1), NewBound(10, 20, 0, 1), NewGeoBound(-10, 20, 0, 1),
+		},
+		{
+			// overlapping on both sides
+			NewGeoBound(170, -10, 0, 1), NewBound(-20, 175, 0, 1), NewGeoBound(-180, 180, 0, 1),
+		},
+	}
+
+	for i, tc := range cases {
+		if u := tc.b.Clone().Union(tc.other); !u.Equals(tc.expected) {
+			t.Errorf("bound, union %d expected %v, got %v", i, tc.expected, u)
+		}
+	}
+}
+
+func TestBoundAntimeridianPad(t *testing.T) {
+	cases := []struct {
+		b        *Bound
+		amount   float64
+		expected *Bound
+	}{
+		{NewGeoBound(170, 179, 0, 1), 2, NewGeoBound(168, -179, -2, 3)},
+		{NewGeoBound(170, -170, 0, 1), 1, NewGeoBound(169, -169, -1, 2)},
+		{NewGeoBound(-179, 179, 0, 1), 1, NewGeoBound(-180, 180, -1, 2)},
+		{NewBound(170, 179, 0, 1), 2, NewBound(168, 181, -2, 3)},
+	}
+
+	for i, tc := range cases {
+		if b := tc.b.Clone().Pad(tc.amount); !b.Equals(tc.expected) {
+			t.Errorf("bound, pad %d expected %v, got %v", i, tc.expected, b)
+		}
+	}
+
+	b := NewGeoBoundAroundPoint(NewPoint(179.5, 0), 1000).GeoPad(100000)
+	if b.sw[0] < -180 || b.sw[0] > 180 || b.ne[0] < -180 || b.ne[0] > 180 {
+		t.Errorf("bound, geo pad should keep longitudes in range, got %v", b)
+	}
+
+	if !b.CrossesAntimeridian() {
+		t.Errorf("bound, geo pad should cross the anti-meridian, got %v", b)
+	}
+}
+
+func TestGeoBoundAroundPointAntimeridian(t *testing.T) {
+	b := NewGeoBoundAroundPoint(NewPoint(179.9, 0), 100000)
+
+	if !b.CrossesAntimeridian() {
+		t.Fatalf("bound, should cross the anti-meridian, got %v", b)
+	}
+
+	if !b.Contains(NewPoint(-179.9, 0)) {
+		t.Errorf("bound, should contain point across the anti-meridian")
+	}
+
+	if b.Contains(NewPoint(0, 0)) {
+		t.Errorf("bound, should not contain point on the other side of the world")
+	}
+
+	if c := b.Center(); math.Abs(c.Lng()-179.9) > 1e-9 {
+		t.Errorf("bound, center expected 179.9, got %v", c.Lng())
+	}
+}
@@ -0,0 +1,331 @@
+package geo
+
+import "math"
+
+// A BufferCapStyle defines the shape of the buffer around the ends of lines and paths.
+type BufferCapStyle int
+
+// The supported cap styles.
+const (
+	// BufferCapRound ends with a half circle around the end point.
+	BufferCapRound BufferCapStyle = iota
+
+	// BufferCapFlat ends with a straight edge through the end point.
+	BufferCapFlat
+
+	// BufferCapSquare ends with a straight edge the buffer distance past the end point.
+	BufferCapSquare
+)
+
+// A BufferJoinStyle defines the shape of the buffer on the outside of the corners of a path.
+type BufferJoinStyle int
+
+// The supported join styles.
+const (
+	// BufferJoinRound joins the offset segments with a circular arc around the corner.
+	BufferJoinRound BufferJoinStyle = iota
+
+	// BufferJoinMitre extends the offset segments until they meet.
+	// Sharp corners fall back to a bevel, see BufferOptions.MitreLimit.
+	BufferJoinMitre
+
+	// BufferJoinBevel connects the offset segments with a straight edge.
+	BufferJoinBevel
+)
+
+// BufferOptions define how the buffer polygon ring is created.
+type BufferOptions struct {
+	// QuadrantSegments is the number of segments used to approximate
+	// a quarter circle for round caps and joins.
+	QuadrantSegments int
+
+	CapStyle  BufferCapStyle
+	JoinStyle BufferJoinStyle
+
+	// MitreLimit is the maximum distance of a mitre join from the corner,
+	// as a multiple of the buffer distance, before a bevel is used instead.
+	MitreLimit float64
+}
+
+// DefaultBufferOptions are used if no options are passed to the buffer methods.
+var DefaultBufferOptions = BufferOptions{
+	QuadrantSegments: 8,
+	CapStyle:         BufferCapRound,
+	JoinStyle:        BufferJoinRound,
+	MitreLimit:       5,
+}
+
+// Buffer returns a ring around the point at the given distance. This is a
+// circle approximated with 4*QuadrantSegments points if the cap style is round,
+// a square if the cap style is square and empty if it is flat.
+// The ring is closed and in counter-clockwise order. Uses standard Euclidean geometry.
+func (p *Point) Buffer(distance float64, options ...BufferOptions) *PointSet {
+	b := newBufferBuilder(distance, options)
+	ring := b.point(p)
+
+	return &ring
+}
+
+// GeoBuffer returns a ring around the point at the given distance in meters.
+// The point is projected with a transverse Mercator projection centered on
+// the point and buffered in that space. See Buffer for more information.
+func (p *Point) GeoBuffer(meters float64, options ...BufferOptions) *PointSet {
+	projection := BuildTransverseMercator(p.Lng())
+
+	projected := p.Clone()
+	projection.Project(projected)
+
+	return projectedRing(projected.Buffer(meters, options...), projection)
+}
+
+// Buffer returns a ring around the line at the given distance.
+// The ring is closed and in counter-clockwise order. Uses standard Euclidean geometry.
+func (l *Line) Buffer(distance float64, options ...BufferOptions) *PointSet {
+	return (&Path{PointSet{l.a, l.b}}).Buffer(distance, options...)
+}
+
+// GeoBuffer returns a ring around the line at the given distance in meters.
+// See Path.GeoBuffer for more information.
+func (l *Line) GeoBuffer(meters float64, options ...BufferOptions) *PointSet {
+	return (&Path{PointSet{l.a, l.b}}).GeoBuffer(meters, options...)
+}
+
+// Buffer returns a ring around the path at the given distance, with the
+// cap and join styles from the options. The ring is closed and in
+// counter-clockwise order. Uses standard Euclidean geometry.
+// Paths that turn back on themselves within the buffer distance,
+// or with segments shorter than the distance at sharp corners,
+// may result in a self intersecting ring.
+func (p *Path) Buffer(distance float64, options ...BufferOptions) *PointSet {
+	b := newBufferBuilder(distance, options)
+
+	// remove repeated points as they have no direction
+	points := make(PointSet, 0, len(p.PointSet))
+	for i := range p.PointSet {
+		if i == 0 || p.PointSet[i] != p.PointSet[i-1] {
+			points = append(points, p.PointSet[i])
+		}
+	}
+
+	var ring PointSet
+	switch {
+	case len(points) == 0 || distance <= 0:
+		ring = PointSet{}
+	case len(points) == 1:
+		ring = b.point(&points[0])
+	default:
+		ring = b.path(points)
+	}
+
+	return &ring
+}
+
+// GeoBuffer returns a ring around the path at the given distance in meters.
+// The path is projected with a transverse Mercator projection centered on
+// the path and buffered in that space, so it works best for paths spanning less
+// than a few hundred kilometers east to west. See Buffer for more information.
+func (p *Path) GeoBuffer(meters float64, options ...BufferOptions) *PointSet {
+	if len(p.PointSet) == 0 {
+		return &PointSet{}
+	}
+
+	projection := BuildTransverseMercator(p.Bound().Center().Lng())
+	projected := p.Clone().Transform(projection.Project)
+
+	return projectedRing(projected.Buffer(meters, options...), projection)
+}
+
+func projectedRing(ring *PointSet, projection Projection) *PointSet {
+	for i := range *ring {
+		projection.Inverse(&(*ring)[i])
+	}
+
+	return ring
+}
+
+type bufferBuilder struct {
+	BufferOptions
+	distance float64
+	ring     PointSet
+}
+
+func newBufferBuilder(distance float64, options []BufferOptions) *bufferBuilder {
+	b := &bufferBuilder{
+		BufferOptions: DefaultBufferOptions,
+		distance:      distance,
+	}
+
+	if len(options) != 0 {
+		b.BufferOptions = options[0]
+	}
+
+	if b.QuadrantSegments < 1 {
+		b.QuadrantSegments = 1
+	}
+
+	return b
+}
+
+func (b *bufferBuilder) point(p *Point) PointSet {
+	d := b.distance
+	if d <= 0 {
+		return PointSet{}
+	}
+
+	switch b.CapStyle {
+	case BufferCapFlat:
+		return PointSet{}
+	case BufferCapSquare:
+		return PointSet{
+			{p[0] - d, p[1] - d},
+			{p[0] + d, p[1] - d},
+			{p[0] + d, p[1] + d},
+			{p[0] - d, p[1] + d},
+			{p[0] - d, p[1] - d},
+		}
+	}
+
+	n := 4 * b.QuadrantSegments
+	b.ring = make(PointSet, 0, n+1)
+	for i := 0; i < n; i++ {
+		b.add(p, 2*math.Pi*float64(i)/float64(n))
+	}
+
+	return append(b.ring, b.ring[0])
+}
+
+// path creates the ring by going along the right side of the path,
+// around the end cap, back along the right side of the reversed path
+// and around the start cap.
+func (b *bufferBuilder) path(points PointSet) PointSet {
+	b.ring = make(PointSet, 0, 2*len(points)+4*b.QuadrantSegments)
+
+	b.side(points)
+	b.cap(&points[len(points)-1], &points[len(points)-2])
+
+	reversed := make(PointSet, len(points))
+	for i := range points {
+		reversed[len(points)-1-i] = points[i]
+	}
+
+	b.side(reversed)
+	b.cap(&reversed[len(reversed)-1], &reversed[len(reversed)-2])
+
+	return append(b.ring, b.ring[0])
+}
+
+// side adds the offset points along the right side of the path.
+func (b *bufferBuilder) side(points PointSet) {
+	normals := make([]Point, len(points)-1)
+	for i := range normals {
+		normals[i] = rightNormal(&points[i], &points[i+1])
+	}
+
+	b.offset(&points[0], &normals[0])
+	for i := 1; i < len(points)-1; i++ {
+		b.join(&points[i-1], &points[i], &points[i+1], &normals[i-1], &normals[i])
+	}
+	b.offset(&points[len(points)-1], &normals[len(normals)-1])
+}
+
+func (b *bufferBuilder) join(prev, v, next, n1, n2 *Point) {
+	cross := n1[0]*n2[1] - n1[1]*n2[0]
+	dot := n1.Dot(n2)
+
+	// collinear, continuing in the same direction
+	if math.Abs(cross) < epsilon*epsilon && dot > 0 {
+		b.offset(v, n1)
+		return
+	}
+
+	// turning right, the right side is on the inside of the corner
+	if cross < 0 {
+		s1 := NewLine(b.offsetPoint(prev, n1), b.offsetPoint(v, n1))
+		s2 := NewLine(b.offsetPoint(v, n2), b.offsetPoint(next, n2))
+
+		if p := s1.Intersection(s2); p != nil && p != InfinityPoint {
+			b.ring = append(b.ring, *p)
+			return
+		}
+
+		// the segments are too short, go around the corner
+		b.offset(v, n1)
+		b.ring = append(b.ring, *v)
+		b.offset(v, n2)
+		return
+	}
+
+	// turning left, the right side is on the outside of the corner
+	switch b.JoinStyle {
+	case BufferJoinMitre:
+		if 1+dot > epsilon && math.Sqrt(2/(1+dot)) <= b.MitreLimit {
+			scale := b.distance / (1 + dot)
+			b.ring = append(b.ring, Point{v[0] + (n1[0]+n2[0])*scale, v[1] + (n1[1]+n2[1])*scale})
+			return
+		}
+
+		b.offset(v, n1)
+		b.offset(v, n2)
+	case BufferJoinBevel:
+		b.offset(v, n1)
+		b.offset(v, n2)
+	default:
+		start := math.Atan2(n1[1], n1[0])
+		sweep := math.Atan2(n2[1], n2[0]) - start
+		if sweep <= 0 {
+			sweep += 2 * math.Pi
+		}
+
+		b.offset(v, n1)
+		b.arc(v, start, sweep)
+		b.offset(v, n2)
+	}
+}
+
+// cap adds the points around the end of the path, from the right side
+// to the left side, given the last two points of the path.
+func (b *bufferBuilder) cap(end, prev *Point) {
+	n := rightNormal(prev, end)
+
+	switch b.CapStyle {
+	case BufferCapFlat:
+	case BufferCapSquare:
+		// the direction of the path is the right normal rotated counter-clockwise
+		d := b.distance
+		b.ring = append(b.ring,
+			Point{end[0] + (n[0]-n[1])*d, end[1] + (n[1]+n[0])*d},
+			Point{end[0] + (-n[0]-n[1])*d, end[1] + (-n[1]+n[0])*d})
+	default:
+		b.arc(end, math.Atan2(n[1], n[0]), math.Pi)
+	}
+}
+
+// arc adds the points of a counter-clockwise arc around the center,
+// excluding the start and end points.
+func (b *bufferBuilder) arc(center *Point, start, sweep float64) {
+	n := int(math.Ceil(sweep / (math.Pi / 2 / float64(b.QuadrantSegments))))
+	for i := 1; i < n; i++ {
+		b.add(center, start+sweep*float64(i)/float64(n))
+	}
+}
+
+func (b *bufferBuilder) add(center *Point, angle float64) {
+	sin, cos := math.Sincos(angle)
+	b.ring = append(b.ring, Point{center[0] + b.distance*cos, center[1] + b.distance*sin})
+}
+
+func (b *bufferBuilder) offset(p, normal *Point) {
+	b.ring = append(b.ring, *b.offsetPoint(p, normal))
+}
+
+func (b *bufferBuilder) offsetPoint(p, normal *Point) *Point {
+	return &Point{p[0] + normal[0]*b.distance, p[1] + normal[1]*b.distance}
+}
+
+// rightNormal returns the unit vector perpendicular, to the right, of the direction from a to b.
+func rightNormal(a, b *Point) Point {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	l := math.Sqrt(dx*dx + dy*dy)
+
+	return Point{dy / l, -dx / l}
+}
@@ -0,0 +1,190 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointBuffer(t *testing.T) {
+	p := NewPoint(1, 2)
+
+	ring := p.Buffer(2)
+	if l := ring.Length(); l != 4*DefaultBufferOptions.QuadrantSegments+1 {
+		t.Errorf("point, buffer incorrect number of points, got %d", l)
+	}
+
+	for i := range *ring {
+		if d := ring.GetAt(i).DistanceFrom(p); math.Abs(d-2) > epsilon {
+			t.Errorf("point, buffer point should be at distance 2, got %f", d)
+		}
+	}
+
+	if !ring.First().Equals(ring.Last()) || !ring.IsCounterClockwise() {
+		t.Errorf("point, buffer should be closed and counter-clockwise")
+	}
+
+	n := 4.0 * float64(DefaultBufferOptions.QuadrantSegments)
+	if a, e := ring.Area(), n/2*math.Sin(2*math.Pi/n)*4; math.Abs(a-e) > epsilon {
+		t.Errorf("point, buffer area expected %f, got %f", e, a)
+	}
+
+	// options
+	options := DefaultBufferOptions
+	options.QuadrantSegments = 2
+	if l := p.Buffer(2, options).Length(); l != 9 {
+		t.Errorf("point, buffer incorrect number of points, got %d", l)
+	}
+
+	options.CapStyle = BufferCapSquare
+	expected := append(PointSet{}, Point{-1, 0}, Point{3, 0}, Point{3, 4}, Point{-1, 4}, Point{-1, 0})
+	if ring := p.Buffer(2, options); !ring.Equals(&expected) {
+		t.Errorf("point, buffer square expected %v, got %v", expected, ring)
+	}
+
+	options.CapStyle = BufferCapFlat
+	if l := p.Buffer(2, options).Length(); l != 0 {
+		t.Errorf("point, buffer flat should be empty, got %d", l)
+	}
+
+	if l := p.Buffer(0).Length(); l != 0 {
+		t.Errorf("point, buffer of zero distance should be empty, got %d", l)
+	}
+}
+
+func TestLineBuffer(t *testing.T) {
+	l := NewLine(NewPoint(0, 0), NewPoint(4, 3))
+
+	options := DefaultBufferOptions
+	options.CapStyle = BufferCapFlat
+	if a := l.Buffer(1, options).Area(); math.Abs(a-10) > epsilon {
+		t.Errorf("line, buffer flat area expected 10, got %f", a)
+	}
+
+	options.CapStyle = BufferCapSquare
+	if a := l.Buffer(1, options).Area(); math.Abs(a-14) > epsilon {
+		t.Errorf("line, buffer square area expected 14, got %f", a)
+	}
+
+	ring := l.Buffer(1)
+	circle := NewPoint(0, 0).Buffer(1).Area()
+	if a := ring.Area(); math.Abs(a-(10+circle)) > epsilon {
+		t.Errorf("line, buffer round area expected %f, got %f", 10+circle, a)
+	}
+
+	if !ring.IsCounterClockwise() {
+		t.Errorf("line, buffer should be counter-clockwise")
+	}
+
+	if !ring.RingContains(NewPoint(4.5, 3.5)) || ring.RingContains(NewPoint(5, 4)) {
+		t.Errorf("line, buffer contains incorrect")
+	}
+}
+
+func TestPathBuffer(t *testing.T) {
+	p := NewPathFromXYData([][2]float64{{0, 0}, {10, 0}, {10, 10}})
+
+	options := DefaultBufferOptions
+	options.CapStyle = BufferCapFlat
+	options.JoinStyle = BufferJoinMitre
+
+	expected := append(PointSet{},
+		Point{0, -1}, Point{11, -1}, Point{11, 10}, Point{9, 10}, Point{9, 1}, Point{0, 1}, Point{0, -1})
+	if ring := p.Buffer(1, options); !ring.Equals(&expected) {
+		t.Errorf("path, buffer mitre expected %v, got %v", expected, ring)
+	}
+
+	options.JoinStyle = BufferJoinBevel
+	if a := p.Buffer(1, options).Area(); math.Abs(a-39.5) > epsilon {
+		t.Errorf("path, buffer bevel area expected 39.5, got %f", a)
+	}
+
+	options.JoinStyle = BufferJoinRound
+	quarter := NewPoint(0, 0).Buffer(1).Area() / 4
+	if a := p.Buffer(1, options).Area(); math.Abs(a-(39+quarter)) > epsilon {
+		t.Errorf("path, buffer round area expected %f, got %f", 39+quarter, a)
+	}
+
+	// sharp corners should fall back to a bevel
+	sharp := NewPathFromXYData([][2]float64{{0, 0}, {10, 0}, {0, 1}})
+	options.JoinStyle = BufferJoinMitre
+	for _, point := range *sharp.Buffer(1, options) {
+		if point[0] > 11 {
+			t.Errorf("path, buffer mitre should be limited, got %v", point)
+		}
+	}
+
+	// every point of the default buffer should be the distance from the path
+	p = NewPathFromXYData([][2]float64{{0, 0}, {5, 5}, {10, 0}, {15, 3}, {15, 10}})
+	ring := p.Buffer(1)
+	for i := range *ring {
+		if d := p.DistanceFrom(ring.GetAt(i)); math.Abs(d-1) > epsilon {
+			t.Errorf("path, buffer point %v should be at distance 1, got %f", ring.GetAt(i), d)
+		}
+	}
+
+	if !ring.IsCounterClockwise() {
+		t.Errorf("path, buffer should be counter-clockwise")
+	}
+
+	// repeated points
+	p = NewPathFromXYData([][2]float64{{0, 0}, {0, 0}, {10, 0}, {10, 0}})
+	if a := p.Buffer(1).Area(); math.Abs(a-20-math.Pi) > 0.1 {
+		t.Errorf("path, buffer with repeated points incorrect area, got %f", a)
+	}
+
+	p = NewPathFromXYData([][2]float64{{1, 1}, {1, 1}})
+	if !p.Buffer(1).Equals(NewPoint(1, 1).Buffer(1)) {
+		t.Errorf("path, buffer of a single point should be a point buffer")
+	}
+
+	if l := NewPath().Buffer(1).Length(); l != 0 {
+		t.Errorf("path, buffer of empty path should be empty, got %d", l)
+	}
+}
+
+func TestGeoBuffer(t *testing.T) {
+	center := NewPoint(-122.4, 37.8)
+
+	ring := center.GeoBuffer(1000)
+	for i := range *ring {
+		if d := center.GeoDistanceFrom(ring.GetAt(i), true); math.Abs(d-1000) > 1 {
+			t.Errorf("point, geoBuffer should be 1000 meters, got %f", d)
+		}
+	}
+
+	if !ring.IsCounterClockwise() {
+		t.Errorf("point, geoBuffer should be counter-clockwise")
+	}
+
+	path := NewPath().Push(center).Push(NewPoint(-122.3, 37.85)).Push(NewPoint(-122.25, 37.8))
+	ring = path.GeoBuffer(50)
+	for i := range *ring {
+		point := ring.GetAt(i)
+
+		min := math.Inf(1)
+		for j := 0; j < path.Length()-1; j++ {
+			l := NewLine(path.GetAt(j), path.GetAt(j+1))
+
+			d := math.Abs(l.GeoCrossTrackDistance(point))
+			if along := l.GeoAlongTrackDistance(point); along < 0 {
+				d = point.GeoDistanceFrom(&l.a, true)
+			} else if along > l.GeoDistance(true) {
+				d = point.GeoDistanceFrom(&l.b, true)
+			}
+			min = math.Min(min, d)
+		}
+
+		if math.Abs(min-50) > 0.5 {
+			t.Errorf("path, geoBuffer should be about 50 meters, got %f", min)
+		}
+	}
+
+	l := NewLine(center, NewPoint(-122.3, 37.85))
+	if !l.GeoBuffer(50).Equals(NewPath().Push(&l.a).Push(&l.b).GeoBuffer(50)) {
+		t.Errorf("line, geoBuffer should match path")
+	}
+
+	if l := NewPath().GeoBuffer(50).Length(); l != 0 {
+		t.Errorf("path, geoBuffer of empty path should be empty, got %d", l)
+	}
+}
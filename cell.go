@@ -0,0 +1,587 @@
+package geo
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A CellID identifies a cell in a hierarchical decomposition of the sphere,
+// compatible with the S2 geometry library. The sphere is projected onto the six faces
+// of a cube and each face is recursively divided into four cells, down to level 30.
+// Cells are numbered along a Hilbert curve, so cells with nearby ids are nearby
+// on the sphere, and all the descendants of a cell are in a contiguous range of ids.
+// Points are treated as on a sphere, the same as the Geo* methods.
+type CellID uint64
+
+// CellMaxLevel is the level of the smallest cells, about 1 square centimeter.
+const CellMaxLevel = 30
+
+const (
+	cellPosBits  = 2*CellMaxLevel + 1
+	cellMaxSize  = 1 << CellMaxLevel
+	cellSwapMask = 1
+	cellInvMask  = 2
+)
+
+// lookup tables for the Hilbert curve, indexed by the orientation
+var (
+	cellIJToPos = [4][4]int{
+		{0, 1, 3, 2}, // canonical order
+		{0, 3, 1, 2}, // axes swapped
+		{2, 3, 1, 0}, // bits inverted
+		{2, 1, 3, 0}, // swapped & inverted
+	}
+	cellPosToIJ = [4][4]int{
+		{0, 1, 3, 2},
+		{0, 2, 3, 1},
+		{3, 2, 0, 1},
+		{3, 1, 0, 2},
+	}
+	cellPosToOrientation = [4]int{cellSwapMask, 0, 0, cellInvMask | cellSwapMask}
+)
+
+// NewCellIDFromPoint returns the leaf, level 30, cell containing the lng/lat point.
+// Use CellID.Parent to get the cell at a lower level.
+func NewCellIDFromPoint(p *Point) CellID {
+	face, u, v := cellXYZToFaceUV(cellPointToXYZ(p))
+	return cellIDFromFaceIJ(face, cellSTToIJ(cellUVToST(u)), cellSTToIJ(cellUVToST(v)))
+}
+
+// NewCellIDFromFace returns the level 0 cell for the face of the cube, 0 to 5.
+func NewCellIDFromFace(face int) CellID {
+	return CellID(uint64(face)<<cellPosBits + 1<<(cellPosBits-1))
+}
+
+// NewCellIDFromToken returns the cell for the token, see CellID.ToToken.
+// Returns 0, an invalid cell, if the token can not be parsed.
+func NewCellIDFromToken(token string) CellID {
+	if len(token) > 16 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(token, 16, 64)
+	if err != nil {
+		return 0
+	}
+
+	return CellID(id << (4 * uint(16-len(token))))
+}
+
+// ToToken returns a compact hex representation of the cell id,
+// with the trailing zeros removed.
+func (c CellID) ToToken() string {
+	if c == 0 {
+		return "X"
+	}
+
+	s := strconv.FormatUint(uint64(c), 16)
+	s = strings.Repeat("0", 16-len(s)) + s
+
+	return strings.TrimRight(s, "0")
+}
+
+// Valid returns true if the id represents a valid cell.
+func (c CellID) Valid() bool {
+	return c.Face() < 6 && c.lsb()&0x1555555555555555 != 0
+}
+
+// Face returns the cube face, 0 to 5, of the cell.
+func (c CellID) Face() int {
+	return int(uint64(c) >> cellPosBits)
+}
+
+// Level returns the level of the cell, 0 for a face to 30 for a leaf cell.
+func (c CellID) Level() int {
+	return CellMaxLevel - bits.TrailingZeros64(uint64(c))>>1
+}
+
+// IsLeaf returns true if the cell is at the maximum level.
+func (c CellID) IsLeaf() bool {
+	return c&1 != 0
+}
+
+// Parent returns the cell at the previous level containing this cell.
+// The parent of a face cell is itself.
+func (c CellID) Parent() CellID {
+	level := c.Level()
+	if level == 0 {
+		return c
+	}
+
+	return c.ParentAtLevel(level - 1)
+}
+
+// ParentAtLevel returns the cell at the given level containing this cell.
+// The level must be between 0 and the level of the cell.
+func (c CellID) ParentAtLevel(level int) CellID {
+	lsb := cellLSBForLevel(level)
+	return CellID((uint64(c) & -lsb) | lsb)
+}
+
+// Children returns the four cells at the next level within this cell,
+// in the order of the Hilbert curve. Leaf cells have no children
+// and return four copies of themselves.
+func (c CellID) Children() [4]CellID {
+	if c.IsLeaf() {
+		return [4]CellID{c, c, c, c}
+	}
+
+	lsb := c.lsb()
+	child := uint64(c) - lsb + lsb>>2
+
+	var result [4]CellID
+	for i := range result {
+		result[i] = CellID(child)
+		child += lsb >> 1
+	}
+
+	return result
+}
+
+// Next returns the next cell at the same level along the Hilbert curve.
+// The cell after the last cell of a face is the first cell of the next face.
+func (c CellID) Next() CellID {
+	return CellID(uint64(c) + c.lsb()<<1)
+}
+
+// RangeMin returns the smallest leaf cell id contained in the cell.
+func (c CellID) RangeMin() CellID {
+	return CellID(uint64(c) - (c.lsb() - 1))
+}
+
+// RangeMax returns the largest leaf cell id contained in the cell.
+// All the descendants of a cell are between RangeMin and RangeMax inclusive.
+func (c CellID) RangeMax() CellID {
+	return CellID(uint64(c) + (c.lsb() - 1))
+}
+
+// Contains returns true if the other cell is this cell or one of its descendants.
+func (c CellID) Contains(other CellID) bool {
+	return other >= c.RangeMin() && other <= c.RangeMax()
+}
+
+// Neighbors returns the four cells at the same level sharing an edge with this cell.
+// They are in the order down, right, up and left in the coordinates of the face.
+func (c CellID) Neighbors() [4]CellID {
+	level := c.Level()
+	size := cellSizeIJ(level)
+	face, i, j := c.faceIJ()
+
+	return [4]CellID{
+		cellIDFromFaceIJWrap(face, i, j-size).ParentAtLevel(level),
+		cellIDFromFaceIJWrap(face, i+size, j).ParentAtLevel(level),
+		cellIDFromFaceIJWrap(face, i, j+size).ParentAtLevel(level),
+		cellIDFromFaceIJWrap(face, i-size, j).ParentAtLevel(level),
+	}
+}
+
+// Center returns the lng/lat center of the cell.
+func (c CellID) Center() *Point {
+	face, i, j := c.faceIJ()
+	size := cellSizeIJ(c.Level())
+
+	u := cellSTToUV((float64(i) + float64(size)/2) / cellMaxSize)
+	v := cellSTToUV((float64(j) + float64(size)/2) / cellMaxSize)
+
+	return cellXYZToPoint(cellFaceUVToXYZ(face, u, v))
+}
+
+// Vertices returns the lng/lat corners of the cell in counter clockwise order.
+// The edges of the cell are great circle arcs between the vertices.
+func (c CellID) Vertices() [4]*Point {
+	face, u0, u1, v0, v1 := c.uvBound()
+
+	return [4]*Point{
+		cellXYZToPoint(cellFaceUVToXYZ(face, u0, v0)),
+		cellXYZToPoint(cellFaceUVToXYZ(face, u1, v0)),
+		cellXYZToPoint(cellFaceUVToXYZ(face, u1, v1)),
+		cellXYZToPoint(cellFaceUVToXYZ(face, u0, v1)),
+	}
+}
+
+// Bound returns a lng/lat bound containing the cell. Since the edges are great
+// circle arcs the latitude range may extend beyond the vertices. The bound crosses
+// the anti-meridian if the cell does, and covers all longitudes if the cell contains a pole.
+func (c CellID) Bound() *Bound {
+	face, u0, u1, v0, v1 := c.uvBound()
+	corners := [4][3]float64{
+		cellFaceUVToXYZ(face, u0, v0),
+		cellFaceUVToXYZ(face, u1, v0),
+		cellFaceUVToXYZ(face, u1, v1),
+		cellFaceUVToXYZ(face, u0, v1),
+	}
+
+	south, north := 90.0, -90.0
+	for i := range corners {
+		a, b := corners[i], corners[(i+1)%4]
+		lo, hi := cellArcLatRange(a, b)
+
+		south = math.Min(south, lo)
+		north = math.Max(north, hi)
+	}
+
+	// faces 2 and 5 are centered on the poles
+	containsPole := (face == 2 || face == 5) && u0 <= 0 && u1 >= 0 && v0 <= 0 && v1 >= 0
+	if containsPole && face == 2 {
+		return NewBound(-180, 180, south, 90)
+	} else if containsPole {
+		return NewBound(-180, 180, -90, north)
+	}
+
+	// the longitude is monotonic along each edge, so the range is
+	// the smallest one containing the vertices.
+	first := cellXYZToPoint(corners[0]).Lng()
+	west, east := first, first
+	for _, xyz := range corners[1:] {
+		lng := first + normalizeLng(cellXYZToPoint(xyz).Lng()-first)
+		west = math.Min(west, lng)
+		east = math.Max(east, lng)
+	}
+
+	if east-west >= 360 {
+		return NewBound(-180, 180, south, north)
+	}
+
+	return NewGeoBound(normalizeLng(west), normalizeLng(east), south, north)
+}
+
+// CellsCoveringBound returns a sorted set of cells, between the min and max levels,
+// whose union covers the bound. Cells completely within the bound are not subdivided
+// and cells are only subdivided while the result has at most maxCells cells,
+// though more may be returned if required by the minimum level.
+// Cells are tested against the bound using their lng/lat bound, so some cells
+// near the edges may not actually intersect the bound.
+func CellsCoveringBound(b *Bound, minLevel, maxLevel, maxCells int) []CellID {
+	var queue, result []CellID
+	for face := 0; face < 6; face++ {
+		if c := NewCellIDFromFace(face); c.Bound().Intersects(b) {
+			queue = append(queue, c)
+		}
+	}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		level := c.Level()
+		cellBound := c.Bound()
+
+		if level >= minLevel && (level >= maxLevel || cellBoundWithin(cellBound, b)) {
+			result = append(result, c)
+			continue
+		}
+
+		var children []CellID
+		for _, child := range c.Children() {
+			if child.Bound().Intersects(b) {
+				children = append(children, child)
+			}
+		}
+
+		// subdividing would use too many cells
+		if level >= minLevel && len(result)+len(queue)+len(children) > maxCells {
+			result = append(result, c)
+			continue
+		}
+
+		queue = append(queue, children...)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return cellNormalize(result, minLevel)
+}
+
+// cellNormalize replaces groups of four siblings with their parent
+// if it is at least the minimum level. The cells must be sorted.
+func cellNormalize(cells []CellID, minLevel int) []CellID {
+	result := make([]CellID, 0, len(cells))
+	for _, c := range cells {
+		result = append(result, c)
+
+		for len(result) >= 4 {
+			last := result[len(result)-4:]
+			parent := last[0].Parent()
+			if last[0].Level() <= minLevel || parent == last[0] {
+				break
+			}
+
+			children := parent.Children()
+			if last[0] != children[0] || last[1] != children[1] ||
+				last[2] != children[2] || last[3] != children[3] {
+				break
+			}
+
+			result = append(result[:len(result)-4], parent)
+		}
+	}
+
+	return result
+}
+
+// cellBoundWithin returns true if inner is within outer, supporting
+// bounds crossing the anti-meridian.
+func cellBoundWithin(inner, outer *Bound) bool {
+	if inner.South() < outer.South() || inner.North() > outer.North() {
+		return false
+	}
+
+	if outer.West() == -180 && outer.East() == 180 {
+		return true
+	}
+
+	if inner.West() == -180 && inner.East() == 180 {
+		return false
+	}
+
+	// compare the longitude ranges relative to the west of the outer bound
+	offset := func(lng float64) float64 {
+		d := math.Mod(lng-outer.West(), 360)
+		if d < 0 {
+			d += 360
+		}
+		return d
+	}
+
+	width := offset(outer.East())
+	start := offset(inner.West())
+	end := start + inner.Width()
+
+	return end <= width
+}
+
+func (c CellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+func cellLSBForLevel(level int) uint64 {
+	return 1 << uint(2*(CellMaxLevel-level))
+}
+
+func cellSizeIJ(level int) int {
+	return 1 << uint(CellMaxLevel-level)
+}
+
+// faceIJ returns the face and the i, j coordinates of the lower left leaf cell.
+func (c CellID) faceIJ() (face, i, j int) {
+	face = c.Face()
+	orientation := face & cellSwapMask
+
+	for k := CellMaxLevel - 1; k >= 0; k-- {
+		pos := int(uint64(c)>>uint(2*k+1)) & 3
+		ij := cellPosToIJ[orientation][pos]
+
+		i = i<<1 | ij>>1
+		j = j<<1 | ij&1
+		orientation ^= cellPosToOrientation[pos]
+	}
+
+	// the bits below the level are the trailing marker
+	mask := cellSizeIJ(c.Level()) - 1
+	return face, i &^ mask, j &^ mask
+}
+
+func (c CellID) uvBound() (face int, u0, u1, v0, v1 float64) {
+	face, i, j := c.faceIJ()
+	size := cellSizeIJ(c.Level())
+
+	u0 = cellSTToUV(float64(i) / cellMaxSize)
+	u1 = cellSTToUV(float64(i+size) / cellMaxSize)
+	v0 = cellSTToUV(float64(j) / cellMaxSize)
+	v1 = cellSTToUV(float64(j+size) / cellMaxSize)
+
+	return
+}
+
+func cellIDFromFaceIJ(face, i, j int) CellID {
+	id := uint64(face)
+	orientation := face & cellSwapMask
+
+	for k := CellMaxLevel - 1; k >= 0; k-- {
+		ij := (i>>uint(k)&1)<<1 | j>>uint(k)&1
+		pos := cellIJToPos[orientation][ij]
+
+		id = id<<2 | uint64(pos)
+		orientation ^= cellPosToOrientation[pos]
+	}
+
+	return CellID(id<<1 | 1)
+}
+
+// cellIDFromFaceIJWrap returns the leaf cell for i, j coordinates that may be
+// just outside the face, in which case the cell on the adjacent face is returned.
+func cellIDFromFaceIJWrap(face, i, j int) CellID {
+	if i >= 0 && j >= 0 && i < cellMaxSize && j < cellMaxSize {
+		return cellIDFromFaceIJ(face, i, j)
+	}
+
+	i = cellClamp(i, -1, cellMaxSize)
+	j = cellClamp(j, -1, cellMaxSize)
+
+	// the projection is linear near the edges, so it is safe to
+	// convert to u, v directly and reproject onto the other face.
+	const scale = 1.0 / cellMaxSize
+	limit := math.Nextafter(1, 2)
+	u := math.Max(-limit, math.Min(limit, scale*float64(2*i+1-cellMaxSize)))
+	v := math.Max(-limit, math.Min(limit, scale*float64(2*j+1-cellMaxSize)))
+
+	face, u, v = cellXYZToFaceUV(cellFaceUVToXYZ(face, u, v))
+	return cellIDFromFaceIJ(face, cellSTToIJ(0.5*(u+1)), cellSTToIJ(0.5*(v+1)))
+}
+
+func cellPointToXYZ(p *Point) [3]float64 {
+	sinLat, cosLat := math.Sincos(deg2rad(p.Lat()))
+	sinLng, cosLng := math.Sincos(deg2rad(p.Lng()))
+
+	return [3]float64{cosLat * cosLng, cosLat * sinLng, sinLat}
+}
+
+func cellXYZToPoint(xyz [3]float64) *Point {
+	return &Point{
+		rad2deg(math.Atan2(xyz[1], xyz[0])),
+		rad2deg(math.Atan2(xyz[2], math.Hypot(xyz[0], xyz[1]))),
+	}
+}
+
+func cellXYZToFaceUV(xyz [3]float64) (face int, u, v float64) {
+	x, y, z := xyz[0], xyz[1], xyz[2]
+
+	switch ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z); {
+	case ax > ay && ax > az:
+		face = 0
+		if x < 0 {
+			face = 3
+		}
+	case ay > az:
+		face = 1
+		if y < 0 {
+			face = 4
+		}
+	default:
+		face = 2
+		if z < 0 {
+			face = 5
+		}
+	}
+
+	switch face {
+	case 0:
+		u, v = y/x, z/x
+	case 1:
+		u, v = -x/y, z/y
+	case 2:
+		u, v = -x/z, -y/z
+	case 3:
+		u, v = z/x, y/x
+	case 4:
+		u, v = z/y, -x/y
+	default:
+		u, v = -y/z, -x/z
+	}
+
+	return face, u, v
+}
+
+func cellFaceUVToXYZ(face int, u, v float64) [3]float64 {
+	var xyz [3]float64
+	switch face {
+	case 0:
+		xyz = [3]float64{1, u, v}
+	case 1:
+		xyz = [3]float64{-u, 1, v}
+	case 2:
+		xyz = [3]float64{-u, -v, 1}
+	case 3:
+		xyz = [3]float64{-1, -v, -u}
+	case 4:
+		xyz = [3]float64{v, -1, -u}
+	default:
+		xyz = [3]float64{v, u, -1}
+	}
+
+	n := math.Sqrt(xyz[0]*xyz[0] + xyz[1]*xyz[1] + xyz[2]*xyz[2])
+	return [3]float64{xyz[0] / n, xyz[1] / n, xyz[2] / n}
+}
+
+// cellSTToUV and cellUVToST implement the quadratic projection that makes
+// the cells at each level closer to the same size.
+func cellSTToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (1.0 / 3.0) * (4*s*s - 1)
+	}
+
+	return (1.0 / 3.0) * (1 - 4*(1-s)*(1-s))
+}
+
+func cellUVToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+func cellSTToIJ(s float64) int {
+	return cellClamp(int(math.Floor(cellMaxSize*s)), 0, cellMaxSize-1)
+}
+
+func cellClamp(i, lo, hi int) int {
+	if i < lo {
+		return lo
+	}
+
+	if i > hi {
+		return hi
+	}
+
+	return i
+}
+
+// cellArcLatRange returns the latitude range, in degrees,
+// of the great circle arc between the unit vectors.
+func cellArcLatRange(a, b [3]float64) (south, north float64) {
+	latA := rad2deg(math.Asin(a[2]))
+	latB := rad2deg(math.Asin(b[2]))
+	south, north = math.Min(latA, latB), math.Max(latA, latB)
+
+	n := cellCross(a, b)
+	nn := cellDot(n, n)
+	if nn == 0 {
+		return
+	}
+
+	// the points of the great circle closest to the poles,
+	// the projection of the z axis onto the plane of the circle
+	p := [3]float64{-n[0] * n[2] / nn, -n[1] * n[2] / nn, 1 - n[2]*n[2]/nn}
+	length := math.Sqrt(cellDot(p, p))
+	if length == 0 {
+		return
+	}
+
+	for _, sign := range []float64{1, -1} {
+		q := [3]float64{sign * p[0] / length, sign * p[1] / length, sign * p[2] / length}
+
+		// within the arc if it is between a and b
+		if cellDot(cellCross(a, q), n) >= 0 && cellDot(cellCross(q, b), n) >= 0 {
+			lat := rad2deg(math.Asin(math.Max(-1, math.Min(1, q[2]))))
+			south = math.Min(south, lat)
+			north = math.Max(north, lat)
+		}
+	}
+
+	return
+}
+
+func cellCross(a, b [3]float64) [3]float64 {
+	return [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func cellDot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
@@ -0,0 +1,279 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewCellIDFromPoint(t *testing.T) {
+	// the center of face 0
+	c := NewCellIDFromPoint(NewPoint(0, 0))
+	if c != 0x1000000000000001 {
+		t.Errorf("cell, fromPoint expected 0x1000000000000001, got %x", uint64(c))
+	}
+
+	if c.Face() != 0 || c.Level() != 30 || !c.IsLeaf() || !c.Valid() {
+		t.Errorf("cell, incorrect leaf cell %x", uint64(c))
+	}
+
+	// the faces
+	faces := []*Point{
+		NewPoint(0, 0), NewPoint(90, 0), NewPoint(0, 90),
+		NewPoint(180, 0), NewPoint(-90, 0), NewPoint(0, -90),
+	}
+
+	for i, p := range faces {
+		c := NewCellIDFromPoint(p)
+		if c.Face() != i {
+			t.Errorf("cell, expected face %d, got %d", i, c.Face())
+		}
+
+		if f := c.ParentAtLevel(0); f != NewCellIDFromFace(i) {
+			t.Errorf("cell, expected face cell %x, got %x", uint64(NewCellIDFromFace(i)), uint64(f))
+		}
+	}
+
+	// the center of a cell should be within the cell
+	for _, city := range cities {
+		leaf := NewCellIDFromPoint(NewPoint(city[1], city[0]))
+		for level := 0; level <= CellMaxLevel; level += 3 {
+			c := leaf.ParentAtLevel(level)
+			if center := NewCellIDFromPoint(c.Center()); !c.Contains(center) {
+				t.Errorf("cell, center not in cell %v at level %d", city, level)
+			}
+
+			if !c.Bound().Pad(1e-9).Contains(NewPoint(city[1], city[0])) {
+				t.Errorf("cell, bound does not contain %v at level %d: %v", city, level, c.Bound())
+			}
+		}
+	}
+}
+
+func TestCellIDToken(t *testing.T) {
+	cases := []struct {
+		cell  CellID
+		token string
+	}{
+		{0x1000000000000001, "1000000000000001"},
+		{NewCellIDFromFace(0), "1"},
+		{NewCellIDFromFace(5), "b"},
+		{0x89c259c000000000, "89c259c"},
+		{0, "X"},
+	}
+
+	for _, tc := range cases {
+		if token := tc.cell.ToToken(); token != tc.token {
+			t.Errorf("cell, toToken expected %v, got %v", tc.token, token)
+		}
+
+		if tc.cell == 0 {
+			continue
+		}
+
+		if c := NewCellIDFromToken(tc.token); c != tc.cell {
+			t.Errorf("cell, fromToken expected %x, got %x", uint64(tc.cell), uint64(c))
+		}
+	}
+
+	if c := NewCellIDFromToken("not a token"); c != 0 {
+		t.Errorf("cell, fromToken expected 0 for invalid token, got %x", uint64(c))
+	}
+}
+
+func TestCellIDHierarchy(t *testing.T) {
+	leaf := NewCellIDFromPoint(NewPoint(-122.4167, 37.7833))
+
+	c := leaf.ParentAtLevel(10)
+	if c.Level() != 10 || !c.Valid() {
+		t.Errorf("cell, parentAtLevel expected level 10, got %d", c.Level())
+	}
+
+	if p := c.Parent(); p.Level() != 9 || !p.Contains(c) || !p.Contains(leaf) {
+		t.Errorf("cell, parent incorrect %x", uint64(p))
+	}
+
+	if f := NewCellIDFromFace(3); f.Parent() != f {
+		t.Errorf("cell, parent of face should be itself")
+	}
+
+	children := c.Children()
+	for i, child := range children {
+		if child.Parent() != c || child.Level() != 11 {
+			t.Errorf("cell, child %d incorrect %x", i, uint64(child))
+		}
+
+		if i > 0 && children[i-1].Next() != child {
+			t.Errorf("cell, children expected along the curve")
+		}
+	}
+
+	if c.RangeMin() != children[0].RangeMin() || c.RangeMax() != children[3].RangeMax() {
+		t.Errorf("cell, range should match the children")
+	}
+
+	if c.Contains(c.Next()) || !c.Contains(c) {
+		t.Errorf("cell, contains incorrect")
+	}
+
+	if ch := leaf.Children(); ch[0] != leaf {
+		t.Errorf("cell, leaf children should be the leaf")
+	}
+}
+
+func TestCellIDNeighbors(t *testing.T) {
+	shared := func(a, b CellID) int {
+		count := 0
+		for _, v1 := range a.Vertices() {
+			for _, v2 := range b.Vertices() {
+				if v1.DistanceFrom(v2) < 1e-9 {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	// including cells on the edges and corners of faces
+	cells := []CellID{
+		NewCellIDFromPoint(NewPoint(-122.4167, 37.7833)).ParentAtLevel(12),
+		NewCellIDFromFace(0).Children()[0].Children()[0],
+		NewCellIDFromPoint(NewPoint(45, 35.26)).ParentAtLevel(5),
+		NewCellIDFromPoint(NewPoint(0, 90)).ParentAtLevel(3),
+	}
+
+	for _, c := range cells {
+		for i, n := range c.Neighbors() {
+			if n.Level() != c.Level() || n == c {
+				t.Errorf("cell, neighbor %d incorrect for %v", i, c.ToToken())
+			}
+
+			if s := shared(c, n); s != 2 {
+				t.Errorf("cell, neighbor %d of %v should share an edge, shares %d vertices", i, c.ToToken(), s)
+			}
+		}
+	}
+
+	// the Hilbert curve moves to an adjacent cell
+	c := NewCellIDFromFace(2).ParentAtLevel(0).Children()[1].Children()[2].Children()[0]
+	for i := 0; i < 100; i++ {
+		next := c.Next()
+
+		found := false
+		for _, n := range c.Neighbors() {
+			found = found || n == next
+		}
+
+		if !found {
+			t.Errorf("cell, next should be a neighbor %v %v", c.ToToken(), next.ToToken())
+		}
+
+		c = next
+	}
+}
+
+func TestCellIDBound(t *testing.T) {
+	// face 0 is centered on lng/lat 0, 0
+	b := NewCellIDFromFace(0).Bound()
+	if math.Abs(b.West()+45) > 1e-9 || math.Abs(b.East()-45) > 1e-9 {
+		t.Errorf("cell, face 0 bound incorrect %v", b)
+	}
+
+	// the edges bulge to 45 degrees at the center
+	if math.Abs(b.North()-45) > 1e-9 || math.Abs(b.South()+45) > 1e-9 {
+		t.Errorf("cell, face 0 bound incorrect %v", b)
+	}
+
+	// contains the pole
+	b = NewCellIDFromFace(2).Bound()
+	if b.North() != 90 || b.West() != -180 || b.East() != 180 {
+		t.Errorf("cell, face 2 bound incorrect %v", b)
+	}
+
+	// crossing the anti-meridian
+	b = NewCellIDFromPoint(NewPoint(180, 0)).ParentAtLevel(5).Bound()
+	if !b.CrossesAntimeridian() && b.East() != 180 && b.West() != -180 {
+		t.Errorf("cell, bound should be at the anti-meridian %v", b)
+	}
+
+	// vertices should be in the bound and counter clockwise
+	c := NewCellIDFromPoint(NewPoint(-122.4167, 37.7833)).ParentAtLevel(8)
+	ring := PointSet{}
+	for _, v := range c.Vertices() {
+		if !c.Bound().Pad(1e-9).Contains(v) {
+			t.Errorf("cell, vertex %v not in bound", v)
+		}
+		ring = append(ring, *v)
+	}
+
+	if !ring.IsCounterClockwise() {
+		t.Errorf("cell, vertices should be counter clockwise")
+	}
+}
+
+func TestCellsCoveringBound(t *testing.T) {
+	bound := NewBound(-122.5, -122.3, 37.7, 37.8)
+	cells := CellsCoveringBound(bound, 0, 14, 20)
+
+	if len(cells) == 0 || len(cells) > 20 {
+		t.Fatalf("cell, covering expected up to 20 cells, got %d", len(cells))
+	}
+
+	for i := 1; i < len(cells); i++ {
+		if cells[i-1] >= cells[i] {
+			t.Errorf("cell, covering should be sorted")
+		}
+	}
+
+	covered := func(p *Point) bool {
+		leaf := NewCellIDFromPoint(p)
+		for _, c := range cells {
+			if c.Contains(leaf) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			p := NewPoint(-122.5+0.02*float64(x), 37.7+0.01*float64(y))
+			if !covered(p) {
+				t.Errorf("cell, covering does not contain %v", p)
+			}
+		}
+	}
+
+	// fixed level
+	cells = CellsCoveringBound(bound, 10, 10, 1)
+	for _, c := range cells {
+		if c.Level() != 10 {
+			t.Errorf("cell, covering expected level 10, got %d", c.Level())
+		}
+	}
+
+	// the whole world are the faces
+	cells = CellsCoveringBound(NewBound(-180, 180, -90, 90), 0, 30, 6)
+	if len(cells) != 6 {
+		t.Errorf("cell, covering world expected 6 faces, got %d", len(cells))
+	}
+
+	// crossing the anti-meridian
+	cells = CellsCoveringBound(NewGeoBound(179, -179, -1, 1), 0, 10, 50)
+	for _, c := range cells {
+		if !c.Bound().Intersects(NewGeoBound(179, -179, -1, 1)) {
+			t.Errorf("cell, covering cell %v outside the bound", c.ToToken())
+		}
+	}
+
+	if len(cells) == 0 || !func() bool {
+		leaf := NewCellIDFromPoint(NewPoint(180, 0))
+		for _, c := range cells {
+			if c.Contains(leaf) {
+				return true
+			}
+		}
+		return false
+	}() {
+		t.Errorf("cell, covering should contain the anti-meridian")
+	}
+}
@@ -0,0 +1,276 @@
+package geo
+
+import "math"
+
+// ClipToBound returns the parts of the line within the bound as new lines,
+// or nil if the line is completely outside. Computed using the
+// Liang-Barsky algorithm. The original line is not modified.
+// Bounds crossing the anti-meridian are split, see Bound.SplitAtAntimeridian,
+// and the part within the west side comes before the part within the east side.
+// A bound not crossing the anti-meridian results in at most one line.
+func (l *Line) ClipToBound(b *Bound) []*Line {
+	var result []*Line
+	for _, part := range b.SplitAtAntimeridian() {
+		t0, t1, ok := liangBarsky(part, &l.a, &l.b)
+		if ok {
+			result = append(result, NewLine(clipPointAt(part, &l.a, &l.b, t0), clipPointAt(part, &l.a, &l.b, t1)))
+		}
+	}
+
+	return result
+}
+
+// ClipToBound returns the points within the bound as a new point set.
+// Points on the boundary are considered within.
+func (ps PointSet) ClipToBound(b *Bound) *PointSet {
+	result := PointSet{}
+	for i := range ps {
+		if b.Contains(&ps[i]) {
+			result = append(result, ps[i])
+		}
+	}
+
+	return &result
+}
+
+// ClipRingToBound treats the point set as a ring and clips it to the bound
+// using the Sutherland-Hodgman algorithm. The results are new closed rings,
+// the first point is repeated at the end, with the same orientation.
+// Parts of the ring outside the bound are replaced by edges along the boundary,
+// so a concave ring may result in degenerate, zero width, edges.
+// Returns nil if the ring is completely outside.
+// Bounds crossing the anti-meridian are split, see Bound.SplitAtAntimeridian,
+// and the ring within the west side comes before the ring within the east side.
+// A bound not crossing the anti-meridian results in at most one ring.
+func (ps PointSet) ClipRingToBound(b *Bound) []*PointSet {
+	var result []*PointSet
+	for _, part := range b.SplitAtAntimeridian() {
+		if ring := clipRingToBound(ps, part); len(ring) != 0 {
+			result = append(result, &ring)
+		}
+	}
+
+	return result
+}
+
+// clipRingToBound returns the ring clipped to the bound, or nil if it is outside.
+// The bound must not cross the anti-meridian.
+func clipRingToBound(ps PointSet, b *Bound) PointSet {
+	ring := make(PointSet, len(ps))
+	copy(ring, ps)
+
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+
+	// clip against each side of the bound, the inside functions
+	// check the point is on the inside of the side.
+	sides := [4]struct {
+		inside func(p *Point) bool
+		dim    int
+		value  float64
+	}{
+		{func(p *Point) bool { return p[0] >= b.sw[0] }, 0, b.sw[0]},
+		{func(p *Point) bool { return p[0] <= b.ne[0] }, 0, b.ne[0]},
+		{func(p *Point) bool { return p[1] >= b.sw[1] }, 1, b.sw[1]},
+		{func(p *Point) bool { return p[1] <= b.ne[1] }, 1, b.ne[1]},
+	}
+
+	for _, side := range sides {
+		if len(ring) == 0 {
+			break
+		}
+
+		input := ring
+		ring = make(PointSet, 0, len(input)+4)
+
+		prev := &input[len(input)-1]
+		for i := range input {
+			current := &input[i]
+
+			if side.inside(current) {
+				if !side.inside(prev) {
+					ring = append(ring, clipCrossing(prev, current, side.dim, side.value))
+				}
+				ring = append(ring, *current)
+			} else if side.inside(prev) {
+				ring = append(ring, clipCrossing(prev, current, side.dim, side.value))
+			}
+
+			prev = current
+		}
+	}
+
+	if len(ring) < 3 {
+		return nil
+	}
+
+	return append(ring, ring[0])
+}
+
+// ClipToBound returns the parts of the path within the bound as new paths.
+// A new path is started every time the path enters the bound, with new points
+// added where the path crosses the boundary. Segments that only touch the
+// bound at a single point are not included. The original path is not modified.
+// Bounds crossing the anti-meridian are split, see Bound.SplitAtAntimeridian,
+// and the paths within the west side come before those within the east side.
+func (p *Path) ClipToBound(b *Bound) []*Path {
+	points := p.PointSet
+	if len(points) == 1 {
+		if b.Contains(&points[0]) {
+			return []*Path{{PointSet{points[0]}}}
+		}
+		return nil
+	}
+
+	var result []*Path
+	for _, part := range b.SplitAtAntimeridian() {
+		result = clipPathToBound(points, part, result)
+	}
+
+	return result
+}
+
+// clipPathToBound appends the parts of the path within the bound to the result.
+// The bound must not cross the anti-meridian.
+func clipPathToBound(points PointSet, b *Bound, result []*Path) []*Path {
+	var current *Path
+	for i := 0; i < len(points)-1; i++ {
+		t0, t1, ok := liangBarsky(b, &points[i], &points[i+1])
+		if !ok || (t0 == t1 && points[i] != points[i+1]) {
+			current = nil
+			continue
+		}
+
+		start := clipPointAt(b, &points[i], &points[i+1], t0)
+		end := clipPointAt(b, &points[i], &points[i+1], t1)
+
+		if current == nil || t0 > 0 {
+			current = &Path{PointSet{*start}}
+			result = append(result, current)
+		}
+
+		current.PointSet = append(current.PointSet, *end)
+
+		if t1 < 1 {
+			current = nil
+		}
+	}
+
+	return result
+}
+
+// ClipToBound clips the rings of the polygon to the bound using
+// the Sutherland-Hodgman algorithm, see PointSet.ClipRingToBound.
+// Holes completely outside the bound are removed and an empty multi polygon
+// is returned if the outer ring is outside. The original polygon is not modified.
+// Bounds crossing the anti-meridian are split, see Bound.SplitAtAntimeridian,
+// and the polygon within the west side comes before the polygon within the east side.
+// A bound not crossing the anti-meridian results in at most one polygon.
+func (p Polygon) ClipToBound(b *Bound) *MultiPolygon {
+	return MultiPolygon{p}.ClipToBound(b)
+}
+
+// ClipToBound clips each of the polygons to the bound, see Polygon.ClipToBound.
+// Polygons completely outside the bound are removed. Bounds crossing the anti-meridian
+// are split, see Bound.SplitAtAntimeridian, and the polygons are clipped to both sides.
+func (mp MultiPolygon) ClipToBound(b *Bound) *MultiPolygon {
+	result := MultiPolygon{}
+	for _, part := range b.SplitAtAntimeridian() {
+		for _, p := range mp {
+			if clipped := clipPolygonToBound(p, part); len(clipped) != 0 {
+				result = append(result, clipped)
+			}
+		}
+	}
+
+	return &result
+}
+
+// clipPolygonToBound returns the polygon clipped to the bound, or nil if it is outside.
+// The bound must not cross the anti-meridian.
+func clipPolygonToBound(p Polygon, b *Bound) Polygon {
+	var result Polygon
+	for i, ring := range p {
+		clipped := clipRingToBound(ring, b)
+		if len(clipped) == 0 {
+			if i == 0 {
+				return nil
+			}
+			continue
+		}
+
+		result = append(result, clipped)
+	}
+
+	return result
+}
+
+// liangBarsky returns the range of the parameter t, where a + t*(b - a),
+// of the part of the segment within the bound.
+func liangBarsky(bound *Bound, a, b *Point) (t0, t1 float64, ok bool) {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+
+	checks := [4][2]float64{
+		{-dx, a[0] - bound.sw[0]},
+		{dx, bound.ne[0] - a[0]},
+		{-dy, a[1] - bound.sw[1]},
+		{dy, bound.ne[1] - a[1]},
+	}
+
+	t0, t1 = 0, 1
+	for _, c := range checks {
+		p, q := c[0], c[1]
+		if p == 0 {
+			// parallel to this side
+			if q < 0 {
+				return 0, 0, false
+			}
+			continue
+		}
+
+		r := q / p
+		if p < 0 {
+			// entering
+			if r > t1 {
+				return 0, 0, false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			// leaving
+			if r < t0 {
+				return 0, 0, false
+			}
+			t1 = math.Min(t1, r)
+		}
+	}
+
+	return t0, t1, true
+}
+
+// clipPointAt returns the point on the segment at t, kept within the
+// bound to avoid rounding errors. The endpoints are returned exactly.
+func clipPointAt(bound *Bound, a, b *Point, t float64) *Point {
+	switch t {
+	case 0:
+		return a.Clone()
+	case 1:
+		return b.Clone()
+	}
+
+	return &Point{
+		math.Max(bound.sw[0], math.Min(bound.ne[0], a[0]+t*(b[0]-a[0]))),
+		math.Max(bound.sw[1], math.Min(bound.ne[1], a[1]+t*(b[1]-a[1]))),
+	}
+}
+
+// clipCrossing returns the point where the segment crosses the line
+// with the given value in the dimension, 0 for x and 1 for y.
+func clipCrossing(a, b *Point, dim int, value float64) Point {
+	t := (value - a[dim]) / (b[dim] - a[dim])
+
+	p := Point{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+	p[dim] = value
+
+	return p
+}
@@ -0,0 +1,283 @@
+package geo
+
+import "testing"
+
+func TestLineClipToBound(t *testing.T) {
+	bound := NewBound(0, 10, 0, 10)
+
+	cases := []struct {
+		line     *Line
+		expected *Line
+	}{
+		{NewLine(NewPoint(1, 1), NewPoint(2, 2)), NewLine(NewPoint(1, 1), NewPoint(2, 2))},
+		{NewLine(NewPoint(-5, 5), NewPoint(15, 5)), NewLine(NewPoint(0, 5), NewPoint(10, 5))},
+		{NewLine(NewPoint(5, 5), NewPoint(5, 20)), NewLine(NewPoint(5, 5), NewPoint(5, 10))},
+		{NewLine(NewPoint(-5, 0), NewPoint(5, 10)), NewLine(NewPoint(0, 5), NewPoint(5, 10))},
+		{NewLine(NewPoint(-5, -5), NewPoint(-1, 20)), nil},
+		{NewLine(NewPoint(11, 0), NewPoint(11, 10)), nil},
+	}
+
+	for i, tc := range cases {
+		lines := tc.line.ClipToBound(bound)
+		if tc.expected == nil {
+			if lines != nil {
+				t.Errorf("line, clipToBound %d expected nil, got %v", i, lines)
+			}
+			continue
+		}
+
+		if len(lines) != 1 || !lines[0].Equals(tc.expected) {
+			t.Errorf("line, clipToBound %d expected %v, got %v", i, tc.expected, lines)
+		}
+	}
+}
+
+func TestPointSetClipToBound(t *testing.T) {
+	ps := PointSet{{0, 0}, {5, 5}, {11, 5}, {10, 10}, {-1, -1}}
+
+	clipped := ps.ClipToBound(NewBound(0, 10, 0, 10))
+	expected := PointSet{{0, 0}, {5, 5}, {10, 10}}
+	if !clipped.Equals(&expected) {
+		t.Errorf("pointset, clipToBound expected %v, got %v", expected, clipped)
+	}
+
+	if len(ps) != 5 {
+		t.Errorf("pointset, clipToBound should not modify the original, got %v", ps)
+	}
+}
+
+func TestPointSetClipRingToBound(t *testing.T) {
+	bound := NewBound(0, 10, 0, 10)
+
+	// a square overlapping the corner
+	ring := PointSet{{5, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 5}}
+	rings := ring.ClipRingToBound(bound)
+	if l := len(rings); l != 1 {
+		t.Fatalf("pointset, clipRingToBound expected 1 ring, got %d", l)
+	}
+
+	clipped := rings[0]
+
+	if a := clipped.Area(); a != 25 {
+		t.Errorf("pointset, clipRingToBound expected area 25, got %v", a)
+	}
+
+	if c := *clipped; c[0] != c[len(c)-1] {
+		t.Errorf("pointset, clipRingToBound expected a closed ring, got %v", clipped)
+	}
+
+	if !clipped.IsCounterClockwise() {
+		t.Errorf("pointset, clipRingToBound should keep the orientation, got %v", clipped)
+	}
+
+	// open, clockwise, triangle
+	ring = PointSet{{-5, 5}, {5, 15}, {5, 5}}
+	clipped = ring.ClipRingToBound(bound)[0]
+
+	if a := clipped.Area(); a != 25 {
+		t.Errorf("pointset, clipRingToBound expected area 25, got %v", a)
+	}
+
+	if !clipped.IsClockwise() {
+		t.Errorf("pointset, clipRingToBound should keep the orientation, got %v", clipped)
+	}
+
+	// contains the bound
+	ring = PointSet{{-5, -5}, {15, -5}, {15, 15}, {-5, 15}}
+	clipped = ring.ClipRingToBound(bound)[0]
+
+	if a := clipped.Area(); a != 100 {
+		t.Errorf("pointset, clipRingToBound expected area 100, got %v", a)
+	}
+
+	// outside
+	ring = PointSet{{20, 20}, {30, 20}, {30, 30}}
+	if l := len(ring.ClipRingToBound(bound)); l != 0 {
+		t.Errorf("pointset, clipRingToBound expected no rings, got %d", l)
+	}
+}
+
+func TestPathClipToBound(t *testing.T) {
+	bound := NewBound(0, 10, 0, 10)
+
+	path := NewPath()
+	path.Push(NewPoint(-5, 5))
+	path.Push(NewPoint(5, 5))
+	path.Push(NewPoint(5, 15))
+	path.Push(NewPoint(8, 15))
+	path.Push(NewPoint(8, 5))
+	path.Push(NewPoint(9, 5))
+
+	paths := path.ClipToBound(bound)
+	if l := len(paths); l != 2 {
+		t.Fatalf("path, clipToBound expected 2 paths, got %d", l)
+	}
+
+	expected := []PointSet{
+		{{0, 5}, {5, 5}, {5, 10}},
+		{{8, 10}, {8, 5}, {9, 5}},
+	}
+
+	for i := range expected {
+		if !paths[i].PointSet.Equals(&expected[i]) {
+			t.Errorf("path, clipToBound %d expected %v, got %v", i, expected[i], paths[i])
+		}
+	}
+
+	if path.Length() != 6 {
+		t.Errorf("path, clipToBound should not modify the original, got %v", path)
+	}
+
+	// crossing the whole bound
+	path = NewPath()
+	path.Push(NewPoint(-5, -5))
+	path.Push(NewPoint(15, 15))
+
+	paths = path.ClipToBound(bound)
+	if l := len(paths); l != 1 {
+		t.Fatalf("path, clipToBound expected 1 path, got %d", l)
+	}
+
+	if p := paths[0]; !p.GetAt(0).Equals(NewPoint(0, 0)) || !p.GetAt(1).Equals(NewPoint(10, 10)) {
+		t.Errorf("path, clipToBound expected crossing points, got %v", p)
+	}
+
+	// touching the corner only
+	path = NewPath()
+	path.Push(NewPoint(-5, 5))
+	path.Push(NewPoint(5, -5))
+
+	if l := len(path.ClipToBound(bound)); l != 0 {
+		t.Errorf("path, clipToBound expected no paths, got %d", l)
+	}
+
+	// outside
+	path = NewPath()
+	path.Push(NewPoint(20, 5))
+	path.Push(NewPoint(30, 5))
+
+	if l := len(path.ClipToBound(bound)); l != 0 {
+		t.Errorf("path, clipToBound expected no paths, got %d", l)
+	}
+
+	// single point
+	path = NewPath()
+	path.Push(NewPoint(5, 5))
+
+	if l := len(path.ClipToBound(bound)); l != 1 {
+		t.Errorf("path, clipToBound expected 1 path, got %d", l)
+	}
+}
+
+func TestPolygonClipToBound(t *testing.T) {
+	bound := NewBound(0, 2, 0, 2)
+
+	mp := testPolygon().ClipToBound(bound)
+	if l := len(*mp); l != 1 {
+		t.Fatalf("polygon, clipToBound expected 1 polygon, got %d", l)
+	}
+
+	if l := len((*mp)[0]); l != 2 {
+		t.Errorf("polygon, clipToBound expected 2 rings, got %d", l)
+	}
+
+	if a := mp.Area(); a != 3 {
+		t.Errorf("polygon, clipToBound expected area 3, got %v", a)
+	}
+
+	// the hole is outside
+	mp = testPolygon().ClipToBound(NewBound(2.5, 5, 2.5, 5))
+	if l := len(*mp); l != 1 || len((*mp)[0]) != 1 {
+		t.Errorf("polygon, clipToBound expected 1 ring, got %v", mp)
+	}
+
+	// the polygon is outside
+	mp = testPolygon().ClipToBound(NewBound(10, 20, 10, 20))
+	if l := len(*mp); l != 0 {
+		t.Errorf("polygon, clipToBound expected no polygons, got %v", mp)
+	}
+
+	mp = NewMultiPolygon(testPolygon(), clipSquare(10, 10, 1)).ClipToBound(bound)
+	if l := len(*mp); l != 1 {
+		t.Errorf("multi polygon, clipToBound expected 1 polygon, got %d", l)
+	}
+}
+
+func TestClipToBoundAntimeridian(t *testing.T) {
+	bound := NewGeoBound(170, -170, -10, 10)
+
+	line := NewLine(NewPoint(175, 0), NewPoint(179, 0))
+	if lines := line.ClipToBound(bound); len(lines) != 1 || !lines[0].Equals(line) {
+		t.Errorf("line, clipToBound expected %v, got %v", line, lines)
+	}
+
+	line = NewLine(NewPoint(-175, 0), NewPoint(-160, 0))
+	if lines := line.ClipToBound(bound); len(lines) != 1 || !lines[0].Equals(NewLine(NewPoint(-175, 0), NewPoint(-170, 0))) {
+		t.Errorf("line, clipToBound expected the part on the east side, got %v", lines)
+	}
+
+	// inside on both sides of the anti-meridian
+	line = NewLine(NewPoint(-175, 0), NewPoint(175, 0))
+	lines := line.ClipToBound(bound)
+	if len(lines) != 2 {
+		t.Fatalf("line, clipToBound expected 2 lines, got %v", lines)
+	}
+
+	if !lines[0].Equals(NewLine(NewPoint(170, 0), NewPoint(175, 0))) ||
+		!lines[1].Equals(NewLine(NewPoint(-175, 0), NewPoint(-170, 0))) {
+		t.Errorf("line, clipToBound incorrect, got %v", lines)
+	}
+
+	path := NewPath()
+	path.Push(NewPoint(175, 0))
+	path.Push(NewPoint(179, 0))
+
+	if paths := path.ClipToBound(bound); len(paths) != 1 || paths[0].Distance() != 4 {
+		t.Errorf("path, clipToBound expected the whole path, got %v", paths)
+	}
+
+	// the path is inside on both sides of the anti-meridian
+	path = NewPath()
+	path.Push(NewPoint(-175, 0))
+	path.Push(NewPoint(175, 0))
+
+	paths := path.ClipToBound(bound)
+	if len(paths) != 2 {
+		t.Fatalf("path, clipToBound expected 2 paths, got %v", paths)
+	}
+
+	if !paths[0].Equals(NewPath().Push(NewPoint(170, 0)).Push(NewPoint(175, 0))) ||
+		!paths[1].Equals(NewPath().Push(NewPoint(-175, 0)).Push(NewPoint(-170, 0))) {
+		t.Errorf("path, clipToBound incorrect, got %v", paths)
+	}
+
+	ring := PointSet{{172, 0}, {178, 0}, {178, 6}, {172, 6}, {172, 0}}
+	if rings := ring.ClipRingToBound(bound); len(rings) != 1 || rings[0].Area() != 36 {
+		t.Errorf("pointset, clipRingToBound expected area 36, got %v", rings)
+	}
+
+	ring = PointSet{{-172, 5}, {-165, 5}, {-165, 15}, {-172, 15}, {-172, 5}}
+	if rings := ring.ClipRingToBound(bound); len(rings) != 1 || rings[0].Area() != 10 {
+		t.Errorf("pointset, clipRingToBound expected area 10, got %v", rings)
+	}
+
+	if mp := NewPolygon(&ring).ClipToBound(bound); len(*mp) != 1 || mp.Area() != 10 {
+		t.Errorf("polygon, clipToBound expected area 10, got %v", mp)
+	}
+
+	// inside on both sides of the anti-meridian
+	ring = PointSet{{-175, 0}, {175, 0}, {175, 5}, {-175, 5}, {-175, 0}}
+	rings := ring.ClipRingToBound(bound)
+	if len(rings) != 2 || rings[0].Area() != 25 || rings[1].Area() != 25 {
+		t.Errorf("pointset, clipRingToBound expected 2 rings, got %v", rings)
+	}
+
+	if mp := NewPolygon(&ring).ClipToBound(bound); len(*mp) != 2 || mp.Area() != 50 {
+		t.Errorf("polygon, clipToBound expected 2 polygons, got %v", mp)
+	}
+
+	mp := NewMultiPolygon(clipSquare(172, 0, 6), clipSquare(-172, 0, 6), clipSquare(0, 0, 1)).ClipToBound(bound)
+	if l := len(*mp); l != 2 || mp.Area() != 36+12 {
+		t.Errorf("multi polygon, clipToBound expected 2 polygons, got %v", mp)
+	}
+}
@@ -0,0 +1,136 @@
+package geo
+
+import "math"
+
+// GRS80 is the ellipsoid of the NAD83 and ETRS89 datums.
+// It differs from WGS84 by less than a millimeter in the polar radius.
+var GRS80 = &Ellipsoid{A: 6378137.0, F: 1 / 298.257222101}
+
+// Airy1830 is the ellipsoid of the OSGB36 datum used by the British National Grid.
+var Airy1830 = &Ellipsoid{A: 6377563.396, F: 1 / 299.3249646}
+
+// A Datum is a geodetic datum, the reference ellipsoid and its position
+// relative to the earth, defined by the 7-parameter Helmert transformation to WGS84.
+type Datum struct {
+	Name      string
+	Ellipsoid *Ellipsoid
+
+	// Helmert are the parameters of the transformation from the datum to WGS84,
+	// using the position vector convention: the X, Y and Z translations in meters,
+	// the X, Y and Z rotations in arc seconds and the scale change in parts per million.
+	Helmert [7]float64
+}
+
+var (
+	// DatumWGS84 is the World Geodetic System 1984, used by GPS.
+	DatumWGS84 = &Datum{Name: "WGS 84", Ellipsoid: WGS84}
+
+	// DatumNAD83 is the North American Datum of 1983. The transformation
+	// to WGS84 is the null transformation, EPSG:1188, accurate to about 4 meters.
+	DatumNAD83 = &Datum{Name: "NAD83", Ellipsoid: GRS80}
+
+	// DatumETRS89 is the European Terrestrial Reference System 1989. The transformation
+	// to WGS84 is the null transformation, EPSG:1149, accurate to about 1 meter.
+	DatumETRS89 = &Datum{Name: "ETRS89", Ellipsoid: GRS80}
+
+	// DatumOSGB36 is the Ordnance Survey of Great Britain 1936 datum. The transformation
+	// to WGS84, EPSG:1314, is accurate to about 2 meters.
+	DatumOSGB36 = &Datum{
+		Name:      "OSGB 1936",
+		Ellipsoid: Airy1830,
+		Helmert:   [7]float64{446.448, -125.157, 542.060, 0.1502, 0.2470, 0.8421, -20.4894},
+	}
+)
+
+// BuildDatumTransform returns a projector that converts lng/lat points on the from datum
+// to lng/lat on the to datum. The points are converted to geocentric coordinates,
+// ignoring the height, and shifted to WGS84 and then to the target datum
+// using the Helmert transformations. Since the height on the target ellipsoid
+// is dropped a round trip may move the point by a few centimeters.
+func BuildDatumTransform(from, to *Datum) Projector {
+	if from == to || (*from.Ellipsoid == *to.Ellipsoid && from.Helmert == to.Helmert) {
+		return func(p *Point) {}
+	}
+
+	return func(p *Point) {
+		x, y, z := from.Ellipsoid.toGeocentric(p)
+		x, y, z = helmert(&from.Helmert, false, x, y, z)
+		x, y, z = helmert(&to.Helmert, true, x, y, z)
+		to.Ellipsoid.fromGeocentric(p, x, y, z)
+	}
+}
+
+// helmert applies the position vector Helmert transformation, or its
+// inverse if inverse is true, to the geocentric coordinates.
+func helmert(params *[7]float64, inverse bool, x, y, z float64) (float64, float64, float64) {
+	if *params == [7]float64{} {
+		return x, y, z
+	}
+
+	arcSecond := math.Pi / (180 * 3600)
+	rx, ry, rz := params[3]*arcSecond, params[4]*arcSecond, params[5]*arcSecond
+	s := 1 + params[6]*1e-6
+
+	m := [3][3]float64{
+		{s, -s * rz, s * ry},
+		{s * rz, s, -s * rx},
+		{-s * ry, s * rx, s},
+	}
+
+	if !inverse {
+		return params[0] + m[0][0]*x + m[0][1]*y + m[0][2]*z,
+			params[1] + m[1][0]*x + m[1][1]*y + m[1][2]*z,
+			params[2] + m[2][0]*x + m[2][1]*y + m[2][2]*z
+	}
+
+	// solve m * v = (x, y, z) - t using Cramer's rule
+	x, y, z = x-params[0], y-params[1], z-params[2]
+	det := func(c0, c1, c2 [3]float64) float64 {
+		return c0[0]*(c1[1]*c2[2]-c1[2]*c2[1]) -
+			c1[0]*(c0[1]*c2[2]-c0[2]*c2[1]) +
+			c2[0]*(c0[1]*c1[2]-c0[2]*c1[1])
+	}
+
+	c0 := [3]float64{m[0][0], m[1][0], m[2][0]}
+	c1 := [3]float64{m[0][1], m[1][1], m[2][1]}
+	c2 := [3]float64{m[0][2], m[1][2], m[2][2]}
+	v := [3]float64{x, y, z}
+
+	d := det(c0, c1, c2)
+	return det(v, c1, c2) / d, det(c0, v, c2) / d, det(c0, c1, v) / d
+}
+
+// toGeocentric returns the earth-centered, earth-fixed coordinates, in meters,
+// of the lng/lat point on the surface of the ellipsoid.
+func (e *Ellipsoid) toGeocentric(p *Point) (x, y, z float64) {
+	e2 := e.F * (2 - e.F)
+
+	sinLat, cosLat := math.Sincos(deg2rad(p.Lat()))
+	sinLng, cosLng := math.Sincos(deg2rad(p.Lng()))
+
+	n := e.A / math.Sqrt(1-e2*sinLat*sinLat)
+	return n * cosLat * cosLng, n * cosLat * sinLng, n * (1 - e2) * sinLat
+}
+
+// fromGeocentric sets the point to the lng/lat of the geocentric coordinates
+// on the ellipsoid, dropping the height above the ellipsoid.
+func (e *Ellipsoid) fromGeocentric(point *Point, x, y, z float64) {
+	e2 := e.F * (2 - e.F)
+	p := math.Hypot(x, y)
+
+	lat := math.Atan2(z, p*(1-e2))
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		sin := math.Sin(lat)
+		n := e.A / math.Sqrt(1-e2*sin*sin)
+
+		prev := lat
+		lat = math.Atan2(z+e2*n*sin, p)
+
+		if math.Abs(lat-prev) < geodesicTolerance {
+			break
+		}
+	}
+
+	point.SetLat(rad2deg(lat))
+	point.SetLng(rad2deg(math.Atan2(y, x)))
+}
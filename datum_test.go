@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuildDatumTransform(t *testing.T) {
+	// the Greenwich meridian of OSGB36 is about 110 meters west in WGS84
+	p := NewPoint(0, 51.4778)
+	BuildDatumTransform(DatumOSGB36, DatumWGS84)(p)
+
+	expected := -0.0016
+	if math.Abs(p.Lng()-expected) > 1e-4 {
+		t.Errorf("datum transform, expected lng %v, got %v", expected, p.Lng())
+	}
+
+	if math.Abs(p.Lat()-51.4778) > 1e-3 {
+		t.Errorf("datum transform, expected lat near %v, got %v", 51.4778, p.Lat())
+	}
+
+	// round trip, not exact since the height above the ellipsoid is dropped
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+
+		BuildDatumTransform(DatumWGS84, DatumOSGB36)(p)
+		BuildDatumTransform(DatumOSGB36, DatumWGS84)(p)
+
+		if math.Abs(p.Lat()-city[0]) > 1e-6 || math.Abs(p.Lng()-city[1]) > 1e-6 {
+			t.Errorf("datum transform, round trip expected %v, got %v", city, p)
+		}
+	}
+
+	// same datum
+	p = NewPoint(1, 2)
+	BuildDatumTransform(DatumWGS84, DatumWGS84)(p)
+	if !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("datum transform, same datum should not change the point, got %v", p)
+	}
+
+	// different ellipsoid with the null transformation
+	p = NewPoint(-120, 45)
+	BuildDatumTransform(DatumNAD83, DatumWGS84)(p)
+	if math.Abs(p.Lat()-45) > 1e-9 || math.Abs(p.Lng()+120) > 1e-9 {
+		t.Errorf("datum transform, expected nearly the same point, got %v", p)
+	}
+}
+
+func TestEllipsoidGeocentric(t *testing.T) {
+	x, y, z := WGS84.toGeocentric(NewPoint(0, 0))
+	if x != WGS84.A || y != 0 || z != 0 {
+		t.Errorf("ellipsoid, toGeocentric expected equator, got %v %v %v", x, y, z)
+	}
+
+	x, y, z = WGS84.toGeocentric(NewPoint(0, 90))
+	if math.Abs(z-WGS84.B()) > 1e-6 || math.Abs(x) > 1e-6 {
+		t.Errorf("ellipsoid, toGeocentric expected pole, got %v %v %v", x, y, z)
+	}
+
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+		x, y, z := WGS84.toGeocentric(p)
+		WGS84.fromGeocentric(p, x, y, z)
+
+		if math.Abs(p.Lat()-city[0]) > 1e-12 || math.Abs(p.Lng()-city[1]) > 1e-12 {
+			t.Errorf("ellipsoid, geocentric round trip expected %v, got %v", city, p)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEPSG is returned when looking up an EPSG code that is not registered.
+var ErrUnknownEPSG = errors.New("go.geo: unknown EPSG code")
+
+// A CRS is a coordinate reference system identified by its EPSG code.
+type CRS struct {
+	Code  int
+	Name  string
+	Datum *Datum
+
+	// Projection converts lng/lat on the datum to the coordinates of the system.
+	// It is nil for geographic systems where the coordinates are lng/lat.
+	Projection *Projection
+
+	// Area is the lng/lat area of use, where the projection is accurate.
+	Area *Bound
+}
+
+var epsgRegistry = make(map[int]*CRS)
+
+func init() {
+	mercator := Mercator
+	worldMercator := EllipsoidalMercator
+	equirectangular := BuildEquirectangular(WGS84, 0, 0, 0, 0)
+	britishNationalGrid := BuildEllipsoidalTransverseMercator(Airy1830, -2, 49, 0.9996012717, 400000, -100000)
+	lambert93 := BuildLambertConformalConic(GRS80, 3, 46.5, 49, 44, 700000, 6600000)
+	conusAlbers := BuildAlbersEqualArea(GRS80, -96, 23, 29.5, 45.5, 0, 0)
+	upsNorth := BuildPolarStereographic(WGS84, 0, 90, 0.994, 2000000, 2000000)
+	upsSouth := BuildPolarStereographic(WGS84, 0, -90, 0.994, 2000000, 2000000)
+	seaIceNorth := BuildPolarStereographic(WGS84, -45, 70, 1, 0, 0)
+	antarctic := BuildPolarStereographic(WGS84, 0, -71, 1, 0, 0)
+
+	world := NewBound(-180, 180, -90, 90)
+	for _, crs := range []*CRS{
+		{4326, "WGS 84", DatumWGS84, nil, world},
+		{4269, "NAD83", DatumNAD83, nil, NewGeoBound(167.65, -40.73, 14.92, 86.45)},
+		{4258, "ETRS89", DatumETRS89, nil, NewBound(-16.1, 40.18, 32.88, 84.73)},
+		{4277, "OSGB 1936", DatumOSGB36, nil, NewBound(-9, 2.01, 49.75, 61.01)},
+		{3857, "WGS 84 / Pseudo-Mercator", DatumWGS84, &mercator, NewBound(-180, 180, -85.06, 85.06)},
+		{3395, "WGS 84 / World Mercator", DatumWGS84, &worldMercator, NewBound(-180, 180, -80, 84)},
+		{4087, "WGS 84 / World Equidistant Cylindrical", DatumWGS84, &equirectangular, world},
+		{27700, "OSGB 1936 / British National Grid", DatumOSGB36, &britishNationalGrid, NewBound(-9, 2.01, 49.75, 61.01)},
+		{2154, "RGF93 / Lambert-93", DatumETRS89, &lambert93, NewBound(-9.86, 10.38, 41.15, 51.56)}, // RGF93 is the French realization of ETRS89
+		{5070, "NAD83 / Conus Albers", DatumNAD83, &conusAlbers, NewBound(-124.79, -66.91, 24.41, 49.38)},
+		{32661, "WGS 84 / UPS North", DatumWGS84, &upsNorth, NewBound(-180, 180, 60, 90)},
+		{32761, "WGS 84 / UPS South", DatumWGS84, &upsSouth, NewBound(-180, 180, -90, -60)},
+		{3413, "WGS 84 / NSIDC Sea Ice Polar Stereographic North", DatumWGS84, &seaIceNorth, NewBound(-180, 180, 60, 90)},
+		{3031, "WGS 84 / Antarctic Polar Stereographic", DatumWGS84, &antarctic, NewBound(-180, 180, -90, -60)},
+	} {
+		RegisterEPSG(crs)
+	}
+
+	for zone := 1; zone <= 60; zone++ {
+		registerUTM(32600+zone, "WGS 84", DatumWGS84, zone, true)
+		registerUTM(32700+zone, "WGS 84", DatumWGS84, zone, false)
+	}
+
+	for zone := 1; zone <= 23; zone++ {
+		registerUTM(26900+zone, "NAD83", DatumNAD83, zone, true)
+	}
+
+	for zone := 28; zone <= 38; zone++ {
+		registerUTM(25800+zone, "ETRS89", DatumETRS89, zone, true)
+	}
+}
+
+func registerUTM(code int, name string, datum *Datum, zone int, north bool) {
+	hemisphere := "N"
+	if !north {
+		hemisphere = "S"
+	}
+
+	west := float64(6*zone - 186)
+	area := NewBound(west, west+6, 0, 84)
+	if !north {
+		area = NewBound(west, west+6, -80, 0)
+	}
+
+	proj := buildUTM(datum.Ellipsoid, zone, north)
+	RegisterEPSG(&CRS{
+		Code:       code,
+		Name:       fmt.Sprintf("%s / UTM zone %d%s", name, zone, hemisphere),
+		Datum:      datum,
+		Projection: &proj,
+		Area:       area,
+	})
+}
+
+// RegisterEPSG adds the coordinate reference system to the registry, replacing
+// any system with the same code. It is not safe to call concurrently
+// with the other EPSG functions, so it should be called during initialization.
+func RegisterEPSG(crs *CRS) {
+	epsgRegistry[crs.Code] = crs
+}
+
+// LookupEPSG returns the registered coordinate reference system for the code.
+// Included are the geographic systems 4326, 4269, 4258 and 4277, web and world mercator 3857 and 3395,
+// UTM zones on WGS84 (326xx and 327xx), NAD83 (269xx) and ETRS89 (258xx),
+// as well as a few national and polar grids.
+func LookupEPSG(code int) (*CRS, error) {
+	crs, ok := epsgRegistry[code]
+	if !ok {
+		return nil, ErrUnknownEPSG
+	}
+
+	return crs, nil
+}
+
+// BuildEPSGTransform returns a projector that converts points from the coordinates
+// of one EPSG coordinate reference system to another, shifting the datum if needed.
+// For example, to convert a GPS track to the British National Grid:
+//
+//	transform, err := geo.BuildEPSGTransform(4326, 27700)
+//	path.Transform(transform)
+func BuildEPSGTransform(from, to int) (Projector, error) {
+	fromCRS, err := LookupEPSG(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toCRS, err := LookupEPSG(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromCRS.TransformTo(toCRS), nil
+}
+
+// TransformTo returns a projector that converts points from the coordinates
+// of this system to the coordinates of the other system.
+func (crs *CRS) TransformTo(other *CRS) Projector {
+	shift := BuildDatumTransform(crs.Datum, other.Datum)
+
+	return func(p *Point) {
+		if crs.Projection != nil {
+			crs.Projection.Inverse(p)
+		}
+
+		shift(p)
+
+		if other.Projection != nil {
+			other.Projection.Project(p)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookupEPSG(t *testing.T) {
+	cases := []struct {
+		code int
+		name string
+	}{
+		{4326, "WGS 84"},
+		{3857, "WGS 84 / Pseudo-Mercator"},
+		{32633, "WGS 84 / UTM zone 33N"},
+		{32756, "WGS 84 / UTM zone 56S"},
+		{26910, "NAD83 / UTM zone 10N"},
+		{25832, "ETRS89 / UTM zone 32N"},
+		{27700, "OSGB 1936 / British National Grid"},
+	}
+
+	for _, tc := range cases {
+		crs, err := LookupEPSG(tc.code)
+		if err != nil {
+			t.Fatalf("epsg, lookup %d unexpected error: %v", tc.code, err)
+		}
+
+		if crs.Code != tc.code || crs.Name != tc.name {
+			t.Errorf("epsg, lookup expected %d %q, got %d %q", tc.code, tc.name, crs.Code, crs.Name)
+		}
+	}
+
+	if _, err := LookupEPSG(1); err != ErrUnknownEPSG {
+		t.Errorf("epsg, lookup expected unknown error, got %v", err)
+	}
+
+	if _, err := BuildEPSGTransform(4326, 32600); err != ErrUnknownEPSG {
+		t.Errorf("epsg, transform expected unknown error, got %v", err)
+	}
+}
+
+func TestRegisterEPSG(t *testing.T) {
+	proj := BuildUTM(1, true)
+	RegisterEPSG(&CRS{Code: 999999, Name: "test", Datum: DatumWGS84, Projection: &proj})
+	defer delete(epsgRegistry, 999999)
+
+	transform, err := BuildEPSGTransform(4326, 999999)
+	if err != nil {
+		t.Fatalf("epsg, unexpected error: %v", err)
+	}
+
+	p := NewPoint(-177, 0)
+	transform(p)
+	if math.Abs(p.X()-500000) > 1e-6 || math.Abs(p.Y()) > 1e-6 {
+		t.Errorf("epsg, registered transform expected center of zone, got %v", p)
+	}
+}
+
+func TestBuildEPSGTransform(t *testing.T) {
+	transform, err := BuildEPSGTransform(4326, 3857)
+	if err != nil {
+		t.Fatalf("epsg, unexpected error: %v", err)
+	}
+
+	path := NewPath()
+	path.Push(NewPoint(-122.4167, 37.7833))
+	path.Push(NewPoint(-122.4, 37.8))
+
+	expected := path.Clone().Transform(Mercator.Project)
+	if p := path.Clone().Transform(transform); !p.Equals(expected) {
+		t.Errorf("epsg, transform expected %v, got %v", expected, p)
+	}
+
+	// utm to the same zone in a different datum
+	transform, _ = BuildEPSGTransform(32610, 26910)
+	p := NewPoint(550000, 4180000)
+	transform(p)
+	if math.Abs(p.X()-550000) > 0.001 || math.Abs(p.Y()-4180000) > 0.001 {
+		t.Errorf("epsg, transform expected nearly the same point, got %v", p)
+	}
+
+	// round trip through every registered system, within the area of use
+	for code, crs := range epsgRegistry {
+		to, _ := BuildEPSGTransform(4326, code)
+		from, _ := BuildEPSGTransform(code, 4326)
+
+		points := []*Point{crs.Area.Center()}
+		for _, city := range cities {
+			if p := NewPoint(city[1], city[0]); crs.Area.Contains(p) {
+				points = append(points, p)
+			}
+		}
+
+		for _, p := range points {
+			city := [2]float64{p.Lat(), p.Lng()}
+			to(p)
+			from(p)
+
+			if math.Abs(p.Lat()-city[0]) > 1e-6 || math.Abs(p.Lng()-city[1]) > 1e-6 {
+				t.Errorf("epsg, %d round trip expected %v, got %v", code, city, p)
+			}
+		}
+	}
+}
+
+func TestBuildEPSGTransformBritishNationalGrid(t *testing.T) {
+	// the Airy transit circle, the Greenwich meridian of OSGB36
+	transform, _ := BuildEPSGTransform(4277, 27700)
+
+	p := NewPoint(0, 51.4778)
+	transform(p)
+
+	if math.Abs(p.X()-538874) > 100 || math.Abs(p.Y()-177344) > 100 {
+		t.Errorf("epsg, british national grid expected near 538874 177344, got %v", p)
+	}
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+)
+
+// A WKBGeometry is a geometry that can be read from and written to WKB.
+// All the geometries in this package implement this interface.
+type WKBGeometry interface {
+	sql.Scanner
+	MarshalWKB(byteOrder ...binary.ByteOrder) []byte
+}
+
+// SRIDGeometry pairs a geometry with its spatial reference identifier.
+// It can be used to scan PostGIS EWKB or MySQL's SRID+WKB data while keeping
+// the SRID, or to write the geometry with its SRID. For example:
+//
+//	path := geo.NewPath()
+//	g := &geo.SRIDGeometry{Geometry: path}
+//	row.Scan(g)
+//	// g.SRID is now set and path contains the data.
+type SRIDGeometry struct {
+	SRID     int
+	Geometry WKBGeometry
+}
+
+// NewSRIDGeometry creates a new geometry with the given SRID.
+func NewSRIDGeometry(srid int, geometry WKBGeometry) *SRIDGeometry {
+	return &SRIDGeometry{
+		SRID:     srid,
+		Geometry: geometry,
+	}
+}
+
+// Scan implements the sql.Scanner interface. The data is unmarshalled into
+// the Geometry, which must be set and be one of the types in this package.
+// The data can be WKB, EWKB, both raw or as a string of hex, or MySQL's SRID+WKB.
+// The SRID will be 0 if it was not part of the data.
+func (g *SRIDGeometry) Scan(value interface{}) error {
+	u, ok := g.Geometry.(wkbUnmarshaler)
+	if !ok {
+		return ErrIncorrectGeometry
+	}
+
+	srid, err := scanWKB(value, u)
+	if err != nil {
+		return err
+	}
+
+	g.SRID = srid
+	return nil
+}
+
+// MarshalEWKB returns the geometry in PostGIS EWKB format,
+// with the SRID included in the header. The byte order defaults
+// to DefaultWKBByteOrder.
+func (g *SRIDGeometry) MarshalEWKB(byteOrder ...binary.ByteOrder) []byte {
+	order := DefaultWKBByteOrder
+	if len(byteOrder) != 0 {
+		order = byteOrder[0]
+	}
+
+	data := g.Geometry.MarshalWKB(order)
+
+	// set the SRID flag and insert the SRID after the header
+	result := make([]byte, len(data)+4)
+	copy(result, data[:5])
+	order.PutUint32(result[1:5], order.Uint32(data[1:5])|ewkbSRIDFlag)
+	order.PutUint32(result[5:9], uint32(g.SRID))
+	copy(result[9:], data[5:])
+
+	return result
+}
+
+// MarshalMySQL returns the geometry in MySQL's internal format,
+// the little endian SRID followed by little endian WKB.
+func (g *SRIDGeometry) MarshalMySQL() []byte {
+	data := g.Geometry.MarshalWKB(binary.LittleEndian)
+
+	result := make([]byte, len(data)+4)
+	binary.LittleEndian.PutUint32(result[:4], uint32(g.SRID))
+	copy(result[4:], data)
+
+	return result
+}
+
+// Value implements the driver.Valuer interface allowing
+// geometries with an SRID to be passed as query arguments.
+// The value is the EWKB of the geometry.
+func (g *SRIDGeometry) Value() (driver.Value, error) {
+	return g.MarshalEWKB(), nil
+}
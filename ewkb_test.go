@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestSRIDGeometryScan(t *testing.T) {
+	// PostGIS returns EWKB as a string of hex for SELECT geom
+	p := NewPoint(0, 0)
+	g := NewSRIDGeometry(0, p)
+
+	err := g.Scan([]byte("0101000020E6100000000000000000F03F0000000000000040"))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if g.SRID != 4326 {
+		t.Errorf("incorrect srid, got %v", g.SRID)
+	}
+
+	if !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v", p)
+	}
+
+	// mysql's SRID+WKB data
+	line := &Line{}
+	g = NewSRIDGeometry(0, line)
+
+	err = g.Scan([]byte{215, 15, 0, 0, 1, 2, 0, 0, 0, 2, 0, 0, 0, 213, 7, 146, 119, 14, 193, 94, 192, 93, 250, 151, 164, 50, 5, 67, 64, 26, 164, 224, 41, 228, 170, 94, 192, 22, 75, 145, 124, 37, 70, 67, 64})
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if g.SRID != 4055 {
+		t.Errorf("incorrect srid, got %v", g.SRID)
+	}
+
+	if !line.Equals(NewLine(NewPoint(-123.016508, 38.040608), NewPoint(-122.670176, 38.548019))) {
+		t.Errorf("incorrect line, got %v", line)
+	}
+
+	// plain WKB has no SRID
+	path := NewPath()
+	g = NewSRIDGeometry(4326, path)
+
+	if err := g.Scan(testPathWKB); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if g.SRID != 0 {
+		t.Errorf("incorrect srid, got %v", g.SRID)
+	}
+
+	if path.Length() != 6 {
+		t.Errorf("incorrect path, got %v", path)
+	}
+
+	// error conditions
+	if err := (&SRIDGeometry{}).Scan(testPathWKB); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if err := NewSRIDGeometry(0, p).Scan(testPathWKB); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestSRIDGeometryMarshal(t *testing.T) {
+	geometries := []WKBGeometry{
+		NewPoint(1, 2),
+		NewLine(NewPoint(1, 2), NewPoint(3, 4)),
+		NewPathFromWKB(testPathWKB),
+		&PointSet{{1, 2}, {3, 4}},
+		NewBound(1, 2, 3, 4),
+		testPolygon(),
+		NewMultiPolygon(testPolygon()),
+	}
+
+	for i, geometry := range geometries {
+		g := NewSRIDGeometry(4326, geometry)
+
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			scanned := &SRIDGeometry{Geometry: geometry}
+			if err := scanned.Scan(g.MarshalEWKB(order)); err != nil {
+				t.Errorf("test %d should not get error, got %v", i, err)
+			}
+
+			if scanned.SRID != 4326 {
+				t.Errorf("test %d incorrect srid, got %v", i, scanned.SRID)
+			}
+		}
+
+		scanned := &SRIDGeometry{Geometry: geometry}
+		if err := scanned.Scan(g.MarshalMySQL()); err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+		}
+
+		if scanned.SRID != 4326 {
+			t.Errorf("test %d incorrect srid, got %v", i, scanned.SRID)
+		}
+	}
+
+	p := NewPoint(1, 2)
+	data := NewSRIDGeometry(4326, p).MarshalEWKB()
+	if h, _ := scanHeader(data); h.srid != 4326 || h.typeCode != wkbPointType || h.size != 9 {
+		t.Errorf("incorrect header, got %+v", h)
+	}
+
+	value, err := NewSRIDGeometry(4326, p).Value()
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	scanned := NewPoint(0, 0)
+	if err := scanned.Scan(value); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(p) {
+		t.Errorf("incorrect point, got %v", scanned)
+	}
+}
+
+func TestScanHeader(t *testing.T) {
+	// EWKB with Z flag
+	p := NewPoint(0, 0)
+	err := p.Scan([]byte{1, 1, 0, 0, 128, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 8, 64})
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v", p)
+	}
+
+	// ISO ZM point, big endian
+	data := []byte{0, 0, 0, 11, 185, 63, 240, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 64, 8, 0, 0, 0, 0, 0, 0, 64, 16, 0, 0, 0, 0, 0, 0}
+	h, err := scanHeader(data)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !h.hasZ || !h.hasM || h.typeCode != wkbPointType || h.pointSize() != 32 {
+		t.Errorf("incorrect header, got %+v", h)
+	}
+
+	p = NewPoint(0, 0)
+	if err := p.Scan(data); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v", p)
+	}
+
+	// error conditions
+	if _, err := scanHeader([]byte{2, 1, 0, 0, 0, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := scanHeader([]byte{1, 1, 0, 0, 32, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package geo
+
+import "math"
+
+// An Ellipsoid is a reference ellipsoid used to model the shape of the earth
+// for the more accurate ellipsoidal, or geodesic, calculations.
+type Ellipsoid struct {
+	A float64 // semi-major axis, the equatorial radius, in meters
+	F float64 // flattening
+}
+
+// WGS84 is the ellipsoid used by GPS and most web mapping.
+// It is the default ellipsoid for the Geodesic* methods.
+var WGS84 = &Ellipsoid{A: 6378137.0, F: 1 / 298.257223563}
+
+// GeodesicMaxIterations is the maximum number of iterations used when
+// solving the geodesic problems. If Vincenty's inverse solution does not converge,
+// as for nearly antipodal points, Karney's method is used instead.
+var GeodesicMaxIterations = 200
+
+const geodesicTolerance = 1e-12
+
+// B returns the semi-minor axis, the polar radius, of the ellipsoid in meters.
+func (e *Ellipsoid) B() float64 {
+	return e.A * (1 - e.F)
+}
+
+// authalicQ returns the q(lat) function used to compute the authalic latitude,
+// normalized so that a*a/2 * q(lat) is the area, per radian of longitude,
+// between the equator and the latitude.
+func (e *Ellipsoid) authalicQ(lat float64) float64 {
+	if e.F == 0 {
+		return 2 * math.Sin(lat)
+	}
+
+	e2 := e.F * (2 - e.F)
+	ecc := math.Sqrt(e2)
+	sinLat := math.Sin(lat)
+
+	return (1 - e2) * (sinLat/(1-e2*sinLat*sinLat) + math.Atanh(ecc*sinLat)/ecc)
+}
+
+// Inverse solves the inverse geodesic problem using Vincenty's formulae.
+// It returns the distance in meters of the shortest path between the points
+// on the surface of the ellipsoid, along with the initial bearing at p1 and the
+// final bearing at p2 in degrees. Bearings are clockwise from north,
+// range (-180, 180], the same as Point.BearingTo.
+// Nearly antipodal points, where Vincenty's iteration does not converge,
+// are solved using Karney's method.
+func (e *Ellipsoid) Inverse(p1, p2 *Point) (distance, initialBearing, finalBearing float64) {
+	b := e.B()
+
+	L := deg2rad(p2.Lng() - p1.Lng())
+	sinU1, cosU1 := reducedLatitude(deg2rad(p1.Lat()), e.F)
+	sinU2, cosU2 := reducedLatitude(deg2rad(p2.Lat()), e.F)
+
+	var sinLambda, cosLambda, sinSigma, cosSigma, sigma, sinAlpha, cosSqAlpha, cos2SigmaM float64
+
+	converged := false
+	lambda := L
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+
+		x := cosU2 * sinLambda
+		y := cosU1*sinU2 - sinU1*cosU2*cosLambda
+		sinSigma = math.Sqrt(x*x + y*y)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, 0, 0
+		}
+
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha = cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM = 0 // equatorial line
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		C := e.F / 16 * cosSqAlpha * (4 + e.F*(4-3*cosSqAlpha))
+
+		prev := lambda
+		lambda = L + (1-C)*e.F*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < geodesicTolerance {
+			converged = true
+			break
+		}
+	}
+
+	if !converged || math.Abs(lambda) > math.Pi {
+		return newKarneyGeodesic(e).inverse(p1.Lat(), p1.Lng(), p2.Lat(), p2.Lng())
+	}
+
+	A, B := vincentyCoefficients(cosSqAlpha, e.A, b)
+	deltaSigma := vincentyDeltaSigma(B, sinSigma, cosSigma, cos2SigmaM)
+
+	distance = b * A * (sigma - deltaSigma)
+	initialBearing = rad2deg(math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda))
+	finalBearing = rad2deg(math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda))
+
+	return distance, initialBearing, finalBearing
+}
+
+// Direct solves the direct geodesic problem using Vincenty's formulae.
+// It returns the point reached by traveling the distance, in meters,
+// along the geodesic starting at the point with the given initial bearing in degrees.
+// The final bearing at the destination is also returned.
+func (e *Ellipsoid) Direct(point *Point, bearing, distance float64) (*Point, float64) {
+	b := e.B()
+
+	sinAlpha1, cosAlpha1 := math.Sincos(deg2rad(bearing))
+	sinU1, cosU1 := reducedLatitude(deg2rad(point.Lat()), e.F)
+
+	sigma1 := math.Atan2(sinU1/cosU1, cosAlpha1)
+
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+
+	A, B := vincentyCoefficients(cosSqAlpha, e.A, b)
+
+	var sinSigma, cosSigma, cos2SigmaM float64
+
+	sigma := distance / (b * A)
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+
+		prev := sigma
+		sigma = distance/(b*A) + vincentyDeltaSigma(B, sinSigma, cosSigma, cos2SigmaM)
+
+		if math.Abs(sigma-prev) < geodesicTolerance {
+			break
+		}
+	}
+
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sincos(sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-e.F)*math.Sqrt(sinAlpha*sinAlpha+x*x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+
+	C := e.F / 16 * cosSqAlpha * (4 + e.F*(4-3*cosSqAlpha))
+	L := lambda - (1-C)*e.F*sinAlpha*
+		(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	p := NewPoint(normalizeLng(point.Lng()+rad2deg(L)), rad2deg(lat))
+	return p, rad2deg(math.Atan2(sinAlpha, -x))
+}
+
+// GeodesicDistanceFrom returns the distance in meters between the points
+// on the WGS84 ellipsoid. It is accurate to within a millimeter
+// but much slower than GeoDistanceFrom.
+func (p *Point) GeodesicDistanceFrom(point *Point) float64 {
+	d, _, _ := WGS84.Inverse(p, point)
+	return d
+}
+
+// GeodesicBearingTo computes the initial bearing, in degrees, of the
+// geodesic from this point to the given point on the WGS84 ellipsoid.
+func (p *Point) GeodesicBearingTo(point *Point) float64 {
+	_, b, _ := WGS84.Inverse(p, point)
+	return b
+}
+
+// GeodesicFinalBearingTo computes the bearing, in degrees, of the geodesic
+// from this point on arrival at the given point on the WGS84 ellipsoid.
+func (p *Point) GeodesicFinalBearingTo(point *Point) float64 {
+	_, _, b := WGS84.Inverse(p, point)
+	return b
+}
+
+// GeodesicDestination returns a new point reached by traveling the distance,
+// in meters, along the geodesic with the initial bearing on the WGS84 ellipsoid.
+func (p *Point) GeodesicDestination(bearing, distance float64) *Point {
+	point, _ := WGS84.Direct(p, bearing, distance)
+	return point
+}
+
+// GeodesicDistance computes the length of the line in meters on the WGS84 ellipsoid.
+func (l *Line) GeodesicDistance() float64 {
+	return l.a.GeodesicDistanceFrom(&l.b)
+}
+
+// GeodesicBearing computes the initial bearing, in degrees,
+// of the geodesic from A() to B() on the WGS84 ellipsoid.
+func (l *Line) GeodesicBearing() float64 {
+	return l.a.GeodesicBearingTo(&l.b)
+}
+
+// GeodesicDistance computes the total distance in meters on the WGS84 ellipsoid.
+func (p *Path) GeodesicDistance() float64 {
+	sum := 0.0
+
+	loopTo := len(p.PointSet) - 1
+	for i := 0; i < loopTo; i++ {
+		sum += p.PointSet[i].GeodesicDistanceFrom(&p.PointSet[i+1])
+	}
+
+	return sum
+}
+
+// reducedLatitude returns the sin and cos of the latitude on the auxiliary sphere.
+func reducedLatitude(lat, f float64) (float64, float64) {
+	tanU := (1 - f) * math.Tan(lat)
+	cosU := 1 / math.Sqrt(1+tanU*tanU)
+
+	return tanU * cosU, cosU
+}
+
+func vincentyCoefficients(cosSqAlpha, a, b float64) (float64, float64) {
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	return A, B
+}
+
+func vincentyDeltaSigma(B, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	return B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+}
+
+// normalizeLng wraps the longitude into the range [-180, 180].
+func normalizeLng(lng float64) float64 {
+	if lng >= -180 && lng <= 180 {
+		return lng
+	}
+
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+
+	return lng - 180
+}
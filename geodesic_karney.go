@@ -0,0 +1,661 @@
+package geo
+
+import "math"
+
+// This file solves the inverse geodesic problem using the method from
+// C. F. F. Karney, Algorithms for geodesics, J. Geodesy 87, 43-55 (2013).
+// It converges for all pairs of points, including nearly antipodal ones where
+// Vincenty's iteration fails, and is used as the fallback in Ellipsoid.Inverse.
+// The series are to the sixth order in the flattening, the same as GeographicLib.
+
+const karneyOrder = 6
+
+var (
+	karneyTiny = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52)) // sqrt of the smallest normal number
+	karneyTol0 = math.Nextafter(1, 2) - 1
+	karneyTol1 = 200 * karneyTol0
+	karneyTol2 = math.Sqrt(karneyTol0)
+	karneyTolb = karneyTol0 * karneyTol2
+
+	karneyXThresh = 1000 * karneyTol2
+)
+
+const (
+	karneyMaxNewton = 20
+	karneyMaxIter   = karneyMaxNewton + 53 + 10
+)
+
+// karneyGeodesic holds the values derived from the ellipsoid used
+// by the inverse solution.
+type karneyGeodesic struct {
+	a, b, f, f1, n, ep2 float64
+	etol2               float64
+
+	a3x [karneyOrder]float64
+	c3x [karneyOrder * (karneyOrder - 1) / 2]float64
+}
+
+func newKarneyGeodesic(e *Ellipsoid) *karneyGeodesic {
+	g := &karneyGeodesic{
+		a:  e.A,
+		b:  e.B(),
+		f:  e.F,
+		f1: 1 - e.F,
+		n:  e.F / (2 - e.F),
+	}
+
+	e2 := e.F * (2 - e.F)
+	g.ep2 = e2 / (g.f1 * g.f1)
+	g.etol2 = 0.1 * karneyTol2 /
+		math.Sqrt(math.Max(0.001, math.Abs(e.F))*math.Min(1, 1-e.F/2)/2)
+
+	g.a3coeff()
+	g.c3coeff()
+
+	return g
+}
+
+// inverse returns the distance, initial and final bearing between the points.
+// The bearings are in degrees clockwise from north, the same as Ellipsoid.Inverse.
+func (g *karneyGeodesic) inverse(lat1, lng1, lat2, lng2 float64) (float64, float64, float64) {
+	// make longitude difference positive, lon12s is 180 - lon12
+	lon12 := karneyAngNormalize(lng2 - lng1)
+	lonSign := 1.0
+	if math.Signbit(lon12) {
+		lonSign = -1
+	}
+
+	lon12 = karneyAngRound(lonSign * lon12)
+	lon12s := karneyAngRound(180 - lon12)
+	lam12 := deg2rad(lon12)
+
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = karneySincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = karneySincosd(lon12)
+	}
+
+	lat1 = karneyAngRound(lat1)
+	lat2 = karneyAngRound(lat2)
+
+	// swap points so that point 1 has the larger absolute latitude
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonSign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+
+	// make lat1 <= 0
+	latSign := 1.0
+	if lat1 > 0 {
+		latSign = -1
+	}
+	lat1 *= latSign
+	lat2 *= latSign
+
+	sbet1, cbet1 := karneySincosd(lat1)
+	sbet1, cbet1 = karneyNorm(g.f1*sbet1, cbet1)
+	cbet1 = math.Max(karneyTiny, cbet1)
+
+	sbet2, cbet2 := karneySincosd(lat2)
+	sbet2, cbet2 = karneyNorm(g.f1*sbet2, cbet2)
+	cbet2 = math.Max(karneyTiny, cbet2)
+
+	// if the latitudes are equal, or opposite, make sure the reduced ones are too
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + g.ep2*sbet2*sbet2)
+
+	var (
+		c1a, c2a, c3a              [karneyOrder + 1]float64
+		s12x, sig12                float64
+		salp1, calp1, salp2, calp2 float64
+		ssig1, csig1, ssig2, csig2 float64
+		meridian                   = lat1 == -90 || slam12 == 0
+	)
+
+	if meridian {
+		// the points are on a meridian or one is at a pole
+		salp1, calp1 = slam12, clam12
+		salp2, calp2 = 0, 1
+
+		ssig1, csig1 = sbet1, calp1*cbet1
+		ssig2, csig2 = sbet2, calp2*cbet2
+
+		sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+
+		s, m12x := g.lengths(g.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, &c1a, &c2a)
+
+		// the meridian is the shortest path if it does not pass a conjugate point
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*karneyTiny {
+				s = 0
+			}
+			s12x = s * g.b
+		} else {
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 && (g.f <= 0 || lon12s >= g.f*180) {
+		// along the equator
+		salp1, calp1 = 1, 0
+		salp2, calp2 = 1, 0
+		s12x = g.a * lam12
+	} else if !meridian {
+		var dnm float64
+		sig12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(
+			sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12)
+
+		if sig12 >= 0 {
+			// short lines, the starting values are accurate enough
+			s12x = sig12 * g.b * dnm
+		} else {
+			// Newton's method on alpha1, falling back to bisection
+			var eps float64
+			tripn, tripb := false, false
+			salp1a, calp1a := karneyTiny, 1.0
+			salp1b, calp1b := karneyTiny, -1.0
+
+			for numit := 0; numit < karneyMaxIter; numit++ {
+				var v, dv float64
+				v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dv = g.lambda12(
+					sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12,
+					numit < karneyMaxNewton, &c1a, &c2a, &c3a)
+
+				tol := karneyTol0
+				if tripn {
+					tol *= 8
+				}
+
+				if tripb || !(math.Abs(v) >= tol) {
+					break
+				}
+
+				// update the bracket of the solution
+				if v > 0 && (numit > karneyMaxNewton || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 && (numit > karneyMaxNewton || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+
+				if numit < karneyMaxNewton && dv > 0 {
+					dalp1 := -v / dv
+					if math.Abs(dalp1) < math.Pi {
+						sdalp1, cdalp1 := math.Sincos(dalp1)
+						nsalp1 := salp1*cdalp1 + calp1*sdalp1
+						if nsalp1 > 0 {
+							calp1 = calp1*cdalp1 - salp1*sdalp1
+							salp1, calp1 = karneyNorm(nsalp1, calp1)
+							tripn = math.Abs(v) <= 16*karneyTol0
+							continue
+						}
+					}
+				}
+
+				// the Newton step went out of range, bisect instead
+				salp1, calp1 = karneyNorm((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < karneyTolb ||
+					math.Abs(salp1-salp1b)+(calp1-calp1b) < karneyTolb
+			}
+
+			s, _ := g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, false, &c1a, &c2a)
+			s12x = s * g.b
+		}
+	}
+
+	// undo the swaps and sign changes
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+
+	salp1 *= swapp * lonSign
+	calp1 *= swapp * latSign
+	salp2 *= swapp * lonSign
+	calp2 *= swapp * latSign
+
+	return s12x, rad2deg(math.Atan2(salp1, calp1)), rad2deg(math.Atan2(salp2, calp2))
+}
+
+// inverseStart returns a starting value of alpha1 for Newton's method.
+// If the points are close enough sig12 is non negative and the result
+// is the solution.
+func (g *karneyGeodesic) inverseStart(
+	sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12 float64,
+) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		somg12, comg12 = math.Sincos(lam12 / (g.f1 * dnm))
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < g.etol2 {
+		// really short lines
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = karneyNorm(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(g.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*cbet1*cbet1 {
+		// the spherical approximation is good enough
+	} else if g.f >= 0 {
+		// nearly antipodal, scale to coordinates where the antipode is the origin
+		// and solve the astroid problem
+		lam12x := math.Atan2(-slam12, -clam12)
+
+		k2 := sbet1 * sbet1 * g.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := g.f * cbet1 * g.a3f(eps) * math.Pi
+		betscale := lamscale * cbet1
+
+		x := lam12x / lamscale
+		y := sbet12a / betscale
+
+		if y > -karneyTol1 && x > -1-karneyXThresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := karneyAstroid(x, y)
+			omg12a := lamscale * (-x * k / (1 + k))
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if !(salp1 <= 0) {
+		salp1, calp1 = karneyNorm(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 returns the error in the longitude difference of the geodesic
+// with the initial azimuth alpha1, and its derivative with respect to alpha1.
+func (g *karneyGeodesic) lambda12(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+	diffp bool, c1a, c2a, c3a *[karneyOrder + 1]float64,
+) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12 float64) {
+	if sbet1 == 0 && calp1 == 0 {
+		// break the degeneracy of the equatorial line
+		calp1 = -karneyTiny
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = karneyNorm(ssig1, csig1)
+
+	salp2 = salp1
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	}
+
+	calp2 = math.Abs(calp1)
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var d float64
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			d = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+d) / cbet2
+	}
+
+	ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = karneyNorm(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * g.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+	g.c3f(eps, c3a)
+	b312 := karneySinCosSeries(ssig2, csig2, c3a) - karneySinCosSeries(ssig1, csig1, c3a)
+	lam12 = eta - g.f*g.a3f(eps)*salp0*(sig12+b312)
+
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * g.f1 * dn1 / sbet1
+		} else {
+			_, m12 := g.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, c1a, c2a)
+			dlam12 = m12 * g.f1 / (calp2 * cbet2)
+		}
+	}
+
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12
+}
+
+// lengths returns the distance and, if requested, the reduced length
+// along the geodesic, both divided by b.
+func (g *karneyGeodesic) lengths(
+	eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64,
+	reduced bool, c1a, c2a *[karneyOrder + 1]float64,
+) (float64, float64) {
+	a1 := karneyA1m1f(eps)
+	karneyC1f(eps, c1a)
+
+	b1 := karneySinCosSeries(ssig2, csig2, c1a) - karneySinCosSeries(ssig1, csig1, c1a)
+	s12b := (1 + a1) * (sig12 + b1)
+	if !reduced {
+		return s12b, 0
+	}
+
+	a2 := karneyA2m1f(eps)
+	karneyC2f(eps, c2a)
+
+	b2 := karneySinCosSeries(ssig2, csig2, c2a) - karneySinCosSeries(ssig1, csig1, c2a)
+	j12 := (a1-a2)*sig12 + ((1+a1)*b1 - (1+a2)*b2)
+
+	return s12b, dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+}
+
+// a3coeff computes the coefficients of the A3 series in eps, highest order first.
+func (g *karneyGeodesic) a3coeff() {
+	coeff := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+
+	o, k := 0, 0
+	for j := karneyOrder - 1; j >= 0; j-- {
+		m := karneyOrder - j - 1
+		if j < m {
+			m = j
+		}
+
+		g.a3x[k] = karneyPolyval(m, coeff[o:], g.n) / coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+}
+
+// c3coeff computes the coefficients of the C3 series in eps.
+func (g *karneyGeodesic) c3coeff() {
+	coeff := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+
+	o, k := 0, 0
+	for l := 1; l < karneyOrder; l++ {
+		for j := karneyOrder - 1; j >= l; j-- {
+			m := karneyOrder - j - 1
+			if j < m {
+				m = j
+			}
+
+			g.c3x[k] = karneyPolyval(m, coeff[o:], g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+func (g *karneyGeodesic) a3f(eps float64) float64 {
+	return karneyPolyval(karneyOrder-1, g.a3x[:], eps)
+}
+
+func (g *karneyGeodesic) c3f(eps float64, c *[karneyOrder + 1]float64) {
+	mult := 1.0
+	o := 0
+	for l := 1; l < karneyOrder; l++ {
+		m := karneyOrder - l - 1
+		mult *= eps
+		c[l] = mult * karneyPolyval(m, g.c3x[o:], eps)
+		o += m + 1
+	}
+	c[karneyOrder] = 0
+}
+
+// karneyA1m1f returns A1 - 1, the scale of the distance integral.
+func karneyA1m1f(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := karneyOrder / 2
+	t := karneyPolyval(m, coeff, eps*eps) / coeff[m+1]
+	return (t + eps) / (1 - eps)
+}
+
+// karneyC1f computes the coefficients of the distance integral.
+func karneyC1f(eps float64, c *[karneyOrder + 1]float64) {
+	coeff := []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+	karneySeries(eps, coeff, c)
+}
+
+// karneyA2m1f returns A2 - 1, the scale of the reduced length integral.
+func karneyA2m1f(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := karneyOrder / 2
+	t := karneyPolyval(m, coeff, eps*eps) / coeff[m+1]
+	return (t - eps) / (1 + eps)
+}
+
+// karneyC2f computes the coefficients of the reduced length integral.
+func karneyC2f(eps float64, c *[karneyOrder + 1]float64) {
+	coeff := []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+	karneySeries(eps, coeff, c)
+}
+
+// karneySeries evaluates the coefficients of a series in eps,
+// where the l-th coefficient is eps^l times a polynomial in eps^2.
+func karneySeries(eps float64, coeff []float64, c *[karneyOrder + 1]float64) {
+	eps2 := eps * eps
+	d := eps
+	o := 0
+	for l := 1; l <= karneyOrder; l++ {
+		m := (karneyOrder - l) / 2
+		c[l] = d * karneyPolyval(m, coeff[o:], eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// karneyAstroid solves k^4+2*k^3-(x^2+y^2-1)*k^2-2*y^2*k-y^2 = 0 for the positive root k.
+func karneyAstroid(x, y float64) float64 {
+	p := x * x
+	q := y * y
+	r := (p + q - 1) / 6
+
+	if q == 0 && r <= 0 {
+		return 0
+	}
+
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+
+	v := math.Sqrt(u*u + q)
+
+	uv := u + v
+	if u < 0 {
+		uv = q / (v - u)
+	}
+
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// karneySinCosSeries returns the sum of c[l] * sin(2*l*x) for l = 1..n
+// using Clenshaw summation.
+func karneySinCosSeries(sinx, cosx float64, c *[karneyOrder + 1]float64) float64 {
+	k := karneyOrder + 1
+	n := karneyOrder
+
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+
+	var y0, y1 float64
+	if n&1 == 1 {
+		k--
+		y0 = c[k]
+	}
+
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+
+	return 2 * sinx * cosx * y0
+}
+
+func karneyPolyval(n int, p []float64, x float64) float64 {
+	y := 0.0
+	if n >= 0 {
+		y = p[0]
+		for i := 1; i <= n; i++ {
+			y = y*x + p[i]
+		}
+	}
+
+	return y
+}
+
+func karneyNorm(s, c float64) (float64, float64) {
+	r := math.Hypot(s, c)
+	return s / r, c / r
+}
+
+// karneyAngRound rounds tiny angles so that small differences are exact.
+func karneyAngRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+
+	return math.Copysign(y, x)
+}
+
+// karneyAngNormalize reduces the angle to the range [-180, 180].
+func karneyAngNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+
+	return y
+}
+
+// karneySincosd returns the sin and cos of an angle in degrees,
+// exact at multiples of 90 degrees.
+func karneySincosd(x float64) (float64, float64) {
+	r := math.Mod(x, 360)
+	q := int(math.Round(r / 90))
+	r -= 90 * float64(q)
+
+	s, c := math.Sincos(deg2rad(r))
+	switch q & 3 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+
+	return s + 0, c + 0
+}
@@ -0,0 +1,192 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Flinders Peak to Buninyong, the example from Vincenty's paper.
+var (
+	flindersPeak = NewPoint(144+25.0/60+29.52440/3600, -(37 + 57.0/60 + 3.72030/3600))
+	buninyong    = NewPoint(143+55.0/60+35.38390/3600, -(37 + 39.0/60 + 10.15610/3600))
+)
+
+func TestEllipsoidInverse(t *testing.T) {
+	d, initial, final := WGS84.Inverse(flindersPeak, buninyong)
+	if math.Abs(d-54972.271) > 0.001 {
+		t.Errorf("ellipsoid, inverse distance incorrect, got %f", d)
+	}
+
+	if e := 306 + 52.0/60 + 5.37/3600 - 360; math.Abs(initial-e) > 1e-5 {
+		t.Errorf("ellipsoid, inverse initial bearing expected %f, got %f", e, initial)
+	}
+
+	if e := 127 + 10.0/60 + 25.07/3600 - 180; math.Abs(final-e) > 1e-5 {
+		t.Errorf("ellipsoid, inverse final bearing expected %f, got %f", e, final)
+	}
+
+	// along the equator
+	d, initial, final = WGS84.Inverse(NewPoint(0, 0), NewPoint(1, 0))
+	if math.Abs(d-111319.491) > 0.001 {
+		t.Errorf("ellipsoid, inverse equator distance incorrect, got %f", d)
+	}
+
+	if initial != 90 || final != 90 {
+		t.Errorf("ellipsoid, inverse equator bearings incorrect, got %f %f", initial, final)
+	}
+
+	// quarter meridian
+	d, initial, _ = WGS84.Inverse(NewPoint(0, 0), NewPoint(0, 90))
+	if math.Abs(d-10001965.729) > 0.001 {
+		t.Errorf("ellipsoid, inverse meridian distance incorrect, got %f", d)
+	}
+
+	if initial != 0 {
+		t.Errorf("ellipsoid, inverse meridian bearing incorrect, got %f", initial)
+	}
+
+	// across the antimeridian
+	d1, _, _ := WGS84.Inverse(NewPoint(179.5, 10), NewPoint(-179.5, 10))
+	d2, _, _ := WGS84.Inverse(NewPoint(-0.5, 10), NewPoint(0.5, 10))
+	if math.Abs(d1-d2) > 1e-6 {
+		t.Errorf("ellipsoid, inverse antimeridian distance incorrect, %f != %f", d1, d2)
+	}
+
+	// coincident
+	if d, _, _ := WGS84.Inverse(buninyong, buninyong); d != 0 {
+		t.Errorf("ellipsoid, inverse coincident distance should be 0, got %f", d)
+	}
+
+	// antipodal points on the equator, the geodesic is over the poles
+	d, _, _ = WGS84.Inverse(NewPoint(0, 0), NewPoint(180, 0))
+	if math.Abs(d-2*10001965.729) > 0.001 {
+		t.Errorf("ellipsoid, inverse antipodal distance incorrect, got %f", d)
+	}
+}
+
+func TestEllipsoidInverseAntipodal(t *testing.T) {
+	// Vincenty's iteration does not converge for these
+	cases := []struct {
+		p1, p2   *Point
+		distance float64
+	}{
+		{NewPoint(0, 0), NewPoint(179.7, 0), 19995624.890},
+		{NewPoint(0, 0), NewPoint(179.9, 0.1), 19992082.108},
+		{NewPoint(0, 0), NewPoint(179.8, 0.3), 19968298.320},
+		{NewPoint(0, 0), NewPoint(179.7, 0.5), 19944127.421},
+		{NewPoint(10, 45), NewPoint(-170.05, -44.9), 19992644.883},
+		{NewPoint(20, 10), NewPoint(-160.1, -10.2), 19981121.598},
+	}
+
+	for _, tc := range cases {
+		d, initial, final := WGS84.Inverse(tc.p1, tc.p2)
+		if math.Abs(d-tc.distance) > 0.001 {
+			t.Errorf("ellipsoid, inverse distance from %v to %v expected %f, got %f", tc.p1, tc.p2, tc.distance, d)
+		}
+
+		// the direct solution should get back to the point
+		p, f := WGS84.Direct(tc.p1, initial, d)
+		if e := p.GeodesicDistanceFrom(tc.p2); e > 0.001 {
+			t.Errorf("ellipsoid, inverse from %v to %v is off by %f meters", tc.p1, tc.p2, e)
+		}
+
+		if math.Abs(f-final) > 1e-6 {
+			t.Errorf("ellipsoid, inverse final bearing expected %f, got %f", f, final)
+		}
+
+		// and the same in reverse
+		r, _, _ := WGS84.Inverse(tc.p2, tc.p1)
+		if math.Abs(r-d) > 0.001 {
+			t.Errorf("ellipsoid, inverse reverse distance expected %f, got %f", d, r)
+		}
+	}
+}
+
+func TestEllipsoidDirect(t *testing.T) {
+	bearing := 306 + 52.0/60 + 5.37/3600
+	p, final := WGS84.Direct(flindersPeak, bearing, 54972.271)
+
+	if math.Abs(p.Lat()-buninyong.Lat()) > 1e-7 || math.Abs(p.Lng()-buninyong.Lng()) > 1e-7 {
+		t.Errorf("ellipsoid, direct point expected %v, got %v", buninyong, p)
+	}
+
+	if e := 127 + 10.0/60 + 25.07/3600 - 180; math.Abs(final-e) > 1e-5 {
+		t.Errorf("ellipsoid, direct final bearing expected %f, got %f", e, final)
+	}
+
+	// round trip
+	for _, city := range cities {
+		start := NewPointFromLatLng(city[0], city[1])
+		for _, bearing := range []float64{-170, -90, -10, 0, 45, 135, 180} {
+			for _, distance := range []float64{1, 1000, 500000, 5000000} {
+				end, _ := WGS84.Direct(start, bearing, distance)
+
+				d, b, _ := WGS84.Inverse(start, end)
+				if math.Abs(d-distance) > 1e-4 {
+					t.Errorf("ellipsoid, round trip distance expected %f, got %f", distance, d)
+				}
+
+				// bearings over very short distances are less precise
+				if diff := math.Mod(b-bearing+540, 360) - 180; distance > 1 && math.Abs(diff) > 1e-6 {
+					t.Errorf("ellipsoid, round trip bearing expected %f, got %f", bearing, b)
+				}
+			}
+		}
+	}
+
+	// across the antimeridian
+	p, _ = WGS84.Direct(NewPoint(179.5, 0), 90, 111319.491)
+	if math.Abs(p.Lng()+179.5) > 1e-7 || math.Abs(p.Lat()) > 1e-7 {
+		t.Errorf("ellipsoid, direct should wrap longitude, got %v", p)
+	}
+}
+
+func TestPointGeodesic(t *testing.T) {
+	if d := flindersPeak.GeodesicDistanceFrom(buninyong); math.Abs(d-54972.271) > 0.001 {
+		t.Errorf("point, geodesic distance incorrect, got %f", d)
+	}
+
+	// should be close to the spherical approximations
+	for _, city := range cities {
+		p := NewPointFromLatLng(city[0], city[1])
+
+		d := p.GeodesicDistanceFrom(flindersPeak)
+		if s := p.GeoDistanceFrom(flindersPeak, true); math.Abs(d-s)/d > 0.01 {
+			t.Errorf("point, geodesic distance not close to haversine, %f != %f", d, s)
+		}
+	}
+
+	if b := flindersPeak.GeodesicBearingTo(buninyong); math.Abs(b+53.131842) > 1e-5 {
+		t.Errorf("point, geodesic bearing incorrect, got %f", b)
+	}
+
+	if b := flindersPeak.GeodesicFinalBearingTo(buninyong); math.Abs(b+52.826369) > 1e-5 {
+		t.Errorf("point, geodesic final bearing incorrect, got %f", b)
+	}
+
+	p := flindersPeak.GeodesicDestination(-53.131842, 54972.271)
+	if p.GeodesicDistanceFrom(buninyong) > 0.01 {
+		t.Errorf("point, geodesic destination incorrect, got %v", p)
+	}
+}
+
+func TestLinePathGeodesic(t *testing.T) {
+	l := NewLine(flindersPeak, buninyong)
+	if d := l.GeodesicDistance(); math.Abs(d-54972.271) > 0.001 {
+		t.Errorf("line, geodesic distance incorrect, got %f", d)
+	}
+
+	if b := l.GeodesicBearing(); math.Abs(b+53.131842) > 1e-5 {
+		t.Errorf("line, geodesic bearing incorrect, got %f", b)
+	}
+
+	p := NewPath()
+	p.Push(NewPoint(0, 0)).Push(NewPoint(1, 0)).Push(NewPoint(2, 0))
+	if d := p.GeodesicDistance(); math.Abs(d-2*111319.491) > 0.002 {
+		t.Errorf("path, geodesic distance incorrect, got %f", d)
+	}
+
+	if d := NewPath().GeodesicDistance(); d != 0 {
+		t.Errorf("path, geodesic distance of empty path should be 0, got %f", d)
+	}
+}
@@ -0,0 +1,242 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// A GeoHashRange is an inclusive range of integer geohashes,
+// see GeoHashInt64RangesCoveringBound.
+type GeoHashRange struct {
+	Min, Max int64
+}
+
+// GeoHashNeighbors returns the geohashes of the same precision adjacent to the hash,
+// in the order north, north east, east, south east, south, south west, west and north west.
+// Neighbors across the anti-meridian wrap around, while those beyond the poles
+// do not exist and are left out. Returns nil if the hash is not valid.
+func GeoHashNeighbors(hash string) []string {
+	h, ok := geoHashDecode(hash)
+	if !ok || len(hash) == 0 {
+		return nil
+	}
+
+	neighbors := GeoHashInt64Neighbors(h, 5*len(hash))
+
+	result := make([]string, len(neighbors))
+	for i, n := range neighbors {
+		result[i] = geoHashEncode(n, len(hash))
+	}
+
+	return result
+}
+
+// GeoHashInt64Neighbors returns the integer geohashes adjacent to the hash, with the
+// given bits of precision, in the same order as GeoHashNeighbors.
+func GeoHashInt64Neighbors(hash int64, bits int) []int64 {
+	x, y := geoHashSplit(hash, bits)
+	nx, ny := int64(1)<<uint((bits+1)/2), int64(1)<<uint(bits/2)
+
+	offsets := [8][2]int64{
+		{0, 1}, {1, 1}, {1, 0}, {1, -1},
+		{0, -1}, {-1, -1}, {-1, 0}, {-1, 1},
+	}
+
+	result := make([]int64, 0, 8)
+	for _, o := range offsets {
+		ny2 := y + o[1]
+		if ny2 < 0 || ny2 >= ny {
+			continue
+		}
+
+		// wrap around the anti-meridian
+		nx2 := (x + o[0] + nx) % nx
+
+		n := geoHashJoin(nx2, ny2, bits)
+		if n == hash || geoHashContains(result, n) {
+			continue
+		}
+
+		result = append(result, n)
+	}
+
+	return result
+}
+
+// GeoHashesCoveringBound returns the sorted geohashes, of the given number
+// of characters, that intersect the bound. If more than maxCount hashes are needed
+// the precision is reduced until they fit, down to one character.
+// A maxCount of zero or less means no limit. Bounds crossing the anti-meridian are supported.
+func GeoHashesCoveringBound(bound *Bound, precision, maxCount int) []string {
+	for precision > 1 && !geoHashCountFits(bound, 5*precision, maxCount) {
+		precision--
+	}
+
+	hashes := geoHashesCoveringBound(bound, 5*precision)
+
+	result := make([]string, len(hashes))
+	for i, h := range hashes {
+		result[i] = geoHashEncode(h, precision)
+	}
+
+	return result
+}
+
+// GeoHashInt64RangesCoveringBound returns sorted, non overlapping, ranges of integer
+// geohashes, with the given bits of precision, that cover the bound. Contiguous hashes
+// are merged into one range so points encoded with GeoHashInt64 using the same bits
+// can be found by scanning the ranges. Coarser cells are used if more than maxCount
+// cells are needed, so there will be at most maxCount ranges.
+// A maxCount of zero or less means no limit.
+func GeoHashInt64RangesCoveringBound(bound *Bound, bits, maxCount int) []GeoHashRange {
+	coarse := bits
+	for coarse > 0 && !geoHashCountFits(bound, coarse, maxCount) {
+		coarse--
+	}
+
+	shift := uint(bits - coarse)
+
+	var result []GeoHashRange
+	for _, h := range geoHashesCoveringBound(bound, coarse) {
+		r := GeoHashRange{h << shift, (h+1)<<shift - 1}
+
+		if l := len(result); l > 0 && result[l-1].Max+1 == r.Min {
+			result[l-1].Max = r.Max
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// geoHashesCoveringBound returns the sorted integer hashes
+// intersecting the bound.
+func geoHashesCoveringBound(bound *Bound, bits int) []int64 {
+	x0, x1, y0, y1 := geoHashCellRange(bound, bits)
+	nx := int64(1) << uint((bits+1)/2)
+
+	result := make([]int64, 0, (x1-x0+1)*(y1-y0+1))
+	for x := x0; x <= x1; x++ {
+		for y := y0; y <= y1; y++ {
+			result = append(result, geoHashJoin(x%nx, y, bits))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func geoHashCountFits(bound *Bound, bits, maxCount int) bool {
+	if maxCount <= 0 {
+		return true
+	}
+
+	x0, x1, y0, y1 := geoHashCellRange(bound, bits)
+	return (x1-x0+1)*(y1-y0+1) <= int64(maxCount)
+}
+
+// geoHashCellRange returns the range of the cell indexes intersecting the bound.
+// If the bound crosses the anti-meridian x1 will be larger than the number
+// of columns and needs to be wrapped.
+func geoHashCellRange(bound *Bound, bits int) (x0, x1, y0, y1 int64) {
+	nx, ny := int64(1)<<uint((bits+1)/2), int64(1)<<uint(bits/2)
+
+	x0 = geoHashCellIndex(bound.West(), -180, 360, nx)
+	x1 = geoHashCellIndex(bound.East(), -180, 360, nx)
+	y0 = geoHashCellIndex(bound.South(), -90, 180, ny)
+	y1 = geoHashCellIndex(bound.North(), -90, 180, ny)
+
+	if bound.CrossesAntimeridian() {
+		x1 += nx
+	}
+
+	// the bound covers all the columns
+	if x1-x0 >= nx {
+		x0, x1 = 0, nx-1
+	}
+
+	return
+}
+
+// geoHashCellIndex returns the index of the cell containing the value.
+// Values on a boundary are in the lower cell, matching Point.GeoHashInt64.
+func geoHashCellIndex(value, start, size float64, n int64) int64 {
+	i := int64(math.Ceil((value-start)/size*float64(n))) - 1
+	if i < 0 {
+		return 0
+	}
+
+	if i >= n {
+		return n - 1
+	}
+
+	return i
+}
+
+// geoHashSplit returns the longitude and latitude cell indexes of the hash.
+func geoHashSplit(hash int64, bits int) (x, y int64) {
+	for i := 0; i < bits; i++ {
+		bit := (hash >> uint(bits-1-i)) & 1
+		if i%2 == 0 {
+			x = x<<1 | bit
+		} else {
+			y = y<<1 | bit
+		}
+	}
+
+	return
+}
+
+// geoHashJoin interleaves the longitude and latitude cell indexes into a hash.
+func geoHashJoin(x, y int64, bits int) (hash int64) {
+	lngBits, latBits := (bits+1)/2, bits/2
+	for i := 0; i < bits; i++ {
+		hash <<= 1
+		if i%2 == 0 {
+			lngBits--
+			hash |= (x >> uint(lngBits)) & 1
+		} else {
+			latBits--
+			hash |= (y >> uint(latBits)) & 1
+		}
+	}
+
+	return
+}
+
+func geoHashDecode(hash string) (int64, bool) {
+	var result int64
+	for i := 0; i < len(hash); i++ {
+		v := strings.IndexByte(base32, hash[i])
+		if v < 0 {
+			return 0, false
+		}
+
+		result = result<<5 | int64(v)
+	}
+
+	return result, len(hash) <= 12
+}
+
+func geoHashEncode(hash int64, precision int) string {
+	result := make([]byte, precision)
+	for i := precision - 1; i >= 0; i-- {
+		result[i] = base32[hash&0x1F]
+		hash >>= 5
+	}
+
+	return string(result)
+}
+
+func geoHashContains(hashes []int64, hash int64) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoHashNeighbors(t *testing.T) {
+	neighbors := GeoHashNeighbors("dqcjq")
+	expected := []string{"dqcjw", "dqcjx", "dqcjr", "dqcjp", "dqcjn", "dqcjj", "dqcjm", "dqcjt"}
+
+	if len(neighbors) != len(expected) {
+		t.Fatalf("geohash, neighbors expected %v, got %v", expected, neighbors)
+	}
+
+	for i := range expected {
+		if neighbors[i] != expected[i] {
+			t.Errorf("geohash, neighbors expected %v, got %v", expected, neighbors)
+			break
+		}
+	}
+
+	// the neighbors should be offset by the size of the cell
+	for _, city := range cities {
+		hash := NewPoint(city[1], city[0]).GeoHash(6)
+		b := NewBoundFromGeoHash(hash)
+		center := b.Center()
+
+		for _, n := range GeoHashNeighbors(hash) {
+			c := NewBoundFromGeoHash(n).Center()
+			dx := math.Abs(normalizeLng(c.Lng() - center.Lng()))
+			dy := math.Abs(c.Lat() - center.Lat())
+
+			if (dx > 1e-9 && math.Abs(dx-b.Width()) > 1e-9) || (dy > 1e-9 && math.Abs(dy-b.Height()) > 1e-9) {
+				t.Errorf("geohash, %v is not a neighbor of %v", n, hash)
+			}
+		}
+	}
+
+	// across the anti-meridian
+	hash := NewPoint(179.99, 0.01).GeoHash(4)
+	found := false
+	for _, n := range GeoHashNeighbors(hash) {
+		found = found || NewBoundFromGeoHash(n).Contains(NewPoint(-179.99, 0.01))
+	}
+
+	if !found {
+		t.Errorf("geohash, neighbors should wrap the anti-meridian")
+	}
+
+	// at the pole
+	if n := GeoHashNeighbors(NewPoint(0, 89.99).GeoHash(4)); len(n) != 5 {
+		t.Errorf("geohash, neighbors expected 5 at the pole, got %v", n)
+	}
+
+	if n := GeoHashNeighbors("invalid"); n != nil {
+		t.Errorf("geohash, neighbors expected nil for invalid hash, got %v", n)
+	}
+}
+
+func TestGeoHashInt64Neighbors(t *testing.T) {
+	// should match the string neighbors
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+
+		hashes := GeoHashNeighbors(p.GeoHash(6))
+		neighbors := GeoHashInt64Neighbors(p.GeoHashInt64(30), 30)
+		if len(neighbors) != len(hashes) {
+			t.Fatalf("geohash, int64 neighbors expected %d, got %d", len(hashes), len(neighbors))
+		}
+
+		for i, n := range neighbors {
+			if h := geoHashEncode(n, 6); h != hashes[i] {
+				t.Errorf("geohash, int64 neighbor expected %v, got %v", hashes[i], h)
+			}
+		}
+	}
+
+	// tiny grids should not have duplicates
+	if n := GeoHashInt64Neighbors(0, 2); len(n) != 3 {
+		t.Errorf("geohash, int64 neighbors expected 3 for 2 bits, got %v", n)
+	}
+}
+
+func TestGeoHashesCoveringBound(t *testing.T) {
+	bound := NewBound(-122.5, -122.3, 37.7, 37.8)
+
+	hashes := GeoHashesCoveringBound(bound, 5, 0)
+	for i, h := range hashes {
+		if len(h) != 5 {
+			t.Errorf("geohash, covering expected precision 5, got %v", h)
+		}
+
+		if i > 0 && hashes[i-1] >= h {
+			t.Errorf("geohash, covering should be sorted")
+		}
+
+		if !NewBoundFromGeoHash(h).Intersects(bound) {
+			t.Errorf("geohash, covering hash %v does not intersect", h)
+		}
+	}
+
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			p := NewPoint(-122.5+0.02*float64(x), 37.7+0.01*float64(y))
+			if !geoHashStringsContain(hashes, p.GeoHash(5)) {
+				t.Errorf("geohash, covering does not contain %v", p)
+			}
+		}
+	}
+
+	// reduce the precision
+	limited := GeoHashesCoveringBound(bound, 5, 4)
+	if len(limited) > 4 || len(limited) == 0 || len(limited[0]) >= 5 {
+		t.Errorf("geohash, covering expected at most 4 coarser hashes, got %v", limited)
+	}
+
+	// crossing the anti-meridian
+	hashes = GeoHashesCoveringBound(NewGeoBound(179, -179, -1, 1), 2, 0)
+	if !geoHashStringsContain(hashes, NewPoint(179.5, 0.5).GeoHash(2)) ||
+		!geoHashStringsContain(hashes, NewPoint(-179.5, -0.5).GeoHash(2)) {
+		t.Errorf("geohash, covering should include both sides of the anti-meridian, got %v", hashes)
+	}
+
+	if l := len(GeoHashesCoveringBound(NewBound(-180, 180, -90, 90), 1, 0)); l != 32 {
+		t.Errorf("geohash, covering world expected 32 hashes, got %d", l)
+	}
+}
+
+func TestGeoHashInt64RangesCoveringBound(t *testing.T) {
+	bound := NewBound(-122.5, -122.3, 37.7, 37.8)
+
+	for _, max := range []int{0, 4, 16} {
+		ranges := GeoHashInt64RangesCoveringBound(bound, 40, max)
+		if max > 0 && len(ranges) > max {
+			t.Errorf("geohash, ranges expected at most %d, got %d", max, len(ranges))
+		}
+
+		for i, r := range ranges {
+			if r.Min > r.Max || (i > 0 && ranges[i-1].Max+1 >= r.Min) {
+				t.Errorf("geohash, ranges should be sorted and not contiguous, got %v", ranges)
+			}
+		}
+
+		for x := 0; x <= 10; x++ {
+			for y := 0; y <= 10; y++ {
+				hash := NewPoint(-122.5+0.02*float64(x), 37.7+0.01*float64(y)).GeoHashInt64(40)
+
+				found := false
+				for _, r := range ranges {
+					found = found || (r.Min <= hash && hash <= r.Max)
+				}
+
+				if !found {
+					t.Errorf("geohash, ranges do not contain hash %v", hash)
+				}
+			}
+		}
+	}
+
+	// contiguous cells are merged
+	ranges := GeoHashInt64RangesCoveringBound(NewBound(-180, 180, -90, 90), 10, 0)
+	if len(ranges) != 1 || ranges[0].Min != 0 || ranges[0].Max != 1<<10-1 {
+		t.Errorf("geohash, ranges of the world expected one range, got %v", ranges)
+	}
+}
+
+func geoHashStringsContain(hashes []string, hash string) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,440 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/paulmach/go.geojson"
+)
+
+// ErrInvalidGeoJSON is returned when converting GeoJSON data
+// that is missing coordinates or has positions with less than two values.
+var ErrInvalidGeoJSON = errors.New("go.geo: invalid GeoJSON data")
+
+// A Feature is a go.geo geometry along with its properties,
+// as found in GeoJSON features.
+type Feature struct {
+	ID         interface{}
+	Geometry   interface{}
+	Properties map[string]interface{}
+}
+
+// NewFeature creates a new feature with the geometry and properties.
+// A nil properties map will be replaced by an empty one.
+func NewFeature(geometry interface{}, properties map[string]interface{}) *Feature {
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+
+	return &Feature{
+		Geometry:   geometry,
+		Properties: properties,
+	}
+}
+
+// NewGeometryFromGeoJSON converts the geojson geometry to the matching go.geo type.
+// Point is returned as *Point, MultiPoint as *PointSet, LineString as *Path,
+// MultiLineString as []*Path, Polygon as *Polygon, MultiPolygon as *MultiPolygon
+// and GeometryCollection as []interface{} of these types.
+// Only the first two values of each position are used.
+func NewGeometryFromGeoJSON(g *geojson.Geometry) (interface{}, error) {
+	if g == nil {
+		return nil, ErrInvalidGeoJSON
+	}
+
+	switch g.Type {
+	case geojson.GeometryPoint:
+		if len(g.Point) < 2 {
+			return nil, ErrInvalidGeoJSON
+		}
+		return NewPoint(g.Point[0], g.Point[1]), nil
+	case geojson.GeometryMultiPoint:
+		ps, err := pointSetFromGeoJSON(g.MultiPoint)
+		if err != nil {
+			return nil, err
+		}
+		return &ps, nil
+	case geojson.GeometryLineString:
+		ps, err := pointSetFromGeoJSON(g.LineString)
+		if err != nil {
+			return nil, err
+		}
+		return &Path{ps}, nil
+	case geojson.GeometryMultiLineString:
+		paths := make([]*Path, 0, len(g.MultiLineString))
+		for _, line := range g.MultiLineString {
+			ps, err := pointSetFromGeoJSON(line)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, &Path{ps})
+		}
+		return paths, nil
+	case geojson.GeometryPolygon:
+		p, err := polygonFromGeoJSON(g.Polygon)
+		if err != nil {
+			return nil, err
+		}
+		return &p, nil
+	case geojson.GeometryMultiPolygon:
+		mp := make(MultiPolygon, 0, len(g.MultiPolygon))
+		for _, rings := range g.MultiPolygon {
+			p, err := polygonFromGeoJSON(rings)
+			if err != nil {
+				return nil, err
+			}
+			mp = append(mp, p)
+		}
+		return &mp, nil
+	case geojson.GeometryCollection:
+		geometries := make([]interface{}, 0, len(g.Geometries))
+		for _, sub := range g.Geometries {
+			geometry, err := NewGeometryFromGeoJSON(sub)
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, geometry)
+		}
+		return geometries, nil
+	}
+
+	return nil, fmt.Errorf("go.geo: unsupported geojson geometry type %q", g.Type)
+}
+
+// NewPointFromGeoJSON converts a geojson Point geometry.
+func NewPointFromGeoJSON(g *geojson.Geometry) (*Point, error) {
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := geometry.(*Point); ok {
+		return p, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewPointSetFromGeoJSON converts a geojson MultiPoint, LineString
+// or single ring Polygon geometry.
+func NewPointSetFromGeoJSON(g *geojson.Geometry) (*PointSet, error) {
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	switch geometry := geometry.(type) {
+	case *PointSet:
+		return geometry, nil
+	case *Path:
+		return &geometry.PointSet, nil
+	case *Polygon:
+		if len(*geometry) == 1 {
+			return &(*geometry)[0], nil
+		}
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewPathFromGeoJSON converts a geojson LineString, MultiPoint
+// or single ring Polygon geometry.
+func NewPathFromGeoJSON(g *geojson.Geometry) (*Path, error) {
+	ps, err := NewPointSetFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Path{*ps}, nil
+}
+
+// NewPolygonFromGeoJSON converts a geojson Polygon geometry.
+func NewPolygonFromGeoJSON(g *geojson.Geometry) (*Polygon, error) {
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := geometry.(*Polygon); ok {
+		return p, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewMultiPolygonFromGeoJSON converts a geojson MultiPolygon or Polygon geometry.
+func NewMultiPolygonFromGeoJSON(g *geojson.Geometry) (*MultiPolygon, error) {
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	switch geometry := geometry.(type) {
+	case *MultiPolygon:
+		return geometry, nil
+	case *Polygon:
+		return &MultiPolygon{*geometry}, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewBoundFromGeoJSON returns the bound around all the coordinates
+// of a geojson geometry of any type. A multi polygon of a bound crossing
+// the anti-meridian, see Bound.ToGeoJSON, results in that bound.
+func NewBoundFromGeoJSON(g *geojson.Geometry) (*Bound, error) {
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	// a bound crossing the anti-meridian, see Bound.ToGeoJSON
+	if mp, ok := geometry.(*MultiPolygon); ok {
+		if b := newBoundFromSplitPolygons(*mp); b != nil {
+			return b, nil
+		}
+	}
+
+	var b *Bound
+	extendBound(&b, geometry)
+	if b == nil {
+		return nil, ErrInvalidGeoJSON
+	}
+
+	return b, nil
+}
+
+func extendBound(b **Bound, geometry interface{}) {
+	var gb *Bound
+	switch geometry := geometry.(type) {
+	case *Point:
+		gb = NewBoundFromPoints(geometry, geometry)
+	case *PointSet:
+		if len(*geometry) == 0 {
+			return
+		}
+		gb = geometry.Bound()
+	case *Path:
+		if len(geometry.PointSet) == 0 {
+			return
+		}
+		gb = geometry.Bound()
+	case *Polygon:
+		if len(*geometry) == 0 || len((*geometry)[0]) == 0 {
+			return
+		}
+		gb = geometry.Bound()
+	case *MultiPolygon:
+		for i := range *geometry {
+			extendBound(b, &(*geometry)[i])
+		}
+		return
+	case []*Path:
+		for _, p := range geometry {
+			extendBound(b, p)
+		}
+		return
+	case []interface{}:
+		for _, g := range geometry {
+			extendBound(b, g)
+		}
+		return
+	default:
+		return
+	}
+
+	if *b == nil {
+		*b = gb
+	} else {
+		(*b).Union(gb)
+	}
+}
+
+// NewFeatureFromGeoJSON converts a geojson feature, keeping the id and properties.
+// A null geometry, which is allowed for features, results in a nil Geometry.
+func NewFeatureFromGeoJSON(f *geojson.Feature) (*Feature, error) {
+	var geometry interface{}
+	if f.Geometry != nil {
+		var err error
+		geometry, err = NewGeometryFromGeoJSON(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	feature := NewFeature(geometry, f.Properties)
+	feature.ID = f.ID
+
+	return feature, nil
+}
+
+// NewFeaturesFromGeoJSON converts all the features of a geojson feature collection.
+func NewFeaturesFromGeoJSON(fc *geojson.FeatureCollection) ([]*Feature, error) {
+	features := make([]*Feature, 0, len(fc.Features))
+	for _, f := range fc.Features {
+		feature, err := NewFeatureFromGeoJSON(f)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, feature)
+	}
+
+	return features, nil
+}
+
+// ParseGeoJSON decodes GeoJSON data of any type, a FeatureCollection,
+// a Feature or a plain geometry, into a list of features. A plain geometry
+// will be returned as a single feature with empty properties.
+func ParseGeoJSON(data []byte) ([]*Feature, error) {
+	object := struct {
+		Type string `json:"type"`
+	}{}
+
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	switch object.Type {
+	case "FeatureCollection":
+		fc, err := geojson.UnmarshalFeatureCollection(data)
+		if err != nil {
+			return nil, err
+		}
+		return NewFeaturesFromGeoJSON(fc)
+	case "Feature":
+		f, err := geojson.UnmarshalFeature(data)
+		if err != nil {
+			return nil, err
+		}
+
+		feature, err := NewFeatureFromGeoJSON(f)
+		if err != nil {
+			return nil, err
+		}
+		return []*Feature{feature}, nil
+	}
+
+	g, err := geojson.UnmarshalGeometry(data)
+	if err != nil {
+		return nil, err
+	}
+
+	geometry, err := NewGeometryFromGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*Feature{NewFeature(geometry, nil)}, nil
+}
+
+// ToGeoJSON creates a new geojson feature with the geometry, id and properties.
+// The geometry can be any go.geo type with a ToGeoJSON method,
+// as well as []*Path, as a MultiLineString, and []interface{} of these types,
+// as a GeometryCollection. A nil geometry, including a nil pointer such as
+// (*Path)(nil), results in a null geometry.
+// Returns nil if the geometry type is not supported.
+func (f *Feature) ToGeoJSON() *geojson.Feature {
+	var g *geojson.Geometry
+	if !isNilGeometry(f.Geometry) {
+		if g = geoJSONGeometry(f.Geometry); g == nil {
+			return nil
+		}
+	}
+
+	return f.toGeoJSON(g)
+}
+
+// toGeoJSON creates a new geojson feature with the geometry and the id and properties of the feature.
+func (f *Feature) toGeoJSON(g *geojson.Geometry) *geojson.Feature {
+	feature := geojson.NewFeature(g)
+	feature.ID = f.ID
+	for k, v := range f.Properties {
+		feature.Properties[k] = v
+	}
+
+	return feature
+}
+
+func geoJSONGeometry(geometry interface{}) *geojson.Geometry {
+	if isNilGeometry(geometry) {
+		return nil
+	}
+
+	switch geometry := geometry.(type) {
+	case interface {
+		ToGeoJSON() *geojson.Feature
+	}:
+		return geometry.ToGeoJSON().Geometry
+	case []*Path:
+		lines := make([][][]float64, 0, len(geometry))
+		for _, p := range geometry {
+			if p == nil {
+				return nil
+			}
+			lines = append(lines, p.ToGeoJSON().Geometry.LineString)
+		}
+		return geojson.NewMultiLineStringGeometry(lines...)
+	case []interface{}:
+		geometries := make([]*geojson.Geometry, 0, len(geometry))
+		for _, g := range geometry {
+			sub := geoJSONGeometry(g)
+			if sub == nil {
+				return nil
+			}
+			geometries = append(geometries, sub)
+		}
+		return geojson.NewCollectionGeometry(geometries...)
+	}
+
+	return nil
+}
+
+// isNilGeometry checks for a nil geometry or a nil pointer to a geometry,
+// which can not be converted by calling its ToGeoJSON method.
+func isNilGeometry(geometry interface{}) bool {
+	if geometry == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(geometry)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// FeaturesToGeoJSON creates a new geojson feature collection from the features.
+// Features with a nil geometry, or an unsupported geometry type, have a null geometry
+// so all the features, and their properties, are kept.
+func FeaturesToGeoJSON(features []*Feature) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	for _, f := range features {
+		fc.AddFeature(f.toGeoJSON(geoJSONGeometry(f.Geometry)))
+	}
+
+	return fc
+}
+
+func pointSetFromGeoJSON(positions [][]float64) (PointSet, error) {
+	ps := make(PointSet, 0, len(positions))
+	for _, p := range positions {
+		if len(p) < 2 {
+			return nil, ErrInvalidGeoJSON
+		}
+		ps = append(ps, Point{p[0], p[1]})
+	}
+
+	return ps, nil
+}
+
+func polygonFromGeoJSON(rings [][][]float64) (Polygon, error) {
+	p := make(Polygon, 0, len(rings))
+	for _, ring := range rings {
+		ps, err := pointSetFromGeoJSON(ring)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, ps)
+	}
+
+	return p, nil
+}
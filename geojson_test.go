@@ -0,0 +1,331 @@
+package geo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/paulmach/go.geojson"
+)
+
+func TestNewGeometryFromGeoJSON(t *testing.T) {
+	type testData struct {
+		geometry *geojson.Geometry
+		expected string
+	}
+
+	tests := []testData{
+		{geojson.NewPointGeometry([]float64{1, 2}), "POINT(1 2)"},
+		{geojson.NewPointGeometry([]float64{1, 2, 3}), "POINT(1 2)"},
+		{geojson.NewMultiPointGeometry([]float64{1, 2}, []float64{3, 4}), "MULTIPOINT(1 2,3 4)"},
+		{geojson.NewLineStringGeometry([][]float64{{1, 2}, {3, 4}}), "LINESTRING(1 2,3 4)"},
+		{testPolygon().ToGeoJSON().Geometry, testPolygon().ToWKT()},
+		{NewMultiPolygon(testPolygon()).ToGeoJSON().Geometry, NewMultiPolygon(testPolygon()).ToWKT()},
+	}
+
+	for i, test := range tests {
+		g, err := NewGeometryFromGeoJSON(test.geometry)
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if s := g.(interface {
+			ToWKT() string
+		}).ToWKT(); s != test.expected {
+			t.Errorf("test %d incorrect geometry, got %v", i, s)
+		}
+	}
+
+	// multi line string
+	g, err := NewGeometryFromGeoJSON(geojson.NewMultiLineStringGeometry([][]float64{{1, 2}, {3, 4}}, [][]float64{{5, 6}, {7, 8}}))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if paths := g.([]*Path); len(paths) != 2 || paths[1].String() != "LINESTRING(5 6,7 8)" {
+		t.Errorf("incorrect paths, got %v", paths)
+	}
+
+	// collection
+	g, err = NewGeometryFromGeoJSON(geojson.NewCollectionGeometry(
+		geojson.NewPointGeometry([]float64{1, 2}),
+		geojson.NewLineStringGeometry([][]float64{{1, 2}, {3, 4}}),
+	))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if geometries := g.([]interface{}); len(geometries) != 2 {
+		t.Errorf("incorrect geometries, got %v", geometries)
+	}
+
+	// error conditions
+	if _, err := NewGeometryFromGeoJSON(nil); err != ErrInvalidGeoJSON {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := NewGeometryFromGeoJSON(geojson.NewPointGeometry([]float64{1})); err != ErrInvalidGeoJSON {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := NewGeometryFromGeoJSON(geojson.NewLineStringGeometry([][]float64{{1, 2}, {3}})); err != ErrInvalidGeoJSON {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := NewGeometryFromGeoJSON(&geojson.Geometry{Type: "Circle"}); err == nil {
+		t.Errorf("should get error for unsupported type")
+	}
+}
+
+func TestNewTypesFromGeoJSON(t *testing.T) {
+	line := geojson.NewLineStringGeometry([][]float64{{1, 2}, {3, 4}})
+	point := geojson.NewPointGeometry([]float64{1, 2})
+
+	if p, err := NewPointFromGeoJSON(point); err != nil || !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v %v", p, err)
+	}
+
+	if _, err := NewPointFromGeoJSON(line); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if p, err := NewPathFromGeoJSON(line); err != nil || p.Length() != 2 {
+		t.Errorf("incorrect path, got %v %v", p, err)
+	}
+
+	if ps, err := NewPointSetFromGeoJSON(NewPolygonFromBound(NewBound(0, 1, 0, 1)).ToGeoJSON().Geometry); err != nil || ps.Length() != 5 {
+		t.Errorf("incorrect point set, got %v %v", ps, err)
+	}
+
+	if _, err := NewPathFromGeoJSON(point); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if p, err := NewPolygonFromGeoJSON(testPolygon().ToGeoJSON().Geometry); err != nil || !p.Equals(testPolygon()) {
+		t.Errorf("incorrect polygon, got %v %v", p, err)
+	}
+
+	if _, err := NewPolygonFromGeoJSON(line); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if mp, err := NewMultiPolygonFromGeoJSON(testPolygon().ToGeoJSON().Geometry); err != nil || len(*mp) != 1 {
+		t.Errorf("incorrect multi polygon, got %v %v", mp, err)
+	}
+
+	if _, err := NewMultiPolygonFromGeoJSON(line); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestNewBoundFromGeoJSON(t *testing.T) {
+	b := NewBound(1, 2, 3, 4)
+
+	bound, err := NewBoundFromGeoJSON(b.ToGeoJSON().Geometry)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !bound.Equals(b) {
+		t.Errorf("incorrect bound, got %v", bound)
+	}
+
+	bound, err = NewBoundFromGeoJSON(geojson.NewCollectionGeometry(
+		geojson.NewPointGeometry([]float64{-1, -2}),
+		geojson.NewMultiLineStringGeometry([][]float64{{1, 2}, {3, 4}}),
+	))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !bound.Equals(NewBound(-1, 3, -2, 4)) {
+		t.Errorf("incorrect bound, got %v", bound)
+	}
+
+	// crossing the anti-meridian
+	b = NewGeoBound(170, -170, -10, 10)
+	if g := b.ToGeoJSON().Geometry; g.Type != geojson.GeometryMultiPolygon {
+		t.Errorf("should be a multi polygon, got %v", g.Type)
+	}
+
+	bound, err = NewBoundFromGeoJSON(b.ToGeoJSON().Geometry)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !bound.Equals(b) || !bound.CrossesAntimeridian() {
+		t.Errorf("incorrect bound, got %v", bound)
+	}
+
+	if _, err := NewBoundFromGeoJSON(geojson.NewLineStringGeometry(nil)); err != ErrInvalidGeoJSON {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	data := []byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "id": 1, "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"name": "a"}},
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[1, 2], [3, 4]]}, "properties": {"speed": 10}}
+		]
+	}`)
+
+	features, err := ParseGeoJSON(data)
+	if err != nil {
+		t.Fatalf("should not get error, got %v", err)
+	}
+
+	if len(features) != 2 {
+		t.Fatalf("incorrect number of features, got %d", len(features))
+	}
+
+	if p := features[0].Geometry.(*Point); !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v", p)
+	}
+
+	if features[0].ID != 1.0 || features[0].Properties["name"] != "a" {
+		t.Errorf("incorrect feature, got %v", features[0])
+	}
+
+	if p := features[1].Geometry.(*Path); p.Length() != 2 || features[1].Properties["speed"] != 10.0 {
+		t.Errorf("incorrect feature, got %v", features[1])
+	}
+
+	// feature
+	features, err = ParseGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"name": "a"}}`))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if len(features) != 1 || features[0].Properties["name"] != "a" {
+		t.Errorf("incorrect features, got %v", features)
+	}
+
+	// geometry
+	features, err = ParseGeoJSON([]byte(`{"type": "MultiPoint", "coordinates": [[1, 2], [3, 4]]}`))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if len(features) != 1 || features[0].Geometry.(*PointSet).Length() != 2 || features[0].Properties == nil {
+		t.Errorf("incorrect features, got %v", features)
+	}
+
+	// error conditions
+	if _, err := ParseGeoJSON([]byte(`{`)); err == nil {
+		t.Errorf("should get error for invalid json")
+	}
+
+	if _, err := ParseGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1]}}`)); err != ErrInvalidGeoJSON {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	// features without a geometry
+	features, err = ParseGeoJSON([]byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": null, "properties": {"name": "a"}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": null}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("should not get error for null geometry, got %v", err)
+	}
+
+	if len(features) != 2 || features[0].Geometry != nil || features[0].Properties["name"] != "a" {
+		t.Errorf("incorrect features, got %v", features)
+	}
+}
+
+func TestFeaturesToGeoJSON(t *testing.T) {
+	features := []*Feature{
+		NewFeature(NewPoint(1, 2), map[string]interface{}{"name": "a"}),
+		NewFeature(NewPath().Push(NewPoint(1, 2)).Push(NewPoint(3, 4)), nil),
+		NewFeature(NewBound(0, 1, 0, 1), nil),
+		NewFeature([]*Path{NewPath().Push(NewPoint(1, 2))}, nil),
+		NewFeature([]interface{}{NewPoint(1, 2), testPolygon()}, nil),
+		NewFeature("not a geometry", map[string]interface{}{"name": "b"}),
+		NewFeature(nil, nil),
+	}
+	features[0].ID = "id"
+
+	fc := FeaturesToGeoJSON(features)
+	if len(fc.Features) != 7 {
+		t.Fatalf("should keep all the features, got %d features", len(fc.Features))
+	}
+
+	if f := fc.Features[5]; f.Geometry != nil || f.Properties["name"] != "b" {
+		t.Errorf("unsupported geometry should be null, got %v", f)
+	}
+
+	if f := fc.Features[6]; f.Geometry != nil {
+		t.Errorf("nil geometry should be null, got %v", f.Geometry)
+	}
+
+	if f := features[5].ToGeoJSON(); f != nil {
+		t.Errorf("should be nil for unsupported geometry, got %v", f)
+	}
+
+	if f := features[6].ToGeoJSON(); f == nil || f.Geometry != nil {
+		t.Errorf("should have a null geometry, got %v", f)
+	}
+
+	for i, g := range []interface{}{(*Point)(nil), (*Path)(nil), (*Polygon)(nil), (*Bound)(nil)} {
+		f := NewFeature(g, nil)
+		if gf := f.ToGeoJSON(); gf == nil || gf.Geometry != nil {
+			t.Errorf("nil pointer %d should have a null geometry, got %v", i, gf)
+		}
+
+		data, err := FeaturesToGeoJSON([]*Feature{f}).MarshalJSON()
+		if err != nil {
+			t.Fatalf("should marshal nil pointer %d, got %v", i, err)
+		}
+
+		if !strings.Contains(string(data), `"geometry":null`) {
+			t.Errorf("nil pointer %d should marshal a null geometry, got %s", i, data)
+		}
+	}
+
+	if fc.Features[0].ID != "id" || fc.Features[0].Properties["name"] != "a" {
+		t.Errorf("incorrect feature, got %v", fc.Features[0])
+	}
+
+	types := []geojson.GeometryType{
+		geojson.GeometryPoint,
+		geojson.GeometryLineString,
+		geojson.GeometryPolygon,
+		geojson.GeometryMultiLineString,
+		geojson.GeometryCollection,
+	}
+
+	for i, f := range fc.Features[:5] {
+		if f.Geometry.Type != types[i] {
+			t.Errorf("feature %d incorrect type, got %v", i, f.Geometry.Type)
+		}
+	}
+
+	// round trip
+	data, err := json.Marshal(fc)
+	if err != nil {
+		t.Errorf("should marshal just fine, %v", err)
+	}
+
+	parsed, err := ParseGeoJSON(data)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if len(parsed) != 7 || parsed[0].Properties["name"] != "a" {
+		t.Errorf("incorrect round trip, got %v", parsed)
+	}
+
+	if parsed[6].Geometry != nil {
+		t.Errorf("null geometry should round trip, got %v", parsed[6].Geometry)
+	}
+
+	if p := parsed[2].Geometry.(*Polygon); !p.Bound().Equals(NewBound(0, 1, 0, 1)) {
+		t.Errorf("incorrect bound polygon, got %v", p)
+	}
+}
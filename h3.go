@@ -0,0 +1,1154 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// An H3Index identifies a cell in a hexagonal hierarchical grid compatible with
+// Uber's H3 library. The sphere is projected onto the twenty faces of an icosahedron
+// and divided into 122 base cells, 12 of them pentagons centered on the vertices
+// of the icosahedron. Each cell is divided into seven children, with every other
+// resolution rotated, down to resolution 15. Indexes are the same 64 bit integers
+// as H3 and String returns the usual hex representation.
+// Points are treated as on a sphere, the same as the Geo* methods.
+type H3Index uint64
+
+// H3MaxResolution is the resolution of the smallest cells, about 1 square meter.
+const H3MaxResolution = 15
+
+const (
+	h3NumFaces     = 20
+	h3NumBaseCells = 122
+
+	h3ModeCell       = 1
+	h3ModeOffset     = 59
+	h3ResOffset      = 52
+	h3BaseCellOffset = 45
+	h3DigitMask      = 7
+	h3DigitsMask     = 1<<h3BaseCellOffset - 1
+
+	h3Res0UGnomonic = 0.38196601125010500003
+	h3Sqrt7         = 2.6457513110645905905016157536392604257102
+	h3Sqrt3_2       = 0.8660254037844386467637231707529361834714
+	h3RSin60        = 1.1547005383792515290182975610039149112953
+	h3Ap7Rot        = 0.333473172251832115336090755351601070065900389
+	h3Epsilon       = 1e-16
+	h3FloatEpsilon  = 1.1920929e-7
+)
+
+// the directions of a child cell from the center of its parent
+const (
+	h3CenterDigit = iota
+	h3KAxesDigit
+	h3JAxesDigit
+	h3JKAxesDigit
+	h3IAxesDigit
+	h3IKAxesDigit
+	h3IJAxesDigit
+	h3InvalidDigit
+)
+
+// the quadrants of a face, used to find the face across an edge
+const (
+	h3Center = iota
+	h3IJ
+	h3KI
+	h3JK
+)
+
+// the result of moving coordinates onto a neighboring face
+const (
+	h3NoOverage = iota
+	h3FaceEdge
+	h3NewFace
+)
+
+// the ijk coordinate of each digit
+var h3UnitVecs = [7]h3IJK{
+	{0, 0, 0}, {0, 0, 1}, {0, 1, 0}, {0, 1, 1}, {1, 0, 0}, {1, 0, 1}, {1, 1, 0},
+}
+
+// the vertices of an origin centered cell, counter clockwise from the i axis,
+// in the aperture 3 substrate grid of Class II and Class III resolutions.
+// Pentagons use the first five.
+var (
+	h3VertsCII  = [6]h3IJK{{2, 1, 0}, {1, 2, 0}, {0, 2, 1}, {0, 1, 2}, {1, 0, 2}, {2, 0, 1}}
+	h3VertsCIII = [6]h3IJK{{5, 4, 0}, {1, 5, 0}, {0, 5, 4}, {0, 1, 5}, {4, 0, 5}, {5, 0, 1}}
+)
+
+var (
+	h3FaceCenterXYZ   [h3NumFaces][3]float64
+	h3AdjacentFaceDir [h3NumFaces][h3NumFaces]int
+)
+
+// h3IJK are coordinates on the hexagonal grid of a face using three axes
+// 120 degrees apart. Normalized coordinates have no negative values
+// and at least one zero.
+type h3IJK struct {
+	i, j, k int
+}
+
+type h3FaceIJK struct {
+	face  int
+	coord h3IJK
+}
+
+type h3FaceOrientIJK struct {
+	face      int
+	translate h3IJK
+	ccwRot60  int
+}
+
+type h3BaseCellData struct {
+	home     h3FaceIJK
+	pentagon bool
+	cwOffset [2]int
+}
+
+type h3BaseCellRotation struct {
+	baseCell  int
+	ccwRot60s int
+}
+
+func init() {
+	for f := 0; f < h3NumFaces; f++ {
+		lat, lng := h3FaceCenterGeo[f][0], h3FaceCenterGeo[f][1]
+		h3FaceCenterXYZ[f] = [3]float64{
+			math.Cos(lat) * math.Cos(lng),
+			math.Cos(lat) * math.Sin(lng),
+			math.Sin(lat),
+		}
+
+		for g := range h3AdjacentFaceDir[f] {
+			h3AdjacentFaceDir[f][g] = -1
+		}
+
+		for dir, orient := range h3FaceNeighbors[f] {
+			h3AdjacentFaceDir[f][orient.face] = dir
+		}
+	}
+}
+
+// NewH3IndexFromPoint returns the cell, at the given resolution, containing the lng/lat point.
+// Will panic if the resolution is not between 0 and H3MaxResolution.
+func NewH3IndexFromPoint(p *Point, res int) H3Index {
+	if res < 0 || res > H3MaxResolution {
+		panic(fmt.Sprintf("geo: h3 resolution out of range, given %d", res))
+	}
+
+	face, x, y := h3PointToHex2d(p, res)
+	return h3FaceIJK{face, h3Hex2dToIJK(x, y)}.index(res)
+}
+
+// NewH3IndexFromString returns the cell for the hex representation, see H3Index.String.
+// Returns 0, an invalid cell, if the string can not be parsed.
+func NewH3IndexFromString(s string) H3Index {
+	id, err := strconv.ParseUint(s, 16, 64)
+	if err != nil || !H3Index(id).Valid() {
+		return 0
+	}
+
+	return H3Index(id)
+}
+
+// String returns the hex representation of the index, as used by H3.
+func (h H3Index) String() string {
+	return strconv.FormatUint(uint64(h), 16)
+}
+
+// Valid returns true if the index represents a valid cell.
+func (h H3Index) Valid() bool {
+	if h>>63 != 0 || int(h>>h3ModeOffset)&0xF != h3ModeCell || (h>>56)&7 != 0 {
+		return false
+	}
+
+	if h.BaseCell() >= h3NumBaseCells {
+		return false
+	}
+
+	res := h.Resolution()
+	for r := 1; r <= H3MaxResolution; r++ {
+		d := h.digit(r)
+		if (r <= res && d == h3InvalidDigit) || (r > res && d != h3InvalidDigit) {
+			return false
+		}
+	}
+
+	return !h3BaseCells[h.BaseCell()].pentagon || h.leadingDigit() != h3KAxesDigit
+}
+
+// Resolution returns the resolution of the cell, 0 for a base cell to 15.
+func (h H3Index) Resolution() int {
+	return int(h>>h3ResOffset) & 0xF
+}
+
+// BaseCell returns the resolution 0 cell, 0 to 121, containing the cell.
+func (h H3Index) BaseCell() int {
+	return int(h>>h3BaseCellOffset) & 0x7F
+}
+
+// IsPentagon returns true if the cell is one of the 12 pentagons at each resolution.
+// Pentagons have five neighbors and six children.
+func (h H3Index) IsPentagon() bool {
+	return h3BaseCells[h.BaseCell()].pentagon && h.leadingDigit() == h3CenterDigit
+}
+
+// Parent returns the cell at the previous resolution containing this cell.
+// The parent of a base cell is itself.
+func (h H3Index) Parent() H3Index {
+	res := h.Resolution()
+	if res == 0 {
+		return h
+	}
+
+	return h.ParentAtResolution(res - 1)
+}
+
+// ParentAtResolution returns the cell at the given resolution containing this cell.
+// The resolution must be between 0 and the resolution of the cell.
+func (h H3Index) ParentAtResolution(res int) H3Index {
+	for r := res + 1; r <= h.Resolution(); r++ {
+		h = h.setDigit(r, h3InvalidDigit)
+	}
+
+	return h.setResolution(res)
+}
+
+// Children returns the cells at the next resolution within this cell,
+// seven for hexagons and six for pentagons. Cells at the maximum
+// resolution have no children.
+func (h H3Index) Children() []H3Index {
+	if h.Resolution() == H3MaxResolution {
+		return nil
+	}
+
+	return h.ChildrenAtResolution(h.Resolution() + 1)
+}
+
+// ChildrenAtResolution returns all the cells at the given resolution within this cell.
+// The resolution must be between the resolution of the cell and H3MaxResolution.
+func (h H3Index) ChildrenAtResolution(res int) []H3Index {
+	result := []H3Index{h}
+	for r := h.Resolution() + 1; r <= res; r++ {
+		next := make([]H3Index, 0, 7*len(result))
+		for _, c := range result {
+			pentagon := c.IsPentagon()
+			c = c.setResolution(r)
+
+			for d := h3CenterDigit; d < h3InvalidDigit; d++ {
+				// the k axes sub sequence is deleted from pentagons
+				if pentagon && d == h3KAxesDigit {
+					continue
+				}
+
+				next = append(next, c.setDigit(r, d))
+			}
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// Center returns the lng/lat center of the cell.
+func (h H3Index) Center() *Point {
+	f := h.faceIJK()
+	x, y := f.coord.hex2d()
+
+	return h3Hex2dToPoint(x, y, f.face, h.Resolution(), false)
+}
+
+// Boundary returns the vertices of the cell as a closed, counter clockwise, ring.
+// Cells with edges crossing the edges of the icosahedron have extra vertices
+// where they cross, so there may be up to ten.
+func (h H3Index) Boundary() *PointSet {
+	f := h.faceIJK()
+
+	var ring PointSet
+	if h.IsPentagon() {
+		ring = f.pentagonBoundary(h.Resolution())
+	} else {
+		ring = f.hexagonBoundary(h.Resolution())
+	}
+
+	ring = append(ring, ring[0])
+	return &ring
+}
+
+// Bound returns a lng/lat bound containing the cell. The bound crosses
+// the anti-meridian if the cell does, and covers all longitudes if the cell contains a pole.
+func (h H3Index) Bound() *Bound {
+	ring := *h.Boundary()
+
+	south, north := 90.0, -90.0
+	for i := 0; i < len(ring)-1; i++ {
+		lo, hi := cellArcLatRange(cellPointToXYZ(&ring[i]), cellPointToXYZ(&ring[i+1]))
+
+		south = math.Min(south, lo)
+		north = math.Max(north, hi)
+	}
+
+	// unwrap the longitudes around the ring, a ring around a pole
+	// ends up a full circle from where it started.
+	lng := ring[0].Lng()
+	west, east := lng, lng
+	for i := 1; i < len(ring); i++ {
+		lng += normalizeLng(ring[i].Lng() - ring[i-1].Lng())
+
+		west = math.Min(west, lng)
+		east = math.Max(east, lng)
+	}
+
+	if math.Abs(lng-ring[0].Lng()) > 180 {
+		if north > 0 {
+			return NewBound(-180, 180, south, 90)
+		}
+
+		return NewBound(-180, 180, -90, north)
+	}
+
+	return NewGeoBound(normalizeLng(west), normalizeLng(east), south, north)
+}
+
+// Neighbors returns the cells at the same resolution sharing an edge with this cell,
+// six for hexagons and five for pentagons.
+func (h H3Index) Neighbors() []H3Index {
+	res := h.Resolution()
+	center := cellPointToXYZ(h.Center())
+	ring := *h.Boundary()
+
+	result := make([]H3Index, 0, 6)
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := cellPointToXYZ(&ring[i]), cellPointToXYZ(&ring[i+1])
+
+		// points just outside the edge, every point close
+		// to the boundary is in a neighboring cell.
+		for _, t := range []float64{0.25, 0.5, 0.75} {
+			var p [3]float64
+			for j := range p {
+				edge := a[j] + t*(b[j]-a[j])
+				p[j] = edge + 0.1*(edge-center[j])
+			}
+
+			n := NewH3IndexFromPoint(cellXYZToPoint(p), res)
+			if n != h && !h3Contains(result, n) {
+				result = append(result, n)
+			}
+		}
+	}
+
+	return result
+}
+
+// KRing returns the cells within k steps of this cell, starting with the cell
+// itself followed by the rings of cells at each distance.
+func (h H3Index) KRing(k int) []H3Index {
+	result := []H3Index{h}
+	seen := map[H3Index]bool{h: true}
+
+	ring := result
+	for i := 0; i < k; i++ {
+		var next []H3Index
+		for _, c := range ring {
+			for _, n := range c.Neighbors() {
+				if !seen[n] {
+					seen[n] = true
+					next = append(next, n)
+				}
+			}
+		}
+
+		result = append(result, next...)
+		ring = next
+	}
+
+	return result
+}
+
+// H3IndexesInBound returns the sorted cells, at the given resolution, whose centers
+// are within the bound. This is the H3 polyfill of the bound, treating the edges of
+// the bound as lines of constant latitude and longitude.
+// Bounds crossing the anti-meridian are supported.
+func H3IndexesInBound(b *Bound, res int) []H3Index {
+	start := NewH3IndexFromPoint(b.Center(), res)
+
+	seen := map[H3Index]bool{start: true}
+	queue := []H3Index{start}
+
+	var result []H3Index
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+
+		if b.Contains(h.Center()) {
+			result = append(result, h)
+		}
+
+		// the cells intersecting the bound are connected so
+		// searching through them will find all the centers.
+		for _, n := range h.Neighbors() {
+			if !seen[n] {
+				seen[n] = true
+				if n.Bound().Intersects(b) {
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func (h H3Index) digit(r int) int {
+	return int(h>>(3*uint(H3MaxResolution-r))) & h3DigitMask
+}
+
+func (h H3Index) setDigit(r, digit int) H3Index {
+	shift := 3 * uint(H3MaxResolution-r)
+	return h&^(h3DigitMask<<shift) | H3Index(digit)<<shift
+}
+
+func (h H3Index) setResolution(res int) H3Index {
+	return h&^(0xF<<h3ResOffset) | H3Index(res)<<h3ResOffset
+}
+
+// leadingDigit returns the first non zero digit, or 0 if they are all zero.
+func (h H3Index) leadingDigit() int {
+	for r := 1; r <= h.Resolution(); r++ {
+		if d := h.digit(r); d != h3CenterDigit {
+			return d
+		}
+	}
+
+	return h3CenterDigit
+}
+
+func (h H3Index) rotate60ccw() H3Index {
+	for r := 1; r <= h.Resolution(); r++ {
+		h = h.setDigit(r, h3Rotate60ccw(h.digit(r)))
+	}
+
+	return h
+}
+
+func (h H3Index) rotate60cw() H3Index {
+	for r := 1; r <= h.Resolution(); r++ {
+		h = h.setDigit(r, h3Rotate60cw(h.digit(r)))
+	}
+
+	return h
+}
+
+// rotatePent60ccw rotates a pentagon cell, skipping over
+// the deleted k axes sub sequence.
+func (h H3Index) rotatePent60ccw() H3Index {
+	found := false
+	for r := 1; r <= h.Resolution(); r++ {
+		h = h.setDigit(r, h3Rotate60ccw(h.digit(r)))
+
+		if !found && h.digit(r) != h3CenterDigit {
+			found = true
+			if h.leadingDigit() == h3KAxesDigit {
+				h = h.rotate60ccw()
+			}
+		}
+	}
+
+	return h
+}
+
+// faceIJK returns the coordinates of the cell center on the face containing it.
+func (h H3Index) faceIJK() h3FaceIJK {
+	data := h3BaseCells[h.BaseCell()]
+
+	// the ik axes sub sequence of pentagons is rotated into the deleted k axes
+	if data.pentagon && h.leadingDigit() == h3IKAxesDigit {
+		h = h.rotate60cw()
+	}
+
+	f := data.home
+	res := h.Resolution()
+
+	// hexagons centered on the face are entirely on the face
+	possibleOverage := data.pentagon || (res != 0 && f.coord != h3IJK{})
+
+	for r := 1; r <= res; r++ {
+		if h3IsClassIII(r) {
+			f.coord.downAp7()
+		} else {
+			f.coord.downAp7r()
+		}
+
+		f.coord = f.coord.neighbor(h.digit(r))
+	}
+
+	if !possibleOverage {
+		return f
+	}
+
+	// the overage is found on the next finer Class II grid
+	orig := f.coord
+	adjRes := res
+	if h3IsClassIII(res) {
+		f.coord.downAp7r()
+		adjRes++
+	}
+
+	pentLeading4 := data.pentagon && h.leadingDigit() == h3IAxesDigit
+	if f.adjustOverage(adjRes, pentLeading4, false) != h3NoOverage {
+		// pentagons may be more than one face away
+		if data.pentagon {
+			for f.adjustOverage(adjRes, false, false) != h3NoOverage {
+			}
+		}
+
+		if adjRes != res {
+			f.coord.upAp7r()
+		}
+	} else if adjRes != res {
+		f.coord = orig
+	}
+
+	return f
+}
+
+// index returns the cell, at the given resolution, containing the face coordinates.
+func (f h3FaceIJK) index(res int) H3Index {
+	h := H3Index(h3ModeCell)<<h3ModeOffset | H3Index(res)<<h3ResOffset | h3DigitsMask
+
+	// walk up to the base cell recording the digit at each resolution
+	c := f.coord
+	for r := res - 1; r >= 0; r-- {
+		last := c
+
+		var center h3IJK
+		if h3IsClassIII(r + 1) {
+			c.upAp7()
+			center = c
+			center.downAp7()
+		} else {
+			c.upAp7r()
+			center = c
+			center.downAp7r()
+		}
+
+		diff := last.sub(center)
+		diff.normalize()
+
+		h = h.setDigit(r+1, diff.digit())
+	}
+
+	if c.i > 2 || c.j > 2 || c.k > 2 {
+		return 0
+	}
+
+	bc := h3FaceIJKBaseCells[f.face][c.i][c.j][c.k]
+	h |= H3Index(bc.baseCell) << h3BaseCellOffset
+
+	// rotate into the coordinates of the base cell's home face
+	data := h3BaseCells[bc.baseCell]
+	if !data.pentagon {
+		for i := 0; i < bc.ccwRot60s; i++ {
+			h = h.rotate60ccw()
+		}
+
+		return h
+	}
+
+	if h.leadingDigit() == h3KAxesDigit {
+		// rotate out of the deleted k axes sub sequence
+		if data.cwOffset[0] == f.face || data.cwOffset[1] == f.face {
+			h = h.rotate60cw()
+		} else {
+			h = h.rotate60ccw()
+		}
+	}
+
+	for i := 0; i < bc.ccwRot60s; i++ {
+		h = h.rotatePent60ccw()
+	}
+
+	return h
+}
+
+// adjustOverage moves coordinates beyond the edge of the face, at a Class II
+// resolution, onto the neighboring face. Substrate coordinates are
+// the vertices of cells, see vertices.
+func (f *h3FaceIJK) adjustOverage(res int, pentLeading4, substrate bool) int {
+	maxDim := h3MaxDim(res)
+	unitScale := h3UnitScale(res)
+	if substrate {
+		maxDim *= 3
+		unitScale *= 3
+	}
+
+	sum := f.coord.i + f.coord.j + f.coord.k
+	if substrate && sum == maxDim {
+		return h3FaceEdge
+	}
+
+	if sum <= maxDim {
+		return h3NoOverage
+	}
+
+	var orient h3FaceOrientIJK
+	if f.coord.k > 0 {
+		if f.coord.j > 0 {
+			orient = h3FaceNeighbors[f.face][h3JK]
+		} else {
+			orient = h3FaceNeighbors[f.face][h3KI]
+
+			// adjust for the deleted pentagon sub sequence by
+			// rotating around the center of the pentagon
+			if pentLeading4 {
+				origin := h3IJK{maxDim, 0, 0}
+				tmp := f.coord.sub(origin)
+				tmp.rotate60cw()
+				f.coord = tmp.add(origin)
+			}
+		}
+	} else {
+		orient = h3FaceNeighbors[f.face][h3IJ]
+	}
+
+	f.face = orient.face
+	for i := 0; i < orient.ccwRot60; i++ {
+		f.coord.rotate60ccw()
+	}
+
+	f.coord = f.coord.add(orient.translate.scale(unitScale))
+	f.coord.normalize()
+
+	// vertices of pentagons can end up on an edge
+	if substrate && f.coord.i+f.coord.j+f.coord.k == maxDim {
+		return h3FaceEdge
+	}
+
+	return h3NewFace
+}
+
+// vertices returns the substrate coordinates of the vertices of the cell
+// and the Class II resolution they are in.
+func (f h3FaceIJK) vertices(res, count int) ([]h3FaceIJK, int) {
+	verts := h3VertsCII
+	if h3IsClassIII(res) {
+		verts = h3VertsCIII
+	}
+
+	c := f.coord
+	c.downAp3()
+	c.downAp3r()
+
+	if h3IsClassIII(res) {
+		c.downAp7r()
+		res++
+	}
+
+	result := make([]h3FaceIJK, count)
+	for v := range result {
+		result[v] = h3FaceIJK{f.face, c.add(verts[v])}
+		result[v].coord.normalize()
+	}
+
+	return result, res
+}
+
+func (f h3FaceIJK) hexagonBoundary(res int) PointSet {
+	verts, adjRes := f.vertices(res, 6)
+	ring := make(PointSet, 0, 10)
+
+	lastFace, lastOverage := -1, h3NoOverage
+
+	// one extra iteration for a crossing on the last edge
+	for vert := 0; vert <= 6; vert++ {
+		v := vert % 6
+		fijk := verts[v]
+		overage := fijk.adjustOverage(adjRes, false, true)
+
+		// Class III edges may cross the edge of the icosahedron and each part is
+		// projected using its own face, so add a vertex where they cross.
+		// Class II cells have vertices on the edges of the faces.
+		if h3IsClassIII(res) && vert > 0 && fijk.face != lastFace && lastOverage != h3FaceEdge {
+			x0, y0 := verts[(v+5)%6].coord.hex2d()
+			x1, y1 := verts[v].coord.hex2d()
+
+			face := lastFace
+			if lastFace == f.face {
+				face = fijk.face
+			}
+
+			ex0, ey0, ex1, ey1 := h3FaceEdgeHex2d(h3AdjacentFaceDir[f.face][face], adjRes)
+			x, y := h3Intersect(x0, y0, x1, y1, ex0, ey0, ex1, ey1)
+
+			// no extra vertex if it crosses at a vertex
+			if !h3AlmostEqual(x0, y0, x, y) && !h3AlmostEqual(x1, y1, x, y) {
+				ring = append(ring, *h3Hex2dToPoint(x, y, f.face, adjRes, true))
+			}
+		}
+
+		if vert < 6 {
+			x, y := fijk.coord.hex2d()
+			ring = append(ring, *h3Hex2dToPoint(x, y, fijk.face, adjRes, true))
+		}
+
+		lastFace, lastOverage = fijk.face, overage
+	}
+
+	return ring
+}
+
+func (f h3FaceIJK) pentagonBoundary(res int) PointSet {
+	verts, adjRes := f.vertices(res, 5)
+	ring := make(PointSet, 0, 10)
+
+	var last h3FaceIJK
+	for vert := 0; vert <= 5; vert++ {
+		fijk := verts[vert%5]
+		for fijk.adjustOverage(adjRes, false, true) == h3NewFace {
+		}
+
+		// all Class III pentagon edges cross the edges of the icosahedron
+		if h3IsClassIII(res) && vert > 0 {
+			x0, y0 := last.coord.hex2d()
+
+			// the vertex in the coordinates of the last face
+			tmp := fijk
+			orient := h3FaceNeighbors[tmp.face][h3AdjacentFaceDir[tmp.face][last.face]]
+			tmp.face = orient.face
+			for i := 0; i < orient.ccwRot60; i++ {
+				tmp.coord.rotate60ccw()
+			}
+
+			tmp.coord = tmp.coord.add(orient.translate.scale(3 * h3UnitScale(adjRes)))
+			tmp.coord.normalize()
+
+			x1, y1 := tmp.coord.hex2d()
+
+			ex0, ey0, ex1, ey1 := h3FaceEdgeHex2d(h3AdjacentFaceDir[tmp.face][fijk.face], adjRes)
+			x, y := h3Intersect(x0, y0, x1, y1, ex0, ey0, ex1, ey1)
+			ring = append(ring, *h3Hex2dToPoint(x, y, tmp.face, adjRes, true))
+		}
+
+		if vert < 5 {
+			x, y := fijk.coord.hex2d()
+			ring = append(ring, *h3Hex2dToPoint(x, y, fijk.face, adjRes, true))
+		}
+
+		last = fijk
+	}
+
+	return ring
+}
+
+// h3FaceEdgeHex2d returns the substrate hex2d end points of the edge of a face
+// in the direction of the neighboring face.
+func h3FaceEdgeHex2d(dir, res int) (x0, y0, x1, y1 float64) {
+	maxDim := float64(h3MaxDim(res))
+
+	v := [3][2]float64{
+		{3 * maxDim, 0},
+		{-1.5 * maxDim, 3 * h3Sqrt3_2 * maxDim},
+		{-1.5 * maxDim, -3 * h3Sqrt3_2 * maxDim},
+	}
+
+	switch dir {
+	case h3IJ:
+		return v[0][0], v[0][1], v[1][0], v[1][1]
+	case h3JK:
+		return v[1][0], v[1][1], v[2][0], v[2][1]
+	default:
+		return v[2][0], v[2][1], v[0][0], v[0][1]
+	}
+}
+
+// h3Intersect returns the intersection of the lines through the two pairs of points.
+func h3Intersect(x0, y0, x1, y1, x2, y2, x3, y3 float64) (float64, float64) {
+	sx1, sy1 := x1-x0, y1-y0
+	sx2, sy2 := x3-x2, y3-y2
+
+	t := (sx2*(y0-y2) - sy2*(x0-x2)) / (-sx2*sy1 + sx1*sy2)
+	return x0 + t*sx1, y0 + t*sy1
+}
+
+func h3AlmostEqual(x0, y0, x1, y1 float64) bool {
+	return math.Abs(x0-x1) < h3FloatEpsilon && math.Abs(y0-y1) < h3FloatEpsilon
+}
+
+// h3PointToHex2d returns the closest face to the point and its position on the
+// gnomonic projection of the face, scaled so the cells are one unit apart.
+func h3PointToHex2d(p *Point, res int) (face int, x, y float64) {
+	xyz := cellPointToXYZ(p)
+
+	sqd := 5.0
+	for f := range h3FaceCenterXYZ {
+		d := [3]float64{
+			h3FaceCenterXYZ[f][0] - xyz[0],
+			h3FaceCenterXYZ[f][1] - xyz[1],
+			h3FaceCenterXYZ[f][2] - xyz[2],
+		}
+
+		if s := cellDot(d, d); s < sqd {
+			face, sqd = f, s
+		}
+	}
+
+	// cos(r) = 1 - 2 * sin^2(r/2) = 1 - sqd/2
+	r := math.Acos(1 - sqd/2)
+	if r < h3Epsilon {
+		return face, 0, 0
+	}
+
+	// counter clockwise from the Class II i axis
+	az := h3Azimuth(h3FaceCenterGeo[face][0], h3FaceCenterGeo[face][1], deg2rad(p.Lat()), deg2rad(p.Lng()))
+	theta := h3PosAngle(h3FaceAxesAzRadsCII[face][0] - h3PosAngle(az))
+	if h3IsClassIII(res) {
+		theta = h3PosAngle(theta - h3Ap7Rot)
+	}
+
+	r = math.Tan(r) / h3Res0UGnomonic
+	for i := 0; i < res; i++ {
+		r *= h3Sqrt7
+	}
+
+	return face, r * math.Cos(theta), r * math.Sin(theta)
+}
+
+// h3Hex2dToPoint is the inverse of h3PointToHex2d. Substrate coordinates are
+// on the finer grid used for the vertices of cells.
+func h3Hex2dToPoint(x, y float64, face, res int, substrate bool) *Point {
+	r := math.Hypot(x, y)
+	if r < h3Epsilon {
+		return &Point{rad2deg(h3FaceCenterGeo[face][1]), rad2deg(h3FaceCenterGeo[face][0])}
+	}
+
+	theta := math.Atan2(y, x)
+	for i := 0; i < res; i++ {
+		r /= h3Sqrt7
+	}
+
+	if substrate {
+		r /= 3
+		if h3IsClassIII(res) {
+			r /= h3Sqrt7
+		}
+	}
+
+	r = math.Atan(r * h3Res0UGnomonic)
+
+	// substrate grids are already in Class II
+	if !substrate && h3IsClassIII(res) {
+		theta = h3PosAngle(theta + h3Ap7Rot)
+	}
+
+	theta = h3PosAngle(h3FaceAxesAzRadsCII[face][0] - theta)
+	return h3AzimuthDistance(h3FaceCenterGeo[face][0], h3FaceCenterGeo[face][1], theta, r)
+}
+
+// h3Azimuth returns the azimuth, in radians clockwise from north, between the lat/lng points in radians.
+func h3Azimuth(lat1, lng1, lat2, lng2 float64) float64 {
+	return math.Atan2(
+		math.Cos(lat2)*math.Sin(lng2-lng1),
+		math.Cos(lat1)*math.Sin(lat2)-math.Sin(lat1)*math.Cos(lat2)*math.Cos(lng2-lng1),
+	)
+}
+
+// h3AzimuthDistance returns the point the angular distance along the azimuth,
+// all in radians, from the lat/lng point.
+func h3AzimuthDistance(lat, lng, az, distance float64) *Point {
+	sinLat := math.Sin(lat)*math.Cos(distance) + math.Cos(lat)*math.Sin(distance)*math.Cos(az)
+	lat2 := math.Asin(math.Max(-1, math.Min(1, sinLat)))
+
+	// at the poles
+	if math.Cos(lat2) < h3Epsilon {
+		return &Point{0, rad2deg(lat2)}
+	}
+
+	dLng := math.Atan2(math.Sin(az)*math.Sin(distance)*math.Cos(lat), math.Cos(distance)-math.Sin(lat)*sinLat)
+	return &Point{normalizeLng(rad2deg(lng + dLng)), rad2deg(lat2)}
+}
+
+func h3PosAngle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+
+	return a
+}
+
+// h3IsClassIII returns true for the odd resolutions, which are
+// rotated from the axes of the icosahedron faces.
+func h3IsClassIII(res int) bool {
+	return res%2 == 1
+}
+
+// h3UnitScale returns the number of cells between the face center and a vertex
+// at the Class II resolution, divided by two.
+func h3UnitScale(res int) int {
+	scale := 1
+	for r := 0; r < res/2; r++ {
+		scale *= 7
+	}
+
+	return scale
+}
+
+func h3MaxDim(res int) int {
+	return 2 * h3UnitScale(res)
+}
+
+func h3Contains(cells []H3Index, h H3Index) bool {
+	for _, c := range cells {
+		if c == h {
+			return true
+		}
+	}
+
+	return false
+}
+
+func h3Rotate60ccw(digit int) int {
+	switch digit {
+	case h3KAxesDigit:
+		return h3IKAxesDigit
+	case h3IKAxesDigit:
+		return h3IAxesDigit
+	case h3IAxesDigit:
+		return h3IJAxesDigit
+	case h3IJAxesDigit:
+		return h3JAxesDigit
+	case h3JAxesDigit:
+		return h3JKAxesDigit
+	case h3JKAxesDigit:
+		return h3KAxesDigit
+	}
+
+	return digit
+}
+
+func h3Rotate60cw(digit int) int {
+	switch digit {
+	case h3KAxesDigit:
+		return h3JKAxesDigit
+	case h3JKAxesDigit:
+		return h3JAxesDigit
+	case h3JAxesDigit:
+		return h3IJAxesDigit
+	case h3IJAxesDigit:
+		return h3IAxesDigit
+	case h3IAxesDigit:
+		return h3IKAxesDigit
+	case h3IKAxesDigit:
+		return h3KAxesDigit
+	}
+
+	return digit
+}
+
+func h3Hex2dToIJK(x, y float64) h3IJK {
+	var c h3IJK
+
+	// reverse the conversion of the absolute values
+	a1, a2 := math.Abs(x), math.Abs(y)
+	x2 := a2 * h3RSin60
+	x1 := a1 + x2/2
+
+	m1, m2 := int(x1), int(x2)
+	r1, r2 := x1-float64(m1), x2-float64(m2)
+
+	// round to the closest cell center
+	if r1 < 0.5 {
+		if r1 < 1.0/3.0 {
+			c.i = m1
+			if r2 < (1+r1)/2 {
+				c.j = m2
+			} else {
+				c.j = m2 + 1
+			}
+		} else {
+			if r2 < 1-r1 {
+				c.j = m2
+			} else {
+				c.j = m2 + 1
+			}
+
+			if 1-r1 <= r2 && r2 < 2*r1 {
+				c.i = m1 + 1
+			} else {
+				c.i = m1
+			}
+		}
+	} else {
+		if r1 < 2.0/3.0 {
+			if r2 < 1-r1 {
+				c.j = m2
+			} else {
+				c.j = m2 + 1
+			}
+
+			if 2*r1-1 < r2 && r2 < 1-r1 {
+				c.i = m1
+			} else {
+				c.i = m1 + 1
+			}
+		} else {
+			c.i = m1 + 1
+			if r2 < r1/2 {
+				c.j = m2
+			} else {
+				c.j = m2 + 1
+			}
+		}
+	}
+
+	// fold across the axes if necessary
+	if x < 0 {
+		if c.j%2 == 0 {
+			c.i -= 2 * (c.i - c.j/2)
+		} else {
+			c.i -= 2*(c.i-(c.j+1)/2) + 1
+		}
+	}
+
+	if y < 0 {
+		c.i -= (2*c.j + 1) / 2
+		c.j = -c.j
+	}
+
+	c.normalize()
+	return c
+}
+
+func (c h3IJK) hex2d() (x, y float64) {
+	i := float64(c.i - c.k)
+	j := float64(c.j - c.k)
+
+	return i - 0.5*j, j * h3Sqrt3_2
+}
+
+func (c *h3IJK) normalize() {
+	if c.i < 0 {
+		c.j -= c.i
+		c.k -= c.i
+		c.i = 0
+	}
+
+	if c.j < 0 {
+		c.i -= c.j
+		c.k -= c.j
+		c.j = 0
+	}
+
+	if c.k < 0 {
+		c.i -= c.k
+		c.j -= c.k
+		c.k = 0
+	}
+
+	m := c.i
+	if c.j < m {
+		m = c.j
+	}
+
+	if c.k < m {
+		m = c.k
+	}
+
+	c.i -= m
+	c.j -= m
+	c.k -= m
+}
+
+func (c h3IJK) add(o h3IJK) h3IJK {
+	return h3IJK{c.i + o.i, c.j + o.j, c.k + o.k}
+}
+
+func (c h3IJK) sub(o h3IJK) h3IJK {
+	return h3IJK{c.i - o.i, c.j - o.j, c.k - o.k}
+}
+
+func (c h3IJK) scale(f int) h3IJK {
+	return h3IJK{c.i * f, c.j * f, c.k * f}
+}
+
+// digit returns the digit of the unit vector, or h3InvalidDigit.
+func (c h3IJK) digit() int {
+	c.normalize()
+	for d, v := range h3UnitVecs {
+		if c == v {
+			return d
+		}
+	}
+
+	return h3InvalidDigit
+}
+
+func (c h3IJK) neighbor(digit int) h3IJK {
+	if digit > h3CenterDigit && digit < h3InvalidDigit {
+		c = c.add(h3UnitVecs[digit])
+		c.normalize()
+	}
+
+	return c
+}
+
+// transform replaces the coordinates with the sum of the vectors scaled by them.
+func (c *h3IJK) transform(iVec, jVec, kVec h3IJK) {
+	*c = iVec.scale(c.i).add(jVec.scale(c.j)).add(kVec.scale(c.k))
+	c.normalize()
+}
+
+func (c *h3IJK) rotate60ccw() {
+	c.transform(h3IJK{1, 1, 0}, h3IJK{0, 1, 1}, h3IJK{1, 0, 1})
+}
+
+func (c *h3IJK) rotate60cw() {
+	c.transform(h3IJK{1, 0, 1}, h3IJK{1, 1, 0}, h3IJK{0, 1, 1})
+}
+
+// downAp7 moves to the center of the cell in the next finer,
+// counter clockwise aperture 7, grid.
+func (c *h3IJK) downAp7() {
+	c.transform(h3IJK{3, 0, 1}, h3IJK{1, 3, 0}, h3IJK{0, 1, 3})
+}
+
+// downAp7r moves to the center of the cell in the next finer,
+// clockwise aperture 7, grid.
+func (c *h3IJK) downAp7r() {
+	c.transform(h3IJK{3, 1, 0}, h3IJK{0, 3, 1}, h3IJK{1, 0, 3})
+}
+
+func (c *h3IJK) downAp3() {
+	c.transform(h3IJK{2, 0, 1}, h3IJK{1, 2, 0}, h3IJK{0, 1, 2})
+}
+
+func (c *h3IJK) downAp3r() {
+	c.transform(h3IJK{2, 1, 0}, h3IJK{0, 2, 1}, h3IJK{1, 0, 2})
+}
+
+// upAp7 moves to the parent cell in the coarser,
+// counter clockwise aperture 7, grid.
+func (c *h3IJK) upAp7() {
+	i, j := c.i-c.k, c.j-c.k
+
+	c.i = int(math.Round(float64(3*i-j) / 7))
+	c.j = int(math.Round(float64(i+2*j) / 7))
+	c.k = 0
+	c.normalize()
+}
+
+// upAp7r moves to the parent cell in the coarser,
+// clockwise aperture 7, grid.
+func (c *h3IJK) upAp7r() {
+	i, j := c.i-c.k, c.j-c.k
+
+	c.i = int(math.Round(float64(2*i+j) / 7))
+	c.j = int(math.Round(float64(3*j-i) / 7))
+	c.k = 0
+	c.normalize()
+}
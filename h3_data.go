@@ -0,0 +1,308 @@
+package geo
+
+// The tables below describe the icosahedron and the 122 resolution 0 base cells
+// of the H3 grid, they match the ones in the H3 reference implementation.
+
+// the lat/lng, in radians, of the center of each icosahedron face
+var h3FaceCenterGeo = [h3NumFaces][2]float64{
+	{0.80358264971899, 1.2483974196173961},
+	{1.3077478834556382, 2.5369450098779214},
+	{1.054751253523952, -1.3475173589003966},
+	{0.6001915955381868, -0.45060390946975576},
+	{0.49171542819877384, 0.40198820291130694},
+	{0.1727453274156187, 1.6781468852804338},
+	{0.6059293215713507, 2.9539233298124117},
+	{0.42737051832897965, -1.8888762003362853},
+	{-0.07906611854921283, -0.7334295133808677},
+	{-0.23096164445538364, 0.506495587332349},
+	{0.07906611854921283, 2.4081631402089254},
+	{0.23096164445538364, -2.635097066257444},
+	{-0.1727453274156187, -1.4634457683093596},
+	{-0.6059293215713507, -0.18766932377738163},
+	{-0.42737051832897965, 1.2527164532535078},
+	{-0.6001915955381868, 2.6909887441200375},
+	{-0.49171542819877384, -2.7396044506784865},
+	{-1.054751253523952, 1.7940752946893965},
+	{-1.3077478834556382, -0.6046476437118721},
+	{-0.80358264971899, -1.8931952339723972},
+}
+
+// the azimuths, in radians, of the Class II i, j and k axes of each face
+var h3FaceAxesAzRadsCII = [h3NumFaces][3]float64{
+	{5.6199582685239395, 3.5255631661307447, 1.4311680637375488},
+	{5.7603390817141875, 3.6659439793209923, 1.571548876927797},
+	{0.78021365439343, 4.969003859179821, 2.8746087567866256},
+	{0.4304693639799999, 4.619259568766391, 2.5248644663731956},
+	{6.130269123335111, 4.0358740209419155, 1.9414789185487202},
+	{2.692877706530643, 0.5984826041374471, 4.787272808923838},
+	{2.982963003477244, 0.8885679010840484, 5.07735810587044},
+	{3.532912002790141, 1.4385169003969456, 5.627307105183337},
+	{3.494305004259568, 1.3999099018663728, 5.588700106652764},
+	{3.0032141694995382, 0.908819067106343, 5.0976092718927335},
+	{5.930472956509812, 3.836077854116616, 1.7416827517234204},
+	{0.13837848409025486, 4.327168688876646, 2.23277358648345},
+	{0.4487149470591504, 4.6375051518455415, 2.543110049452346},
+	{0.15862965011254937, 4.3474198548989405, 2.2530247525057447},
+	{5.891865957979238, 3.797470855586043, 1.7030757531928475},
+	{2.711123289609793, 0.6167281872165977, 4.8055183920029885},
+	{3.294508837434268, 1.2001137350410729, 5.388903939827464},
+	{2.361378999196363, 0.2669838968031676, 4.455774101589559},
+	{3.6644388790551923, 1.570043776661997, 5.758833981448388},
+	{3.80481969224544, 1.7104245898522445, 5.8992147946386355},
+}
+
+// the face across each edge of a face, with the translation and rotation
+// from the coordinates of the face to the neighbor, indexed by h3Center, h3IJ, h3KI and h3JK
+var h3FaceNeighbors = [h3NumFaces][4]h3FaceOrientIJK{
+	{{0, h3IJK{0, 0, 0}, 0}, {4, h3IJK{2, 0, 2}, 1}, {1, h3IJK{2, 2, 0}, 5}, {5, h3IJK{0, 2, 2}, 3}},     // face 0
+	{{1, h3IJK{0, 0, 0}, 0}, {0, h3IJK{2, 0, 2}, 1}, {2, h3IJK{2, 2, 0}, 5}, {6, h3IJK{0, 2, 2}, 3}},     // face 1
+	{{2, h3IJK{0, 0, 0}, 0}, {1, h3IJK{2, 0, 2}, 1}, {3, h3IJK{2, 2, 0}, 5}, {7, h3IJK{0, 2, 2}, 3}},     // face 2
+	{{3, h3IJK{0, 0, 0}, 0}, {2, h3IJK{2, 0, 2}, 1}, {4, h3IJK{2, 2, 0}, 5}, {8, h3IJK{0, 2, 2}, 3}},     // face 3
+	{{4, h3IJK{0, 0, 0}, 0}, {3, h3IJK{2, 0, 2}, 1}, {0, h3IJK{2, 2, 0}, 5}, {9, h3IJK{0, 2, 2}, 3}},     // face 4
+	{{5, h3IJK{0, 0, 0}, 0}, {10, h3IJK{2, 2, 0}, 3}, {14, h3IJK{2, 0, 2}, 3}, {0, h3IJK{0, 2, 2}, 3}},   // face 5
+	{{6, h3IJK{0, 0, 0}, 0}, {11, h3IJK{2, 2, 0}, 3}, {10, h3IJK{2, 0, 2}, 3}, {1, h3IJK{0, 2, 2}, 3}},   // face 6
+	{{7, h3IJK{0, 0, 0}, 0}, {12, h3IJK{2, 2, 0}, 3}, {11, h3IJK{2, 0, 2}, 3}, {2, h3IJK{0, 2, 2}, 3}},   // face 7
+	{{8, h3IJK{0, 0, 0}, 0}, {13, h3IJK{2, 2, 0}, 3}, {12, h3IJK{2, 0, 2}, 3}, {3, h3IJK{0, 2, 2}, 3}},   // face 8
+	{{9, h3IJK{0, 0, 0}, 0}, {14, h3IJK{2, 2, 0}, 3}, {13, h3IJK{2, 0, 2}, 3}, {4, h3IJK{0, 2, 2}, 3}},   // face 9
+	{{10, h3IJK{0, 0, 0}, 0}, {5, h3IJK{2, 2, 0}, 3}, {6, h3IJK{2, 0, 2}, 3}, {15, h3IJK{0, 2, 2}, 3}},   // face 10
+	{{11, h3IJK{0, 0, 0}, 0}, {6, h3IJK{2, 2, 0}, 3}, {7, h3IJK{2, 0, 2}, 3}, {16, h3IJK{0, 2, 2}, 3}},   // face 11
+	{{12, h3IJK{0, 0, 0}, 0}, {7, h3IJK{2, 2, 0}, 3}, {8, h3IJK{2, 0, 2}, 3}, {19, h3IJK{0, 2, 2}, 3}},   // face 12
+	{{13, h3IJK{0, 0, 0}, 0}, {8, h3IJK{2, 2, 0}, 3}, {9, h3IJK{2, 0, 2}, 3}, {18, h3IJK{0, 2, 2}, 3}},   // face 13
+	{{14, h3IJK{0, 0, 0}, 0}, {9, h3IJK{2, 2, 0}, 3}, {5, h3IJK{2, 0, 2}, 3}, {17, h3IJK{0, 2, 2}, 3}},   // face 14
+	{{15, h3IJK{0, 0, 0}, 0}, {16, h3IJK{2, 0, 2}, 1}, {17, h3IJK{2, 2, 0}, 5}, {10, h3IJK{0, 2, 2}, 3}}, // face 15
+	{{16, h3IJK{0, 0, 0}, 0}, {19, h3IJK{2, 0, 2}, 1}, {15, h3IJK{2, 2, 0}, 5}, {11, h3IJK{0, 2, 2}, 3}}, // face 16
+	{{17, h3IJK{0, 0, 0}, 0}, {15, h3IJK{2, 0, 2}, 1}, {18, h3IJK{2, 2, 0}, 5}, {14, h3IJK{0, 2, 2}, 3}}, // face 17
+	{{18, h3IJK{0, 0, 0}, 0}, {17, h3IJK{2, 0, 2}, 1}, {19, h3IJK{2, 2, 0}, 5}, {13, h3IJK{0, 2, 2}, 3}}, // face 18
+	{{19, h3IJK{0, 0, 0}, 0}, {18, h3IJK{2, 0, 2}, 1}, {16, h3IJK{2, 2, 0}, 5}, {12, h3IJK{0, 2, 2}, 3}}, // face 19
+}
+
+// the home face and coordinates of each base cell
+var h3BaseCells = [h3NumBaseCells]h3BaseCellData{
+	{h3FaceIJK{1, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 0
+	{h3FaceIJK{2, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 1
+	{h3FaceIJK{1, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 2
+	{h3FaceIJK{2, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 3
+	{h3FaceIJK{0, h3IJK{2, 0, 0}}, true, [2]int{-1, -1}},  // base cell 4
+	{h3FaceIJK{1, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 5
+	{h3FaceIJK{1, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 6
+	{h3FaceIJK{2, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 7
+	{h3FaceIJK{0, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 8
+	{h3FaceIJK{2, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 9
+	{h3FaceIJK{1, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 10
+	{h3FaceIJK{1, h3IJK{0, 1, 1}}, false, [2]int{}},       // base cell 11
+	{h3FaceIJK{3, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 12
+	{h3FaceIJK{3, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 13
+	{h3FaceIJK{11, h3IJK{2, 0, 0}}, true, [2]int{2, 6}},   // base cell 14
+	{h3FaceIJK{4, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 15
+	{h3FaceIJK{0, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 16
+	{h3FaceIJK{6, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 17
+	{h3FaceIJK{0, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 18
+	{h3FaceIJK{2, h3IJK{0, 1, 1}}, false, [2]int{}},       // base cell 19
+	{h3FaceIJK{7, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 20
+	{h3FaceIJK{2, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 21
+	{h3FaceIJK{0, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 22
+	{h3FaceIJK{6, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 23
+	{h3FaceIJK{10, h3IJK{2, 0, 0}}, true, [2]int{1, 5}},   // base cell 24
+	{h3FaceIJK{6, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 25
+	{h3FaceIJK{3, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 26
+	{h3FaceIJK{11, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 27
+	{h3FaceIJK{4, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 28
+	{h3FaceIJK{3, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 29
+	{h3FaceIJK{0, h3IJK{0, 1, 1}}, false, [2]int{}},       // base cell 30
+	{h3FaceIJK{4, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 31
+	{h3FaceIJK{5, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 32
+	{h3FaceIJK{0, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 33
+	{h3FaceIJK{7, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 34
+	{h3FaceIJK{6, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 35
+	{h3FaceIJK{7, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 36
+	{h3FaceIJK{10, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 37
+	{h3FaceIJK{12, h3IJK{2, 0, 0}}, true, [2]int{3, 7}},   // base cell 38
+	{h3FaceIJK{6, h3IJK{1, 0, 1}}, false, [2]int{}},       // base cell 39
+	{h3FaceIJK{7, h3IJK{1, 0, 1}}, false, [2]int{}},       // base cell 40
+	{h3FaceIJK{4, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 41
+	{h3FaceIJK{3, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 42
+	{h3FaceIJK{3, h3IJK{0, 1, 1}}, false, [2]int{}},       // base cell 43
+	{h3FaceIJK{4, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 44
+	{h3FaceIJK{6, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 45
+	{h3FaceIJK{11, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 46
+	{h3FaceIJK{8, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 47
+	{h3FaceIJK{5, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 48
+	{h3FaceIJK{14, h3IJK{2, 0, 0}}, true, [2]int{0, 9}},   // base cell 49
+	{h3FaceIJK{5, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 50
+	{h3FaceIJK{12, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 51
+	{h3FaceIJK{5, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 52
+	{h3FaceIJK{4, h3IJK{0, 1, 1}}, false, [2]int{}},       // base cell 53
+	{h3FaceIJK{7, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 54
+	{h3FaceIJK{7, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 55
+	{h3FaceIJK{11, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 56
+	{h3FaceIJK{10, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 57
+	{h3FaceIJK{13, h3IJK{2, 0, 0}}, true, [2]int{4, 8}},   // base cell 58
+	{h3FaceIJK{10, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 59
+	{h3FaceIJK{11, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 60
+	{h3FaceIJK{9, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 61
+	{h3FaceIJK{8, h3IJK{0, 1, 0}}, false, [2]int{}},       // base cell 62
+	{h3FaceIJK{6, h3IJK{2, 0, 0}}, true, [2]int{11, 15}},  // base cell 63
+	{h3FaceIJK{8, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 64
+	{h3FaceIJK{9, h3IJK{0, 0, 1}}, false, [2]int{}},       // base cell 65
+	{h3FaceIJK{14, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 66
+	{h3FaceIJK{5, h3IJK{1, 0, 1}}, false, [2]int{}},       // base cell 67
+	{h3FaceIJK{11, h3IJK{0, 1, 1}}, false, [2]int{}},      // base cell 68
+	{h3FaceIJK{8, h3IJK{1, 0, 1}}, false, [2]int{}},       // base cell 69
+	{h3FaceIJK{5, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 70
+	{h3FaceIJK{12, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 71
+	{h3FaceIJK{7, h3IJK{2, 0, 0}}, true, [2]int{12, 16}},  // base cell 72
+	{h3FaceIJK{12, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 73
+	{h3FaceIJK{10, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 74
+	{h3FaceIJK{9, h3IJK{0, 0, 0}}, false, [2]int{}},       // base cell 75
+	{h3FaceIJK{13, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 76
+	{h3FaceIJK{16, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 77
+	{h3FaceIJK{10, h3IJK{0, 1, 1}}, false, [2]int{}},      // base cell 78
+	{h3FaceIJK{15, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 79
+	{h3FaceIJK{16, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 80
+	{h3FaceIJK{9, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 81
+	{h3FaceIJK{8, h3IJK{1, 1, 0}}, false, [2]int{}},       // base cell 82
+	{h3FaceIJK{5, h3IJK{2, 0, 0}}, true, [2]int{10, 17}},  // base cell 83
+	{h3FaceIJK{8, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 84
+	{h3FaceIJK{14, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 85
+	{h3FaceIJK{9, h3IJK{1, 0, 1}}, false, [2]int{}},       // base cell 86
+	{h3FaceIJK{14, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 87
+	{h3FaceIJK{19, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 88
+	{h3FaceIJK{12, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 89
+	{h3FaceIJK{16, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 90
+	{h3FaceIJK{12, h3IJK{0, 1, 1}}, false, [2]int{}},      // base cell 91
+	{h3FaceIJK{15, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 92
+	{h3FaceIJK{15, h3IJK{1, 1, 0}}, false, [2]int{}},      // base cell 93
+	{h3FaceIJK{9, h3IJK{1, 0, 0}}, false, [2]int{}},       // base cell 94
+	{h3FaceIJK{15, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 95
+	{h3FaceIJK{13, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 96
+	{h3FaceIJK{8, h3IJK{2, 0, 0}}, true, [2]int{13, 19}},  // base cell 97
+	{h3FaceIJK{13, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 98
+	{h3FaceIJK{16, h3IJK{1, 1, 0}}, false, [2]int{}},      // base cell 99
+	{h3FaceIJK{17, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 100
+	{h3FaceIJK{14, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 101
+	{h3FaceIJK{14, h3IJK{0, 1, 1}}, false, [2]int{}},      // base cell 102
+	{h3FaceIJK{19, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 103
+	{h3FaceIJK{13, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 104
+	{h3FaceIJK{19, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 105
+	{h3FaceIJK{16, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 106
+	{h3FaceIJK{9, h3IJK{2, 0, 0}}, true, [2]int{14, 18}},  // base cell 107
+	{h3FaceIJK{17, h3IJK{1, 1, 0}}, false, [2]int{}},      // base cell 108
+	{h3FaceIJK{15, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 109
+	{h3FaceIJK{13, h3IJK{0, 1, 1}}, false, [2]int{}},      // base cell 110
+	{h3FaceIJK{18, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 111
+	{h3FaceIJK{17, h3IJK{0, 0, 1}}, false, [2]int{}},      // base cell 112
+	{h3FaceIJK{19, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 113
+	{h3FaceIJK{17, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 114
+	{h3FaceIJK{18, h3IJK{0, 1, 0}}, false, [2]int{}},      // base cell 115
+	{h3FaceIJK{19, h3IJK{1, 1, 0}}, false, [2]int{}},      // base cell 116
+	{h3FaceIJK{15, h3IJK{2, 0, 0}}, true, [2]int{-1, -1}}, // base cell 117
+	{h3FaceIJK{17, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 118
+	{h3FaceIJK{18, h3IJK{0, 0, 0}}, false, [2]int{}},      // base cell 119
+	{h3FaceIJK{18, h3IJK{1, 1, 0}}, false, [2]int{}},      // base cell 120
+	{h3FaceIJK{18, h3IJK{1, 0, 0}}, false, [2]int{}},      // base cell 121
+}
+
+// the base cell at each resolution 0 face coordinate, with the number of
+// counter clockwise rotations into the coordinates of the base cell's home face
+var h3FaceIJKBaseCells = [h3NumFaces][3][3][3]h3BaseCellRotation{
+	{ // face 0
+		{{{16, 0}, {18, 0}, {24, 0}}, {{33, 0}, {30, 0}, {32, 3}}, {{49, 1}, {48, 3}, {50, 3}}},
+		{{{8, 0}, {5, 5}, {10, 5}}, {{22, 0}, {16, 0}, {18, 0}}, {{41, 1}, {33, 0}, {30, 0}}},
+		{{{4, 0}, {0, 5}, {2, 5}}, {{15, 1}, {8, 0}, {5, 5}}, {{31, 1}, {22, 0}, {16, 0}}},
+	},
+	{ // face 1
+		{{{2, 0}, {6, 0}, {14, 0}}, {{10, 0}, {11, 0}, {17, 3}}, {{24, 1}, {23, 3}, {25, 3}}},
+		{{{0, 0}, {1, 5}, {9, 5}}, {{5, 0}, {2, 0}, {6, 0}}, {{18, 1}, {10, 0}, {11, 0}}},
+		{{{4, 1}, {3, 5}, {7, 5}}, {{8, 1}, {0, 0}, {1, 5}}, {{16, 1}, {5, 0}, {2, 0}}},
+	},
+	{ // face 2
+		{{{7, 0}, {21, 0}, {38, 0}}, {{9, 0}, {19, 0}, {34, 3}}, {{14, 1}, {20, 3}, {36, 3}}},
+		{{{3, 0}, {13, 5}, {29, 5}}, {{1, 0}, {7, 0}, {21, 0}}, {{6, 1}, {9, 0}, {19, 0}}},
+		{{{4, 2}, {12, 5}, {26, 5}}, {{0, 1}, {3, 0}, {13, 5}}, {{2, 1}, {1, 0}, {7, 0}}},
+	},
+	{ // face 3
+		{{{26, 0}, {42, 0}, {58, 0}}, {{29, 0}, {43, 0}, {62, 3}}, {{38, 1}, {47, 3}, {64, 3}}},
+		{{{12, 0}, {28, 5}, {44, 5}}, {{13, 0}, {26, 0}, {42, 0}}, {{21, 1}, {29, 0}, {43, 0}}},
+		{{{4, 3}, {15, 5}, {31, 5}}, {{3, 1}, {12, 0}, {28, 5}}, {{7, 1}, {13, 0}, {26, 0}}},
+	},
+	{ // face 4
+		{{{31, 0}, {41, 0}, {49, 0}}, {{44, 0}, {53, 0}, {61, 3}}, {{58, 1}, {65, 3}, {75, 3}}},
+		{{{15, 0}, {22, 5}, {33, 5}}, {{28, 0}, {31, 0}, {41, 0}}, {{42, 1}, {44, 0}, {53, 0}}},
+		{{{4, 4}, {8, 5}, {16, 5}}, {{12, 1}, {15, 0}, {22, 5}}, {{26, 1}, {28, 0}, {31, 0}}},
+	},
+	{ // face 5
+		{{{50, 0}, {48, 0}, {49, 3}}, {{32, 0}, {30, 3}, {33, 3}}, {{24, 3}, {18, 3}, {16, 3}}},
+		{{{70, 0}, {67, 0}, {66, 3}}, {{52, 0}, {50, 0}, {48, 0}}, {{37, 3}, {32, 0}, {30, 3}}},
+		{{{83, 0}, {87, 3}, {85, 3}}, {{74, 3}, {70, 0}, {67, 0}}, {{57, 3}, {52, 0}, {50, 0}}},
+	},
+	{ // face 6
+		{{{25, 0}, {23, 0}, {24, 3}}, {{17, 0}, {11, 3}, {10, 3}}, {{14, 3}, {6, 3}, {2, 3}}},
+		{{{45, 0}, {39, 0}, {37, 3}}, {{35, 0}, {25, 0}, {23, 0}}, {{27, 3}, {17, 0}, {11, 3}}},
+		{{{63, 0}, {59, 3}, {57, 3}}, {{56, 3}, {45, 0}, {39, 0}}, {{46, 3}, {35, 0}, {25, 0}}},
+	},
+	{ // face 7
+		{{{36, 0}, {20, 0}, {14, 3}}, {{34, 0}, {19, 3}, {9, 3}}, {{38, 3}, {21, 3}, {7, 3}}},
+		{{{55, 0}, {40, 0}, {27, 3}}, {{54, 0}, {36, 0}, {20, 0}}, {{51, 3}, {34, 0}, {19, 3}}},
+		{{{72, 0}, {60, 3}, {46, 3}}, {{73, 3}, {55, 0}, {40, 0}}, {{71, 3}, {54, 0}, {36, 0}}},
+	},
+	{ // face 8
+		{{{64, 0}, {47, 0}, {38, 3}}, {{62, 0}, {43, 3}, {29, 3}}, {{58, 3}, {42, 3}, {26, 3}}},
+		{{{84, 0}, {69, 0}, {51, 3}}, {{82, 0}, {64, 0}, {47, 0}}, {{76, 3}, {62, 0}, {43, 3}}},
+		{{{97, 0}, {89, 3}, {71, 3}}, {{98, 3}, {84, 0}, {69, 0}}, {{96, 3}, {82, 0}, {64, 0}}},
+	},
+	{ // face 9
+		{{{75, 0}, {65, 0}, {58, 3}}, {{61, 0}, {53, 3}, {44, 3}}, {{49, 3}, {41, 3}, {31, 3}}},
+		{{{94, 0}, {86, 0}, {76, 3}}, {{81, 0}, {75, 0}, {65, 0}}, {{66, 3}, {61, 0}, {53, 3}}},
+		{{{107, 0}, {104, 3}, {96, 3}}, {{101, 3}, {94, 0}, {86, 0}}, {{85, 3}, {81, 0}, {75, 0}}},
+	},
+	{ // face 10
+		{{{57, 0}, {59, 0}, {63, 3}}, {{74, 0}, {78, 0}, {79, 3}}, {{83, 3}, {92, 3}, {95, 3}}},
+		{{{37, 0}, {39, 3}, {45, 3}}, {{52, 3}, {57, 0}, {59, 0}}, {{70, 3}, {74, 0}, {78, 0}}},
+		{{{24, 0}, {23, 3}, {25, 3}}, {{32, 3}, {37, 0}, {39, 3}}, {{50, 3}, {52, 3}, {57, 0}}},
+	},
+	{ // face 11
+		{{{46, 0}, {60, 0}, {72, 3}}, {{56, 0}, {68, 0}, {80, 3}}, {{63, 3}, {77, 3}, {90, 3}}},
+		{{{27, 0}, {40, 3}, {55, 3}}, {{35, 3}, {46, 0}, {60, 0}}, {{45, 3}, {56, 0}, {68, 0}}},
+		{{{14, 0}, {20, 3}, {36, 3}}, {{17, 3}, {27, 0}, {40, 3}}, {{25, 3}, {35, 3}, {46, 0}}},
+	},
+	{ // face 12
+		{{{71, 0}, {89, 0}, {97, 3}}, {{73, 0}, {91, 0}, {103, 3}}, {{72, 3}, {88, 3}, {105, 3}}},
+		{{{51, 0}, {69, 3}, {84, 3}}, {{54, 3}, {71, 0}, {89, 0}}, {{55, 3}, {73, 0}, {91, 0}}},
+		{{{38, 0}, {47, 3}, {64, 3}}, {{34, 3}, {51, 0}, {69, 3}}, {{36, 3}, {54, 3}, {71, 0}}},
+	},
+	{ // face 13
+		{{{96, 0}, {104, 0}, {107, 3}}, {{98, 0}, {110, 0}, {115, 3}}, {{97, 3}, {111, 3}, {119, 3}}},
+		{{{76, 0}, {86, 3}, {94, 3}}, {{82, 3}, {96, 0}, {104, 0}}, {{84, 3}, {98, 0}, {110, 0}}},
+		{{{58, 0}, {65, 3}, {75, 3}}, {{62, 3}, {76, 0}, {86, 3}}, {{64, 3}, {82, 3}, {96, 0}}},
+	},
+	{ // face 14
+		{{{85, 0}, {87, 0}, {83, 3}}, {{101, 0}, {102, 0}, {100, 3}}, {{107, 3}, {112, 3}, {114, 3}}},
+		{{{66, 0}, {67, 3}, {70, 3}}, {{81, 3}, {85, 0}, {87, 0}}, {{94, 3}, {101, 0}, {102, 0}}},
+		{{{49, 0}, {48, 3}, {50, 3}}, {{61, 3}, {66, 0}, {67, 3}}, {{75, 3}, {81, 3}, {85, 0}}},
+	},
+	{ // face 15
+		{{{95, 0}, {92, 0}, {83, 0}}, {{79, 0}, {78, 3}, {74, 3}}, {{63, 1}, {59, 3}, {57, 3}}},
+		{{{109, 0}, {108, 5}, {100, 5}}, {{93, 0}, {95, 0}, {92, 0}}, {{77, 1}, {79, 0}, {78, 3}}},
+		{{{117, 0}, {118, 5}, {114, 5}}, {{106, 1}, {109, 0}, {108, 5}}, {{90, 1}, {93, 0}, {95, 0}}},
+	},
+	{ // face 16
+		{{{90, 0}, {77, 0}, {63, 0}}, {{80, 0}, {68, 3}, {56, 3}}, {{72, 1}, {60, 3}, {46, 3}}},
+		{{{106, 0}, {93, 5}, {79, 5}}, {{99, 0}, {90, 0}, {77, 0}}, {{88, 1}, {80, 0}, {68, 3}}},
+		{{{117, 4}, {109, 5}, {95, 5}}, {{113, 1}, {106, 0}, {93, 5}}, {{105, 1}, {99, 0}, {90, 0}}},
+	},
+	{ // face 17
+		{{{114, 0}, {112, 0}, {107, 0}}, {{100, 0}, {102, 3}, {101, 3}}, {{83, 1}, {87, 3}, {85, 3}}},
+		{{{118, 0}, {120, 5}, {115, 5}}, {{108, 0}, {114, 0}, {112, 0}}, {{92, 1}, {100, 0}, {102, 3}}},
+		{{{117, 1}, {121, 5}, {119, 5}}, {{109, 1}, {118, 0}, {120, 5}}, {{95, 1}, {108, 0}, {114, 0}}},
+	},
+	{ // face 18
+		{{{119, 0}, {111, 0}, {97, 0}}, {{115, 0}, {110, 3}, {98, 3}}, {{107, 1}, {104, 3}, {96, 3}}},
+		{{{121, 0}, {116, 5}, {103, 5}}, {{120, 0}, {119, 0}, {111, 0}}, {{112, 1}, {115, 0}, {110, 3}}},
+		{{{117, 2}, {113, 5}, {105, 5}}, {{118, 1}, {121, 0}, {116, 5}}, {{114, 1}, {120, 0}, {119, 0}}},
+	},
+	{ // face 19
+		{{{105, 0}, {88, 0}, {72, 0}}, {{103, 0}, {91, 3}, {73, 3}}, {{97, 1}, {89, 3}, {71, 3}}},
+		{{{113, 0}, {99, 5}, {80, 5}}, {{116, 0}, {105, 0}, {88, 0}}, {{111, 1}, {103, 0}, {91, 3}}},
+		{{{117, 3}, {106, 5}, {90, 5}}, {{121, 1}, {113, 0}, {99, 5}}, {{119, 1}, {116, 0}, {105, 0}}},
+	},
+}
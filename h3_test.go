@@ -0,0 +1,277 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewH3IndexFromPoint(t *testing.T) {
+	// from the H3 documentation
+	h := NewH3IndexFromPoint(NewPoint(-122.0553238, 37.3615593), 7)
+	if h.String() != "87283472bffffff" {
+		t.Errorf("h3, fromPoint expected 87283472bffffff, got %v", h)
+	}
+
+	if h.Resolution() != 7 || h.BaseCell() != 20 || !h.Valid() || h.IsPentagon() {
+		t.Errorf("h3, incorrect cell %v", h)
+	}
+
+	c := NewH3IndexFromString("85283473fffffff").Center()
+	if math.Abs(c.Lng()+121.976375973) > 1e-6 || math.Abs(c.Lat()-37.345793375) > 1e-6 {
+		t.Errorf("h3, center incorrect, got %v", c)
+	}
+
+	// the center of a cell should be within the cell
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+		for res := 0; res <= H3MaxResolution; res++ {
+			h := NewH3IndexFromPoint(p, res)
+			if c := NewH3IndexFromPoint(h.Center(), res); c != h {
+				t.Errorf("h3, center of %v in %v", h, c)
+			}
+
+			if !h.Bound().Pad(1e-9).Contains(p) {
+				t.Errorf("h3, bound does not contain %v at resolution %d: %v", city, res, h.Bound())
+			}
+		}
+	}
+
+	// the pentagons at the vertices of the icosahedron
+	h = NewH3IndexFromPoint(NewPoint(10.536199, 64.7), 0)
+	if h.String() != "8009fffffffffff" || !h.IsPentagon() {
+		t.Errorf("h3, fromPoint expected pentagon 8009fffffffffff, got %v", h)
+	}
+}
+
+func TestH3IndexString(t *testing.T) {
+	cases := []struct {
+		index H3Index
+		str   string
+	}{
+		{0x87283472bffffff, "87283472bffffff"},
+		{0x8009fffffffffff, "8009fffffffffff"},
+		{0x8f0800000000000, "8f0800000000000"},
+	}
+
+	for _, tc := range cases {
+		if s := tc.index.String(); s != tc.str {
+			t.Errorf("h3, string expected %v, got %v", tc.str, s)
+		}
+
+		if h := NewH3IndexFromString(tc.str); h != tc.index || !h.Valid() {
+			t.Errorf("h3, fromString expected %v, got %v", tc.index, h)
+		}
+	}
+
+	invalid := []string{
+		"not a cell",
+		"",
+		"87283472bfffff0", // unused digits not 7
+		"80f5fffffffffff", // base cell 122
+		"8008bffffffffff", // deleted pentagon sub sequence
+	}
+
+	for _, s := range invalid {
+		if h := NewH3IndexFromString(s); h.Valid() {
+			t.Errorf("h3, fromString expected invalid for %v, got %v", s, h)
+		}
+	}
+}
+
+func TestH3IndexHierarchy(t *testing.T) {
+	h := NewH3IndexFromPoint(NewPoint(-122.4167, 37.7833), 9)
+
+	p := h.Parent()
+	if p.Resolution() != 8 || p != h.ParentAtResolution(8) {
+		t.Errorf("h3, parent incorrect %v", p)
+	}
+
+	if b := h.ParentAtResolution(0); b.Resolution() != 0 || b.BaseCell() != h.BaseCell() || b.Parent() != b {
+		t.Errorf("h3, base cell parent incorrect %v", b)
+	}
+
+	children := h.Children()
+	if len(children) != 7 {
+		t.Fatalf("h3, children expected 7, got %d", len(children))
+	}
+
+	for i, c := range children {
+		if c.Parent() != h || c.Resolution() != 10 || !c.Valid() {
+			t.Errorf("h3, child %d incorrect %v", i, c)
+		}
+	}
+
+	if l := len(h.ChildrenAtResolution(11)); l != 49 {
+		t.Errorf("h3, childrenAtResolution expected 49, got %d", l)
+	}
+
+	// pentagons have no children in the deleted k sub sequence
+	pent := NewH3IndexFromString("8009fffffffffff")
+	if l := len(pent.Children()); l != 6 {
+		t.Errorf("h3, pentagon children expected 6, got %d", l)
+	}
+
+	if l := len(pent.ChildrenAtResolution(2)); l != 6+5*7 {
+		t.Errorf("h3, pentagon childrenAtResolution expected 41, got %d", l)
+	}
+
+	if c := pent.Children()[0]; !c.IsPentagon() {
+		t.Errorf("h3, center child of a pentagon should be a pentagon")
+	}
+
+	if c := NewH3IndexFromPoint(NewPoint(0, 0), H3MaxResolution).Children(); c != nil {
+		t.Errorf("h3, children at max resolution should be nil, got %v", c)
+	}
+}
+
+func TestH3IndexBoundary(t *testing.T) {
+	h := NewH3IndexFromPoint(NewPoint(-122.4167, 37.7833), 6)
+
+	ring := *h.Boundary()
+	if len(ring) != 7 || !ring[0].Equals(&ring[6]) {
+		t.Fatalf("h3, boundary expected closed ring of 6 vertices, got %v", ring)
+	}
+
+	if !ring.IsCounterClockwise() {
+		t.Errorf("h3, boundary should be counter clockwise")
+	}
+
+	if !ring.Bound().Contains(h.Center()) {
+		t.Errorf("h3, boundary should contain the center")
+	}
+
+	// the vertices are equidistant from the center
+	d := ring[0].GeoDistanceFrom(h.Center())
+	for _, v := range ring {
+		if e := v.GeoDistanceFrom(h.Center()); math.Abs(e-d)/d > 0.05 {
+			t.Errorf("h3, vertex distance expected %f, got %f", d, e)
+		}
+	}
+
+	pent := *NewH3IndexFromString("8009fffffffffff").Children()[0].Children()[0].Boundary()
+	if len(pent) != 6 {
+		t.Errorf("h3, pentagon boundary expected 5 vertices, got %v", pent)
+	}
+
+	// crossing the anti-meridian
+	h = NewH3IndexFromPoint(NewPoint(180, 0), 3)
+	if b := h.Bound(); !b.CrossesAntimeridian() || !b.Contains(NewPoint(180, 0)) {
+		t.Errorf("h3, bound should cross the anti-meridian, got %v", b)
+	}
+
+	// containing the pole
+	h = NewH3IndexFromPoint(NewPoint(0, 90), 2)
+	if b := h.Bound(); b.North() != 90 || b.West() != -180 || b.East() != 180 {
+		t.Errorf("h3, bound should contain the pole, got %v", b)
+	}
+}
+
+func TestH3IndexNeighbors(t *testing.T) {
+	cells := []H3Index{
+		NewH3IndexFromPoint(NewPoint(-122.4167, 37.7833), 8),
+		NewH3IndexFromPoint(NewPoint(180, 0), 4),
+		NewH3IndexFromPoint(NewPoint(0, -90), 1),
+		NewH3IndexFromString("831c00fffffffff"),
+	}
+
+	for _, h := range cells {
+		neighbors := h.Neighbors()
+
+		expected := 6
+		if h.IsPentagon() {
+			expected = 5
+		}
+
+		if len(neighbors) != expected {
+			t.Errorf("h3, neighbors of %v expected %d, got %d", h, expected, len(neighbors))
+		}
+
+		for _, n := range neighbors {
+			if n.Resolution() != h.Resolution() || n == h {
+				t.Errorf("h3, neighbor %v of %v incorrect", n, h)
+			}
+
+			found := false
+			for _, nn := range n.Neighbors() {
+				found = found || nn == h
+			}
+
+			if !found {
+				t.Errorf("h3, neighbor %v of %v should be a neighbor back", n, h)
+			}
+		}
+	}
+
+	h := cells[0]
+	if k := h.KRing(0); len(k) != 1 || k[0] != h {
+		t.Errorf("h3, kRing 0 expected the cell, got %v", k)
+	}
+
+	if k := h.KRing(1); len(k) != 7 || k[0] != h {
+		t.Errorf("h3, kRing 1 expected 7 cells, got %d", len(k))
+	}
+
+	if k := h.KRing(2); len(k) != 19 {
+		t.Errorf("h3, kRing 2 expected 19 cells, got %d", len(k))
+	}
+
+	if k := cells[3].KRing(2); len(k) != 16 {
+		t.Errorf("h3, kRing 2 of pentagon expected 16 cells, got %d", len(k))
+	}
+}
+
+func TestH3IndexesInBound(t *testing.T) {
+	bound := NewBound(-122.5, -122.3, 37.7, 37.8)
+	cells := H3IndexesInBound(bound, 7)
+
+	// about 5 square kilometers per cell
+	if len(cells) < 30 || len(cells) > 45 {
+		t.Errorf("h3, inBound expected about 38 cells, got %d", len(cells))
+	}
+
+	for i, h := range cells {
+		if h.Resolution() != 7 || !bound.Contains(h.Center()) {
+			t.Errorf("h3, inBound cell %v incorrect", h)
+		}
+
+		if i > 0 && cells[i-1] >= h {
+			t.Errorf("h3, inBound should be sorted")
+		}
+	}
+
+	// the cells containing points in the bound are included if their center is
+	inside := map[H3Index]bool{}
+	for _, h := range cells {
+		inside[h] = true
+	}
+
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			p := NewPoint(-122.48+0.016*float64(x), 37.71+0.008*float64(y))
+			if h := NewH3IndexFromPoint(p, 7); !inside[h] && bound.Contains(h.Center()) {
+				t.Errorf("h3, inBound missing %v", h)
+			}
+		}
+	}
+
+	// crossing the anti-meridian
+	bound = NewGeoBound(179, -179, -1, 1)
+	cells = H3IndexesInBound(bound, 3)
+	east, west := 0, 0
+	for _, h := range cells {
+		c := h.Center()
+		if !bound.Contains(c) {
+			t.Errorf("h3, inBound cell %v outside the bound", h)
+		}
+
+		if c.Lng() > 0 {
+			east++
+		} else {
+			west++
+		}
+	}
+
+	if east == 0 || west == 0 {
+		t.Errorf("h3, inBound expected cells on both sides, got %d and %d", east, west)
+	}
+}
@@ -56,6 +56,8 @@ func (b *Bound) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON enables bounds to be decoded as JSON using the encoding/json package.
+// If the first point, the south west corner, is east of the second, the north east corner,
+// the bound crosses the anti-meridian as encoded by MarshalJSON, see NewGeoBound.
 func (b *Bound) UnmarshalJSON(data []byte) error {
 	var points []*Point
 
@@ -72,8 +74,15 @@ func (b *Bound) UnmarshalJSON(data []byte) error {
 		return errors.New("geo: not enough points to unmarshal into bound")
 	}
 
+	sw, ne := points[0], points[1]
+	if sw[0] > ne[0] && sw[1] <= ne[1] && sw[0] <= 180 && ne[0] >= -180 {
+		*b = *NewGeoBound(sw[0], ne[0], sw[1], ne[1])
+		return nil
+	}
+
 	b.sw = points[0]
 	b.ne = points[0].Clone()
+	b.geo = false
 	b.Extend(points[1])
 
 	return nil
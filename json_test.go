@@ -126,6 +126,19 @@ func TestBoundJSON(t *testing.T) {
 		t.Errorf("unmarshal incorrect, got %v", b2)
 	}
 
+	// crossing the anti-meridian
+	b1 = NewGeoBound(170, -170, -10, 10)
+	data, _ = json.Marshal(b1)
+
+	b2 = nil
+	if err := json.Unmarshal(data, &b2); err != nil {
+		t.Errorf("should unmarshal just fine, %v", err)
+	}
+
+	if !b1.Equals(b2) || !b2.CrossesAntimeridian() || b2.Width() != 20 {
+		t.Errorf("unmarshal incorrect, got %v", b2)
+	}
+
 	err = json.Unmarshal([]byte("[[1,2]]"), &b2)
 	if err == nil {
 		t.Errorf("should get error since datatypes do not match")
@@ -252,6 +252,40 @@ func (l *Line) GeoMidpoint() *Point {
 	return p
 }
 
+// GeoCrossTrackDistance returns the distance, in meters, of the point from the
+// great circle through the line's points. The value is positive if the point is
+// to the right of the great circle heading from A() to B(), negative if to the left.
+func (l *Line) GeoCrossTrackDistance(point *Point) float64 {
+	delta13 := l.a.GeoDistanceFrom(point, true) / EarthRadius
+	theta13 := deg2rad(l.a.BearingTo(point))
+	theta12 := deg2rad(l.a.BearingTo(&l.b))
+
+	return math.Asin(math.Sin(delta13)*math.Sin(theta13-theta12)) * EarthRadius
+}
+
+// GeoAlongTrackDistance returns the distance, in meters, from A() to the point
+// on the great circle through the line's points closest to the given point.
+// The value is negative if the closest point is behind A(), and larger than
+// the line's GeoDistance if it is beyond B().
+func (l *Line) GeoAlongTrackDistance(point *Point) float64 {
+	delta13 := l.a.GeoDistanceFrom(point, true) / EarthRadius
+	theta13 := deg2rad(l.a.BearingTo(point))
+	theta12 := deg2rad(l.a.BearingTo(&l.b))
+
+	deltaXT := math.Asin(math.Sin(delta13) * math.Sin(theta13-theta12))
+	cosRatio := math.Cos(delta13) / math.Cos(deltaXT)
+	if cosRatio > 1 {
+		cosRatio = 1 // rounding errors
+	}
+
+	deltaAT := math.Acos(cosRatio)
+	if math.Cos(theta12-theta13) < 0 {
+		deltaAT = -deltaAT
+	}
+
+	return deltaAT * EarthRadius
+}
+
 // Bound returns a bound around the line. Simply uses rectangular coordinates.
 func (l *Line) Bound() *Bound {
 	return NewBound(math.Max(l.a[0], l.b[0]), math.Min(l.a[0], l.b[0]),
@@ -421,3 +421,51 @@ func TestLineString(t *testing.T) {
 		t.Errorf("line, string expected %s, got %s", answer, s)
 	}
 }
+
+func TestLineGeoCrossTrackDistance(t *testing.T) {
+	l := NewLine(NewPoint(0, 0), NewPoint(10, 0))
+	degree := EarthRadius * math.Pi / 180
+
+	if d := l.GeoCrossTrackDistance(NewPoint(5, 1)); math.Abs(d+degree) > epsilon {
+		t.Errorf("line, geoCrossTrackDistance expected %f, got %f", -degree, d)
+	}
+
+	if d := l.GeoCrossTrackDistance(NewPoint(5, -1)); math.Abs(d-degree) > epsilon {
+		t.Errorf("line, geoCrossTrackDistance expected %f, got %f", degree, d)
+	}
+
+	if d := l.GeoCrossTrackDistance(NewPoint(20, 0)); math.Abs(d) > epsilon {
+		t.Errorf("line, geoCrossTrackDistance expected 0, got %f", d)
+	}
+
+	// movable-type.co.uk example, which uses a radius of 6371km
+	l = NewLine(NewPoint(-1.7297, 53.3206), NewPoint(0.1334, 53.1887))
+	expected := -307.55 * EarthRadius / 6371000
+	if d := l.GeoCrossTrackDistance(NewPoint(-0.7972, 53.2611)); math.Abs(d-expected) > 0.1 {
+		t.Errorf("line, geoCrossTrackDistance expected %f, got %f", expected, d)
+	}
+}
+
+func TestLineGeoAlongTrackDistance(t *testing.T) {
+	l := NewLine(NewPoint(0, 0), NewPoint(10, 0))
+	degree := EarthRadius * math.Pi / 180
+
+	if d := l.GeoAlongTrackDistance(NewPoint(5, 1)); math.Abs(d-5*degree) > epsilon {
+		t.Errorf("line, geoAlongTrackDistance expected %f, got %f", 5*degree, d)
+	}
+
+	if d := l.GeoAlongTrackDistance(NewPoint(-2, -1)); math.Abs(d+2*degree) > epsilon {
+		t.Errorf("line, geoAlongTrackDistance expected %f, got %f", -2*degree, d)
+	}
+
+	if d := l.GeoAlongTrackDistance(NewPoint(0, 0)); d != 0 {
+		t.Errorf("line, geoAlongTrackDistance expected 0, got %f", d)
+	}
+
+	// movable-type.co.uk example, which uses a radius of 6371km
+	l = NewLine(NewPoint(-1.7297, 53.3206), NewPoint(0.1334, 53.1887))
+	expected := 62331.6 * EarthRadius / 6371000
+	if d := l.GeoAlongTrackDistance(NewPoint(-0.7972, 53.2611)); math.Abs(d-expected) > 1 {
+		t.Errorf("line, geoAlongTrackDistance expected %f, got %f", expected, d)
+	}
+}
@@ -530,6 +530,24 @@ func (p *Path) Length() int {
 	return p.PointSet.Length()
 }
 
+// Reverse reverses the order of the points of the path in place.
+func (p *Path) Reverse() *Path {
+	p.PointSet.Reverse()
+	return p
+}
+
+// ForceClockwise reverses the path if, as a ring, it is in counter-clockwise order.
+func (p *Path) ForceClockwise() *Path {
+	p.PointSet.ForceClockwise()
+	return p
+}
+
+// ForceCounterClockwise reverses the path if, as a ring, it is in clockwise order.
+func (p *Path) ForceCounterClockwise() *Path {
+	p.PointSet.ForceCounterClockwise()
+	return p
+}
+
 // Equals compares two paths. Returns true if lengths are the same
 // and all points are Equal.
 func (p *Path) Equals(path *Path) bool {
@@ -0,0 +1,78 @@
+package geo
+
+// UnwrapLongitudes modifies the longitudes of the path so that there are no
+// jumps of more than 180 degrees between consecutive points, by adding or subtracting
+// multiples of 360. Paths crossing the anti-meridian will have longitudes
+// outside of [-180, 180] but will be continuous, which is useful for computing
+// bounds and drawing. Use NormalizeLongitudes to undo.
+func (p *Path) UnwrapLongitudes() *Path {
+	for i := 1; i < len(p.PointSet); i++ {
+		prev := p.PointSet[i-1][0]
+		p.PointSet[i][0] = prev + normalizeLng(p.PointSet[i][0]-prev)
+	}
+
+	return p
+}
+
+// NormalizeLongitudes wraps the longitude of every point into the range [-180, 180].
+func (p *Path) NormalizeLongitudes() *Path {
+	for i := range p.PointSet {
+		p.PointSet[i].NormalizeLng()
+	}
+
+	return p
+}
+
+// SplitAtAntimeridian returns new paths split where the path crosses the
+// anti-meridian, so they can be drawn on a map without a line across the
+// whole world. A segment crosses if its longitudes differ by more than 180 degrees.
+// The crossing points are linearly interpolated, ending one path at 180 (or -180)
+// and starting the next at -180 (or 180). All the longitudes are normalized
+// into the range [-180, 180]. The original path is not modified.
+func (p *Path) SplitAtAntimeridian() []*Path {
+	if len(p.PointSet) == 0 {
+		return []*Path{p.Clone()}
+	}
+
+	current := NewPathPreallocate(0, len(p.PointSet))
+	current.Push(p.PointSet[0].Clone().NormalizeLng())
+
+	result := []*Path{current}
+	for i := 1; i < len(p.PointSet); i++ {
+		prev := current.PointSet[len(current.PointSet)-1]
+		point := p.PointSet[i].Clone().NormalizeLng()
+
+		delta := point[0] - prev[0]
+		if delta > 180 || delta < -180 {
+			// the side of the anti-meridian the previous point is on
+			side := 180.0
+			if delta > 0 {
+				side = -180
+			}
+
+			// interpolate in continuous longitudes
+			lat := prev[1]
+			if unwrapped := prev[0] + normalizeLng(delta); unwrapped != prev[0] {
+				t := (side - prev[0]) / (unwrapped - prev[0])
+				lat += t * (point[1] - prev[1])
+			}
+
+			// avoid repeating points already on the anti-meridian
+			if prev[0] != side {
+				current.Push(NewPoint(side, lat))
+			}
+
+			current = NewPathPreallocate(0, len(p.PointSet)-i+1)
+			current.Push(NewPoint(-side, lat))
+			result = append(result, current)
+
+			if point[0] == -side {
+				continue
+			}
+		}
+
+		current.Push(point)
+	}
+
+	return result
+}
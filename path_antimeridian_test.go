@@ -0,0 +1,125 @@
+package geo
+
+import "testing"
+
+func TestPathUnwrapLongitudes(t *testing.T) {
+	path := NewPath()
+	path.Push(NewPoint(170, 0))
+	path.Push(NewPoint(179, 1))
+	path.Push(NewPoint(-175, 2))
+	path.Push(NewPoint(-170, 3))
+	path.Push(NewPoint(175, 4))
+
+	path.UnwrapLongitudes()
+
+	expected := PointSet{{170, 0}, {179, 1}, {185, 2}, {190, 3}, {175, 4}}
+	if !path.PointSet.Equals(&expected) {
+		t.Errorf("path, unwrapLongitudes expected %v, got %v", expected, path)
+	}
+
+	if b := path.Bound(); b.Width() != 20 {
+		t.Errorf("path, unwrapLongitudes should have continuous bound, got %v", b)
+	}
+
+	path.NormalizeLongitudes()
+
+	expected = PointSet{{170, 0}, {179, 1}, {-175, 2}, {-170, 3}, {175, 4}}
+	if !path.PointSet.Equals(&expected) {
+		t.Errorf("path, normalizeLongitudes expected %v, got %v", expected, path)
+	}
+
+	// westward
+	path = NewPath()
+	path.Push(NewPoint(-170, 0))
+	path.Push(NewPoint(170, 0))
+	path.Push(NewPoint(150, 0))
+
+	path.UnwrapLongitudes()
+
+	expected = PointSet{{-170, 0}, {-190, 0}, {-210, 0}}
+	if !path.PointSet.Equals(&expected) {
+		t.Errorf("path, unwrapLongitudes expected %v, got %v", expected, path)
+	}
+}
+
+func TestPathSplitAtAntimeridian(t *testing.T) {
+	path := NewPath()
+	path.Push(NewPoint(170, 0))
+	path.Push(NewPoint(-170, 10))
+	path.Push(NewPoint(-160, 10))
+	path.Push(NewPoint(160, 20))
+
+	paths := path.SplitAtAntimeridian()
+	if l := len(paths); l != 3 {
+		t.Fatalf("path, splitAtAntimeridian expected 3 paths, got %d", l)
+	}
+
+	expected := []PointSet{
+		{{170, 0}, {180, 5}},
+		{{-180, 5}, {-170, 10}, {-160, 10}, {-180, 15}},
+		{{180, 15}, {160, 20}},
+	}
+
+	for i := range expected {
+		if !paths[i].PointSet.Equals(&expected[i]) {
+			t.Errorf("path, splitAtAntimeridian %d expected %v, got %v", i, expected[i], paths[i])
+		}
+	}
+
+	if path.Length() != 4 {
+		t.Errorf("path, splitAtAntimeridian should not modify the original, got %v", path)
+	}
+
+	// longitudes out of range, crossing back and forth
+	path = NewPath()
+	path.Push(NewPoint(-170, 0))
+	path.Push(NewPoint(170, 10))
+	path.Push(NewPoint(190, 10))
+	path.Push(NewPoint(10, 10))
+
+	paths = path.SplitAtAntimeridian()
+	if l := len(paths); l != 3 {
+		t.Fatalf("path, splitAtAntimeridian expected 3 paths, got %d", l)
+	}
+
+	if p := paths[2].GetAt(1); !p.Equals(NewPoint(-170, 10)) {
+		t.Errorf("path, splitAtAntimeridian should normalize longitudes, got %v", p)
+	}
+
+	// not crossing
+	path = NewPath()
+	path.Push(NewPoint(-170, 0))
+	path.Push(NewPoint(10, 10))
+	path.Push(NewPoint(170, 10))
+
+	if paths := path.SplitAtAntimeridian(); len(paths) != 1 {
+		t.Errorf("path, splitAtAntimeridian expected 1 path, got %d", len(paths))
+	}
+
+	// on the anti-meridian
+	path = NewPath()
+	path.Push(NewPoint(170, 0))
+	path.Push(NewPoint(180, 0))
+	path.Push(NewPoint(-170, 0))
+
+	paths = path.SplitAtAntimeridian()
+	if l := len(paths); l != 2 {
+		t.Fatalf("path, splitAtAntimeridian expected 2 paths, got %d", l)
+	}
+
+	expected = []PointSet{
+		{{170, 0}, {180, 0}},
+		{{-180, 0}, {-170, 0}},
+	}
+
+	for i := range expected {
+		if !paths[i].PointSet.Equals(&expected[i]) {
+			t.Errorf("path, splitAtAntimeridian %d expected %v, got %v", i, expected[i], paths[i])
+		}
+	}
+
+	// empty
+	if paths := NewPath().SplitAtAntimeridian(); len(paths) != 1 || paths[0].Length() != 0 {
+		t.Errorf("path, splitAtAntimeridian of empty path expected 1 empty path, got %v", paths)
+	}
+}
@@ -71,25 +71,50 @@ func (p *Path) resample(distances []float64, totalDistance float64, totalPoints
 	points := make([]Point, 1, totalPoints)
 	points[0] = p.PointSet[0] // start stays the same
 
+	resampleSteps(distances, totalDistance, totalPoints, func(i int, percent float64) {
+		a, b := &p.PointSet[i], &p.PointSet[i+1]
+		points = append(points, Point{
+			a[0] + percent*(b[0]-a[0]),
+			a[1] + percent*(b[1]-a[1]),
+		})
+	})
+
+	// end stays the same, to handle round off errors
+	if totalPoints != 1 { // for 1, we want the first point
+		points[totalPoints-1] = p.PointSet[len(p.PointSet)-1]
+	}
+
+	(&p.PointSet).SetPoints(points)
+	return
+}
+
+// resampleSteps calls the function for each point, after the first, of a path resampled
+// into totalPoints-1 evenly spaced segments. It is given the index of the segment
+// of the original path the point is on and the percent along that segment.
+// The distances are the lengths of the segments of the original path.
+func resampleSteps(distances []float64, totalDistance float64, totalPoints int, f func(i int, percent float64)) {
+	if totalDistance == 0 {
+		// all the points are at the start, avoids dividing by zero
+		for step := 1; step < totalPoints; step++ {
+			f(0, 0)
+		}
+		return
+	}
+
 	step := 1
 	distance := 0.0
 
 	currentDistance := totalDistance / float64(totalPoints-1)
-	currentLine := &Line{} // declare here and update has nice performance benefits
-	for i := 0; i < len(p.PointSet)-1; i++ {
-		currentLine.a = p.PointSet[i]
-		currentLine.b = p.PointSet[i+1]
-
-		currentLineDistance := distances[i]
+	for i, currentLineDistance := range distances {
 		nextDistance := distance + currentLineDistance
 
 		for currentDistance <= nextDistance {
 			// need to add a point
-			percent := (currentDistance - distance) / currentLineDistance
-			points = append(points, Point{
-				currentLine.a[0] + percent*(currentLine.b[0]-currentLine.a[0]),
-				currentLine.a[1] + percent*(currentLine.b[1]-currentLine.a[1]),
-			})
+			percent := 0.0
+			if currentLineDistance != 0 {
+				percent = (currentDistance - distance) / currentLineDistance
+			}
+			f(i, percent)
 
 			// move to the next distance we want
 			step++
@@ -102,14 +127,6 @@ func (p *Path) resample(distances []float64, totalDistance float64, totalPoints
 		// past the current point in the original line, so move to the next one
 		distance = nextDistance
 	}
-
-	// end stays the same, to handle round off errors
-	if totalPoints != 1 { // for 1, we want the first point
-		points[totalPoints-1] = p.PointSet[len(p.PointSet)-1]
-	}
-
-	(&p.PointSet).SetPoints(points)
-	return
 }
 
 // resampleEdgeCases is used to handle edge case for
@@ -664,3 +664,28 @@ func TestPathString(t *testing.T) {
 		t.Errorf("path, string expected %s, got %s", answer, s)
 	}
 }
+
+func TestPathOrientation(t *testing.T) {
+	p := NewPath()
+	p.Push(NewPoint(0, 0)).Push(NewPoint(0, 1)).Push(NewPoint(1, 1)).Push(NewPoint(0, 0))
+
+	if !p.IsClockwise() {
+		t.Errorf("path, should be clockwise")
+	}
+
+	if a := p.SignedArea(); a != -0.5 {
+		t.Errorf("path, signedArea expected -0.5, got %f", a)
+	}
+
+	if !p.Clone().ForceCounterClockwise().IsCounterClockwise() {
+		t.Errorf("path, should be counter-clockwise")
+	}
+
+	if !p.Clone().ForceClockwise().Equals(p) {
+		t.Errorf("path, forceClockwise should not change the path")
+	}
+
+	if r := p.Clone().Reverse(); !r.GetAt(1).Equals(NewPoint(1, 1)) {
+		t.Errorf("path, reverse incorrect, got %v", r)
+	}
+}
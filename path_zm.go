@@ -0,0 +1,485 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/paulmach/go.geojson"
+)
+
+// A Dimension indicates which of the Z (elevation) and M (measure)
+// values of the points of a geometry are meaningful.
+// The values match the ISO WKB type code offsets divided by 1000.
+type Dimension int
+
+// The possible coordinate dimensions.
+const (
+	XY Dimension = iota
+	XYZ
+	XYM
+	XYZM
+)
+
+// HasZ returns true if the dimension includes elevation.
+func (d Dimension) HasZ() bool {
+	return d == XYZ || d == XYZM
+}
+
+// HasM returns true if the dimension includes a measure.
+func (d Dimension) HasM() bool {
+	return d == XYM || d == XYZM
+}
+
+// String returns the WKT dimension marker, eg. Z, M or ZM. Empty for XY.
+func (d Dimension) String() string {
+	switch d {
+	case XYZ:
+		return "Z"
+	case XYM:
+		return "M"
+	case XYZM:
+		return "ZM"
+	}
+
+	return ""
+}
+
+// count returns the number of values of each point, 2 to 4.
+func (d Dimension) count() int {
+	n := 2
+	if d.HasZ() {
+		n++
+	}
+
+	if d.HasM() {
+		n++
+	}
+
+	return n
+}
+
+func dimensionOf(hasZ, hasM bool) Dimension {
+	d := XY
+	if hasZ {
+		d = XYZ
+	}
+
+	if hasM {
+		d += XYM
+	}
+
+	return d
+}
+
+// A PointZM is a Point with an elevation, Z, and a measure, M, value.
+// The embedded Point can be used with all of the 2D methods.
+type PointZM struct {
+	Point
+	Z, M float64
+}
+
+// NewPointZM creates a new point with elevation and measure values.
+func NewPointZM(x, y, z, m float64) *PointZM {
+	return &PointZM{Point{x, y}, z, m}
+}
+
+// Equals checks if the point represents the same point
+// including the elevation and measure.
+func (p *PointZM) Equals(point *PointZM) bool {
+	return p.Point.Equals(&point.Point) && p.Z == point.Z && p.M == point.M
+}
+
+// interpolateZM returns the point the given percent from a to b,
+// with all values linearly interpolated.
+func interpolateZM(a, b *PointZM, percent float64) PointZM {
+	return PointZM{
+		Point{
+			a.Point[0] + percent*(b.Point[0]-a.Point[0]),
+			a.Point[1] + percent*(b.Point[1]-a.Point[1]),
+		},
+		a.Z + percent*(b.Z-a.Z),
+		a.M + percent*(b.M-a.M),
+	}
+}
+
+// A PathZM is a polyline whose points carry elevation and/or measure values.
+// Dim indicates which of the values are meaningful and is used for encoding.
+type PathZM struct {
+	Dim    Dimension
+	Points []PointZM
+}
+
+// NewPathZM creates a new empty path with the given dimension.
+func NewPathZM(dim Dimension) *PathZM {
+	return &PathZM{Dim: dim}
+}
+
+// NewPathZMFromPath creates a new path with the points of the 2D path
+// and zero elevation and measure values.
+func NewPathZMFromPath(path *Path, dim Dimension) *PathZM {
+	p := &PathZM{
+		Dim:    dim,
+		Points: make([]PointZM, len(path.PointSet)),
+	}
+
+	for i := range path.PointSet {
+		p.Points[i].Point = path.PointSet[i]
+	}
+
+	return p
+}
+
+// NewPathZMFromSlice creates a path from a slice of []float64 values
+// such as GeoJSON positions. The first two elements are the horizontal and
+// vertical components, followed by the elevation and/or measure values
+// as indicated by the dimension. Missing values are set to zero.
+// Nil slices or slices with less than 2 elements are skipped.
+func NewPathZMFromSlice(data [][]float64, dim Dimension) *PathZM {
+	p := &PathZM{
+		Dim:    dim,
+		Points: make([]PointZM, 0, len(data)),
+	}
+
+	for _, d := range data {
+		if len(d) < 2 {
+			continue
+		}
+
+		p.Points = append(p.Points, pointZMFromSlice(d, dim))
+	}
+
+	return p
+}
+
+func pointZMFromSlice(d []float64, dim Dimension) PointZM {
+	point := PointZM{Point: Point{d[0], d[1]}}
+
+	i := 2
+	if dim.HasZ() {
+		if len(d) > i {
+			point.Z = d[i]
+		}
+		i++
+	}
+
+	if dim.HasM() && len(d) > i {
+		point.M = d[i]
+	}
+
+	return point
+}
+
+// Path returns a new 2D path with the elevation and measure values dropped.
+func (p *PathZM) Path() *Path {
+	path := NewPathPreallocate(len(p.Points), len(p.Points))
+	for i := range p.Points {
+		path.PointSet[i] = p.Points[i].Point
+	}
+
+	return path
+}
+
+// Push appends a point to the end of the path.
+func (p *PathZM) Push(point *PointZM) *PathZM {
+	p.Points = append(p.Points, *point)
+	return p
+}
+
+// GetAt returns the pointer to the PointZM in the path.
+// Returns nil if index is out of range.
+func (p *PathZM) GetAt(i int) *PointZM {
+	if i >= len(p.Points) || i < 0 {
+		return nil
+	}
+
+	return &p.Points[i]
+}
+
+// Length returns the number of points in the path.
+func (p *PathZM) Length() int {
+	return len(p.Points)
+}
+
+// Transform applies a given projection or inverse projection to the
+// horizontal components of all the points in the path.
+func (p *PathZM) Transform(projector Projector) *PathZM {
+	for i := range p.Points {
+		projector(&p.Points[i].Point)
+	}
+
+	return p
+}
+
+// Bound returns a bound around the horizontal components of the path.
+func (p *PathZM) Bound() *Bound {
+	return p.Path().Bound()
+}
+
+// Distance computes the total horizontal distance in the units of the points.
+func (p *PathZM) Distance() float64 {
+	sum := 0.0
+	for i := 0; i < len(p.Points)-1; i++ {
+		sum += p.Points[i].DistanceFrom(&p.Points[i+1].Point)
+	}
+
+	return sum
+}
+
+// Distance3D computes the total distance including changes in elevation,
+// which must be in the same units as the points. Same as Distance
+// if the path does not have elevation.
+func (p *PathZM) Distance3D() float64 {
+	if !p.Dim.HasZ() {
+		return p.Distance()
+	}
+
+	sum := 0.0
+	for i := 0; i < len(p.Points)-1; i++ {
+		d := p.Points[i].DistanceFrom(&p.Points[i+1].Point)
+		dz := p.Points[i+1].Z - p.Points[i].Z
+		sum += math.Sqrt(d*d + dz*dz)
+	}
+
+	return sum
+}
+
+// GeoDistance computes the total horizontal distance using spherical geometry.
+func (p *PathZM) GeoDistance(haversine ...bool) float64 {
+	return p.Path().GeoDistance(haversine...)
+}
+
+// GeoDistance3D computes the total distance in meters using spherical geometry
+// for the horizontal component and including changes in elevation,
+// which must be in meters. Same as GeoDistance if the path does not have elevation.
+func (p *PathZM) GeoDistance3D(haversine ...bool) float64 {
+	if !p.Dim.HasZ() {
+		return p.GeoDistance(haversine...)
+	}
+
+	yesgeo := yesHaversine(haversine)
+	sum := 0.0
+	for i := 0; i < len(p.Points)-1; i++ {
+		d := p.Points[i].GeoDistanceFrom(&p.Points[i+1].Point, yesgeo)
+		dz := p.Points[i+1].Z - p.Points[i].Z
+		sum += math.Sqrt(d*d + dz*dz)
+	}
+
+	return sum
+}
+
+// Interpolate performs a linear interpolation along the path using
+// the horizontal distance. Elevation and measure values are also interpolated.
+// Returns nil for empty paths.
+func (p *PathZM) Interpolate(percent float64) *PointZM {
+	if len(p.Points) == 0 {
+		return nil
+	}
+
+	if percent <= 0 {
+		return &p.Points[0]
+	} else if percent >= 1 {
+		return &p.Points[len(p.Points)-1]
+	}
+
+	destination := p.Distance() * percent
+	travelled := 0.0
+
+	for i := 0; i < len(p.Points)-1; i++ {
+		segDistance := p.Points[i].DistanceFrom(&p.Points[i+1].Point)
+		if (travelled + segDistance) > destination {
+			point := interpolateZM(&p.Points[i], &p.Points[i+1], (destination-travelled)/segDistance)
+			return &point
+		}
+		travelled += segDistance
+	}
+
+	return &p.Points[0]
+}
+
+// Resample converts the path into totalPoints-1 evenly spaced segments.
+// Assumes euclidean geometry. Elevation and measure values are interpolated.
+func (p *PathZM) Resample(totalPoints int) *PathZM {
+	if totalPoints <= 0 {
+		p.Points = make([]PointZM, 0)
+		return p
+	}
+
+	if p.resampleEdgeCases(totalPoints) {
+		return p
+	}
+
+	total, dists := precomputeDistances(p.Path().PointSet)
+	p.resample(dists, total, totalPoints)
+	return p
+}
+
+// ResampleWithInterval coverts the path into evenly spaced points of
+// about the given distance. Elevation and measure values are interpolated.
+func (p *PathZM) ResampleWithInterval(dist float64) *PathZM {
+	if dist <= 0 {
+		p.Points = make([]PointZM, 0)
+		return p
+	}
+
+	total, dists := precomputeDistances(p.Path().PointSet)
+
+	totalPoints := int(total/dist) + 1
+	if p.resampleEdgeCases(totalPoints) {
+		return p
+	}
+
+	p.resample(dists, total, totalPoints)
+	return p
+}
+
+// ResampleWithGeoInterval converts the path into about evenly spaced points of
+// about the given distance in meters. The distance is computed using
+// spherical (lng/lat) geometry. Elevation and measure values are interpolated.
+func (p *PathZM) ResampleWithGeoInterval(meters float64) *PathZM {
+	if meters <= 0 {
+		p.Points = make([]PointZM, 0)
+		return p
+	}
+
+	totalDistance := 0.0
+	distances := make([]float64, len(p.Points)-1)
+	for i := 0; i < len(p.Points)-1; i++ {
+		distances[i] = p.Points[i].GeoDistanceFrom(&p.Points[i+1].Point)
+		totalDistance += distances[i]
+	}
+
+	totalPoints := int(totalDistance/meters) + 1
+	if p.resampleEdgeCases(totalPoints) {
+		return p
+	}
+
+	p.resample(distances, totalDistance, totalPoints)
+	return p
+}
+
+// resample mirrors Path.resample, interpolating all the values of the points.
+func (p *PathZM) resample(distances []float64, totalDistance float64, totalPoints int) {
+	points := make([]PointZM, 1, totalPoints)
+	points[0] = p.Points[0]
+
+	resampleSteps(distances, totalDistance, totalPoints, func(i int, percent float64) {
+		points = append(points, interpolateZM(&p.Points[i], &p.Points[i+1], percent))
+	})
+
+	if totalPoints != 1 {
+		points[totalPoints-1] = p.Points[len(p.Points)-1]
+	}
+
+	p.Points = points
+}
+
+// resampleEdgeCases handles not enough points and all the points
+// being at the same place, with only the x and y values compared.
+// Returns true if one of these edge cases was found and handled.
+func (p *PathZM) resampleEdgeCases(totalPoints int) bool {
+	if len(p.Points) <= 1 {
+		return true
+	}
+
+	for i := range p.Points {
+		if !p.Points[0].Point.Equals(&p.Points[i].Point) {
+			return false
+		}
+	}
+
+	for len(p.Points) < totalPoints {
+		p.Points = append(p.Points, p.Points[0])
+	}
+	p.Points = p.Points[:totalPoints]
+
+	return true
+}
+
+// Equals compares two paths. Returns true if the dimensions and lengths
+// are the same and all points are Equal.
+func (p *PathZM) Equals(path *PathZM) bool {
+	if p.Dim != path.Dim || len(p.Points) != len(path.Points) {
+		return false
+	}
+
+	for i := range p.Points {
+		if !p.Points[i].Equals(&path.Points[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a new copy of the path.
+func (p *PathZM) Clone() *PathZM {
+	points := make([]PointZM, len(p.Points))
+	copy(points, p.Points)
+
+	return &PathZM{Dim: p.Dim, Points: points}
+}
+
+// ToGeoJSON creates a new geojson feature with a linestring geometry.
+// Positions include the elevation as the third value. Paths with a measure
+// include it as the fourth value, with a zero elevation if the path has none.
+func (p *PathZM) ToGeoJSON() *geojson.Feature {
+	coords := make([][]float64, 0, len(p.Points))
+	for _, v := range p.Points {
+		coords = append(coords, v.geoJSONPosition(p.Dim))
+	}
+
+	return geojson.NewLineStringFeature(coords)
+}
+
+func (p *PointZM) geoJSONPosition(dim Dimension) []float64 {
+	switch dim {
+	case XYZ:
+		return []float64{p.Point[0], p.Point[1], p.Z}
+	case XYM, XYZM:
+		return []float64{p.Point[0], p.Point[1], p.Z, p.M}
+	}
+
+	return []float64{p.Point[0], p.Point[1]}
+}
+
+// ToWKT returns the path in WKT format, eg. LINESTRING Z (30 10 1,10 30 2)
+// For empty paths the result will be 'EMPTY'.
+func (p *PathZM) ToWKT() string {
+	return p.String()
+}
+
+// String returns a string representation of the path.
+// The format is WKT, e.g. LINESTRING Z (30 10 1,10 30 2)
+// For empty paths the result will be 'EMPTY'.
+func (p *PathZM) String() string {
+	if len(p.Points) == 0 {
+		return "EMPTY"
+	}
+
+	buff := bytes.NewBuffer(nil)
+	buff.WriteString("LINESTRING")
+	if p.Dim != XY {
+		fmt.Fprintf(buff, " %s ", p.Dim)
+	}
+
+	buff.WriteString("(")
+	for i := range p.Points {
+		if i != 0 {
+			buff.WriteString(",")
+		}
+
+		point := &p.Points[i]
+		fmt.Fprintf(buff, "%g %g", point.Point[0], point.Point[1])
+		if p.Dim.HasZ() {
+			fmt.Fprintf(buff, " %g", point.Z)
+		}
+
+		if p.Dim.HasM() {
+			fmt.Fprintf(buff, " %g", point.M)
+		}
+	}
+	buff.WriteString(")")
+
+	return buff.String()
+}
@@ -0,0 +1,341 @@
+package geo
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func testPathZM() *PathZM {
+	return NewPathZM(XYZM).
+		Push(NewPointZM(0, 0, 10, 0)).
+		Push(NewPointZM(3, 4, 10, 5)).
+		Push(NewPointZM(3, 8, 13, 9))
+}
+
+func TestDimension(t *testing.T) {
+	type testData struct {
+		dim        Dimension
+		hasZ, hasM bool
+		marker     string
+	}
+
+	tests := []testData{
+		{XY, false, false, ""},
+		{XYZ, true, false, "Z"},
+		{XYM, false, true, "M"},
+		{XYZM, true, true, "ZM"},
+	}
+
+	for i, test := range tests {
+		if test.dim.HasZ() != test.hasZ {
+			t.Errorf("test %d incorrect has z", i)
+		}
+
+		if test.dim.HasM() != test.hasM {
+			t.Errorf("test %d incorrect has m", i)
+		}
+
+		if test.dim.String() != test.marker {
+			t.Errorf("test %d incorrect marker, got %v", i, test.dim)
+		}
+
+		if d := dimensionOf(test.hasZ, test.hasM); d != test.dim {
+			t.Errorf("test %d incorrect dimension, got %v", i, d)
+		}
+	}
+}
+
+func TestNewPathZMFromSlice(t *testing.T) {
+	p := NewPathZMFromSlice([][]float64{{1, 2, 3}, nil, {4, 5}}, XYZ)
+	expected := NewPathZM(XYZ).Push(NewPointZM(1, 2, 3, 0)).Push(NewPointZM(4, 5, 0, 0))
+	if !p.Equals(expected) {
+		t.Errorf("incorrect path, got %v", p)
+	}
+
+	p = NewPathZMFromSlice([][]float64{{1, 2, 3}}, XYM)
+	if !p.GetAt(0).Equals(NewPointZM(1, 2, 0, 3)) {
+		t.Errorf("incorrect point, got %v", p.GetAt(0))
+	}
+
+	path := NewPath().Push(NewPoint(1, 2))
+	p = NewPathZMFromPath(path, XYZ)
+	if !p.Path().Equals(path) || p.Dim != XYZ {
+		t.Errorf("incorrect path, got %v", p)
+	}
+}
+
+func TestPathZMDistance(t *testing.T) {
+	p := testPathZM()
+
+	if d := p.Distance(); d != 9 {
+		t.Errorf("incorrect distance, got %v", d)
+	}
+
+	if d := p.Distance3D(); d != 10 {
+		t.Errorf("incorrect 3d distance, got %v", d)
+	}
+
+	p.Dim = XYM
+	if d := p.Distance3D(); d != 9 {
+		t.Errorf("should ignore z if not part of dimension, got %v", d)
+	}
+
+	geo := NewPathZM(XYZ).Push(NewPointZM(0, 0, 0, 0)).Push(NewPointZM(0, 0.001, 100, 0))
+	horizontal := geo.GeoDistance()
+	expected := math.Sqrt(horizontal*horizontal + 100*100)
+	if d := geo.GeoDistance3D(); math.Abs(d-expected) > epsilon {
+		t.Errorf("incorrect geo 3d distance, got %v", d)
+	}
+}
+
+func TestPathZMInterpolate(t *testing.T) {
+	p := testPathZM()
+
+	point := p.Interpolate(0.5)
+	expected := NewPointZM(2.7, 3.6, 10, 4.5)
+	if point.DistanceFrom(&expected.Point) > epsilon || point.Z != expected.Z || math.Abs(point.M-expected.M) > epsilon {
+		t.Errorf("incorrect point, got %v", point)
+	}
+
+	if point := p.Interpolate(-1); !point.Equals(p.GetAt(0)) {
+		t.Errorf("incorrect point, got %v", point)
+	}
+
+	if point := p.Interpolate(2); !point.Equals(p.GetAt(2)) {
+		t.Errorf("incorrect point, got %v", point)
+	}
+
+	if point := NewPathZM(XYZ).Interpolate(0.5); point != nil {
+		t.Errorf("empty path should return nil, got %v", point)
+	}
+}
+
+func TestPathZMResample(t *testing.T) {
+	p := NewPathZM(XYZM).
+		Push(NewPointZM(0, 0, 0, 0)).
+		Push(NewPointZM(10, 0, 20, 100))
+
+	resampled := p.Clone().Resample(3)
+	expected := NewPathZM(XYZM).
+		Push(NewPointZM(0, 0, 0, 0)).
+		Push(NewPointZM(5, 0, 10, 50)).
+		Push(NewPointZM(10, 0, 20, 100))
+
+	if !resampled.Equals(expected) {
+		t.Errorf("incorrect resample, got %v", resampled)
+	}
+
+	resampled = p.Clone().ResampleWithInterval(5)
+	if !resampled.Equals(expected) {
+		t.Errorf("incorrect resample, got %v", resampled)
+	}
+
+	resampled = NewPathZM(XYZ).
+		Push(NewPointZM(0, 0, 0, 0)).
+		Push(NewPointZM(0, 1, 10, 0)).
+		ResampleWithGeoInterval(11119.49)
+	if l := resampled.Length(); l != 11 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	if z := resampled.GetAt(5).Z; math.Abs(z-5) > epsilon {
+		t.Errorf("should interpolate elevation, got %v", z)
+	}
+
+	// edge cases
+	if l := p.Clone().Resample(0).Length(); l != 0 {
+		t.Errorf("should be empty, got %d", l)
+	}
+
+	same := NewPathZM(XYZ).Push(NewPointZM(1, 1, 1, 0)).Push(NewPointZM(1, 1, 1, 0))
+	if l := same.Resample(4).Length(); l != 4 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	// same place with different elevations should not loop forever
+	vertical := NewPathZM(XYZ).Push(NewPointZM(1, 1, 0, 0)).Push(NewPointZM(1, 1, 10, 0))
+	if l := vertical.Clone().Resample(5).Length(); l != 5 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	if l := vertical.Clone().ResampleWithInterval(1).Length(); l != 1 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	if l := vertical.Clone().ResampleWithGeoInterval(1).Length(); l != 1 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	// different points with no distance between them
+	pole := NewPathZM(XYZ).Push(NewPointZM(0, 90, 0, 0)).Push(NewPointZM(10, 90, 10, 0))
+	if l := pole.ResampleWithGeoInterval(1).Length(); l != 1 {
+		t.Errorf("incorrect length, got %d", l)
+	}
+
+	pole = NewPathZM(XYZ).Push(NewPointZM(0, 90, 0, 0)).Push(NewPointZM(10, 90, 10, 0))
+	pole.resample([]float64{0}, 0, 3)
+	if l := pole.Length(); l != 3 || !pole.GetAt(2).Equals(NewPointZM(10, 90, 10, 0)) {
+		t.Errorf("incorrect resample, got %v", pole)
+	}
+}
+
+func TestPathZMTransform(t *testing.T) {
+	p := testPathZM()
+	p.Transform(func(p *Point) { p.Scale(2) })
+
+	if !p.GetAt(1).Equals(NewPointZM(6, 8, 10, 5)) {
+		t.Errorf("should only transform horizontal components, got %v", p.GetAt(1))
+	}
+
+	if !p.Bound().Equals(NewBound(0, 6, 0, 16)) {
+		t.Errorf("incorrect bound, got %v", p.Bound())
+	}
+}
+
+func TestPathZMWKB(t *testing.T) {
+	for _, dim := range []Dimension{XY, XYZ, XYM, XYZM} {
+		p := testPathZM()
+		p.Dim = dim
+		if !dim.HasZ() {
+			for i := range p.Points {
+				p.Points[i].Z = 0
+			}
+		}
+
+		if !dim.HasM() {
+			for i := range p.Points {
+				p.Points[i].M = 0
+			}
+		}
+
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			scanned := &PathZM{}
+			if err := scanned.Scan(p.MarshalWKB(order)); err != nil {
+				t.Errorf("%v should not get error, got %v", dim, err)
+			}
+
+			if !scanned.Equals(p) {
+				t.Errorf("%v incorrect round trip, got %v", dim, scanned)
+			}
+		}
+
+		// 2d types should skip the extra values
+		path := NewPath()
+		if err := path.Scan(p.MarshalWKB()); err != nil {
+			t.Errorf("%v should not get error, got %v", dim, err)
+		}
+
+		if !path.Equals(p.Path()) {
+			t.Errorf("%v incorrect path, got %v", dim, path)
+		}
+	}
+
+	// EWKB z point
+	scanned := &PathZM{}
+	err := scanned.Scan([]byte{1, 1, 0, 0, 128, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 8, 64})
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if scanned.Dim != XYZ || !scanned.GetAt(0).Equals(NewPointZM(1, 2, 3, 0)) {
+		t.Errorf("incorrect path, got %v", scanned)
+	}
+
+	// multipoint
+	scanned = &PathZM{}
+	ps := PointSet{{1, 2}, {3, 4}}
+	if err := scanned.Scan(ps.MarshalWKB()); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if scanned.Dim != XY || !scanned.Path().PointSet.Equals(&ps) {
+		t.Errorf("incorrect path, got %v", scanned)
+	}
+
+	// point count larger than the data
+	if err := scanned.Scan([]byte{1, 4, 0, 0, 0, 255, 255, 255, 127, 0, 0, 0, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestPathZMWKT(t *testing.T) {
+	p := testPathZM()
+
+	expected := "LINESTRING ZM (0 0 10 0,3 4 10 5,3 8 13 9)"
+	if s := p.ToWKT(); s != expected {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+
+	parsed, err := NewPathZMFromWKT(expected)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !parsed.Equals(p) {
+		t.Errorf("incorrect path, got %v", parsed)
+	}
+
+	type testData struct {
+		wkt   string
+		dim   Dimension
+		point *PointZM
+	}
+
+	tests := []testData{
+		{"LINESTRING(1 2,3 4)", XY, NewPointZM(1, 2, 0, 0)},
+		{"LINESTRING(1 2 3,3 4 5)", XYZ, NewPointZM(1, 2, 3, 0)},
+		{"LINESTRING M (1 2 3,3 4 5)", XYM, NewPointZM(1, 2, 0, 3)},
+		{"POINT Z (1 2 3)", XYZ, NewPointZM(1, 2, 3, 0)},
+		{"MULTIPOINT ZM ((1 2 3 4))", XYZM, NewPointZM(1, 2, 3, 4)},
+		{"POLYGON Z ((1 2 3,3 4 5,5 6 7,1 2 3))", XYZ, NewPointZM(1, 2, 3, 0)},
+	}
+
+	for i, test := range tests {
+		p, err := NewPathZMFromWKT(test.wkt)
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if p.Dim != test.dim {
+			t.Errorf("test %d incorrect dimension, got %v", i, p.Dim)
+		}
+
+		if !p.GetAt(0).Equals(test.point) {
+			t.Errorf("test %d incorrect point, got %v", i, p.GetAt(0))
+		}
+	}
+
+	if _, err := NewPathZMFromWKT("MULTIPOLYGON(((1 2,3 4,5 6,1 2)))"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if s := NewPathZM(XYZ).ToWKT(); s != "EMPTY" {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+}
+
+func TestPathZMToGeoJSON(t *testing.T) {
+	p := testPathZM()
+	p.Dim = XYZ
+
+	f := p.ToGeoJSON()
+	if !f.Geometry.IsLineString() {
+		t.Errorf("should be linestring geometry")
+	}
+
+	parsed := NewPathZMFromSlice(f.Geometry.LineString, XYZ)
+	for i := range parsed.Points {
+		p.Points[i].M = 0
+	}
+
+	if !parsed.Equals(p) {
+		t.Errorf("incorrect round trip, got %v", parsed)
+	}
+
+	p.Dim = XYM
+	if pos := p.ToGeoJSON().Geometry.LineString[1]; len(pos) != 4 || pos[2] != 10 {
+		t.Errorf("incorrect position, got %v", pos)
+	}
+}
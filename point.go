@@ -127,6 +127,26 @@ func (p *Point) BearingTo(point *Point) float64 {
 	return rad2deg(math.Atan2(y, x))
 }
 
+// GeoDestination returns a new point reached by traveling the distance,
+// in meters, along a great circle starting with the given bearing in degrees.
+// This is the inverse of BearingTo and GeoDistanceFrom using haversine.
+func (p *Point) GeoDestination(bearing, distance float64) *Point {
+	delta := distance / EarthRadius
+	theta := deg2rad(bearing)
+
+	latRad := deg2rad(p.Lat())
+	lngRad := deg2rad(p.Lng())
+
+	sinLat := math.Sin(latRad)*math.Cos(delta) + math.Cos(latRad)*math.Sin(delta)*math.Cos(theta)
+	destLatRad := math.Asin(sinLat)
+	destLngRad := lngRad + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(latRad),
+		math.Cos(delta)-math.Sin(latRad)*sinLat,
+	)
+
+	return NewPoint(normalizeLng(rad2deg(destLngRad)), rad2deg(destLatRad))
+}
+
 // Quadkey returns the quad key for the given point at the provided level.
 // See http://msdn.microsoft.com/en-us/library/bb259689.aspx for more information
 // about this coordinate system.
@@ -247,6 +267,34 @@ func (p *Point) Normalize() *Point {
 	return p
 }
 
+// NormalizeLng wraps the longitude of the point into the range [-180, 180].
+// For example 190 becomes -170. Only applies if the data is Lng/Lat degrees.
+func (p *Point) NormalizeLng() *Point {
+	p[0] = normalizeLng(p[0])
+	return p
+}
+
+// NormalizeLatLng wraps the point into valid latitude and longitude degrees.
+// Latitudes past the poles are reflected back, moving the point to the other
+// side of the earth, so NewPoint(10, 95) becomes (-170, 85). The longitude is then
+// wrapped into the range [-180, 180]. Only applies if the data is Lng/Lat degrees.
+func (p *Point) NormalizeLatLng() *Point {
+	lat := math.Mod(p[1]+90, 360)
+	if lat < 0 {
+		lat += 360
+	}
+
+	// lat is now in [0, 360), where (180, 360) is over a pole
+	if lat > 180 {
+		p[1] = 270 - lat
+		p[0] += 180
+	} else {
+		p[1] = lat - 90
+	}
+
+	return p.NormalizeLng()
+}
+
 // Scale each component of the point.
 func (p *Point) Scale(factor float64) *Point {
 	p[0] *= factor
@@ -132,6 +132,157 @@ func (ps PointSet) Bound() *Bound {
 	return NewBound(maxX, minX, maxY, minY)
 }
 
+// SignedArea returns the area enclosed by the points treated as a ring,
+// using standard Euclidean geometry. The ring does not need to be closed.
+// The value is positive if the ring is counter-clockwise, negative if clockwise.
+func (ps PointSet) SignedArea() float64 {
+	if len(ps) < 3 {
+		return 0
+	}
+
+	return ringSignedArea(ps)
+}
+
+// Area returns the area enclosed by the points treated as a ring,
+// using standard Euclidean geometry. The ring does not need to be closed.
+func (ps PointSet) Area() float64 {
+	return math.Abs(ps.SignedArea())
+}
+
+// GeoArea returns the area, in square meters, enclosed by the points treated
+// as a ring on a sphere of radius EarthRadius. The ring does not need to be closed.
+// Only applies if the data is Lng/Lat degrees.
+func (ps PointSet) GeoArea() float64 {
+	return EarthRadius * EarthRadius * math.Abs(ringGeoArea(ps, math.Sin))
+}
+
+// GeodesicArea returns the area, in square meters, enclosed by the points treated
+// as a ring on the WGS84 ellipsoid. The points are mapped to the authalic,
+// or equal area, sphere so the result is exact for edges along parallels and
+// meridians and very close for other edges. Only applies if the data is Lng/Lat degrees.
+func (ps PointSet) GeodesicArea() float64 {
+	return WGS84.A * WGS84.A / 2 * math.Abs(ringGeoArea(ps, WGS84.authalicQ))
+}
+
+// ringGeoArea computes the signed area of a lng/lat ring on a unit sphere
+// using the line integral from "Some Algorithms for Polygons on a Sphere"
+// by Chamberlain and Duquette. The sine of the latitude can be replaced
+// with the q function of an ellipsoid to compute the area on its authalic sphere.
+// The value is positive if the ring is counter-clockwise.
+func ringGeoArea(ring PointSet, sinLat func(float64) float64) float64 {
+	if len(ring) < 3 {
+		return 0
+	}
+
+	sum := 0.0
+	for i := range ring {
+		j := (i + 1) % len(ring)
+
+		dLng := deg2rad(ring[j].Lng() - ring[i].Lng())
+		if dLng > math.Pi {
+			dLng -= 2 * math.Pi
+		} else if dLng < -math.Pi {
+			dLng += 2 * math.Pi
+		}
+
+		sum += dLng * (sinLat(deg2rad(ring[i].Lat())) + sinLat(deg2rad(ring[j].Lat())))
+	}
+
+	return -sum / 2
+}
+
+// IsClockwise returns true if the points, treated as a ring, are in clockwise order.
+// The result is based on the sign of the Euclidean SignedArea.
+func (ps PointSet) IsClockwise() bool {
+	return ps.SignedArea() < 0
+}
+
+// IsCounterClockwise returns true if the points, treated as a ring,
+// are in counter-clockwise order.
+func (ps PointSet) IsCounterClockwise() bool {
+	return ps.SignedArea() > 0
+}
+
+// WindingNumber returns the number of times the points, treated as a ring,
+// wind counter-clockwise around the point. Clockwise windings are negative.
+// Points on the boundary may have any winding number, see OnRingBoundary.
+func (ps PointSet) WindingNumber(point *Point) int {
+	wn := 0
+	for i, j := len(ps)-1, 0; j < len(ps); i, j = j, j+1 {
+		a, b := &ps[i], &ps[j]
+		if a[1] <= point[1] {
+			if b[1] > point[1] && isLeft(a, b, point) > 0 {
+				wn++ // upward crossing with the point to the left
+			}
+		} else if b[1] <= point[1] && isLeft(a, b, point) < 0 {
+			wn-- // downward crossing with the point to the right
+		}
+	}
+
+	return wn
+}
+
+// OnRingBoundary returns true if the point is on one of the edges of the
+// points treated as a ring, including the closing edge.
+func (ps PointSet) OnRingBoundary(point *Point) bool {
+	for i, j := len(ps)-1, 0; j < len(ps); i, j = j, j+1 {
+		a, b := &ps[i], &ps[j]
+		if isLeft(a, b, point) == 0 &&
+			math.Min(a[0], b[0]) <= point[0] && point[0] <= math.Max(a[0], b[0]) &&
+			math.Min(a[1], b[1]) <= point[1] && point[1] <= math.Max(a[1], b[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RingContains determines if the point is inside the points treated as a ring,
+// using the non-zero winding rule. Points on the boundary are considered inside.
+// The ring does not need to be closed.
+func (ps PointSet) RingContains(point *Point) bool {
+	if len(ps) == 0 {
+		return false
+	}
+
+	return ps.WindingNumber(point) != 0 || ps.OnRingBoundary(point)
+}
+
+// isLeft returns a positive value if p is left of the line through a and b,
+// negative if it is to the right, and zero if it is on the line.
+func isLeft(a, b, p *Point) float64 {
+	return (b[0]-a[0])*(p[1]-a[1]) - (p[0]-a[0])*(b[1]-a[1])
+}
+
+// Reverse reverses the order of the points in place.
+func (ps *PointSet) Reverse() *PointSet {
+	for i, j := 0, len(*ps)-1; i < j; i, j = i+1, j-1 {
+		(*ps)[i], (*ps)[j] = (*ps)[j], (*ps)[i]
+	}
+
+	return ps
+}
+
+// ForceClockwise reverses the points if they are in counter-clockwise order.
+// Rings with no area are left unchanged.
+func (ps *PointSet) ForceClockwise() *PointSet {
+	if ps.IsCounterClockwise() {
+		ps.Reverse()
+	}
+
+	return ps
+}
+
+// ForceCounterClockwise reverses the points if they are in clockwise order.
+// Rings with no area are left unchanged.
+func (ps *PointSet) ForceCounterClockwise() *PointSet {
+	if ps.IsClockwise() {
+		ps.Reverse()
+	}
+
+	return ps
+}
+
 // SetAt updates a position at i in the point set
 func (ps *PointSet) SetAt(index int, point *Point) *PointSet {
 	deref := *ps
@@ -0,0 +1,271 @@
+package geo
+
+import (
+	"container/list"
+	"math"
+	"sort"
+)
+
+// ConvexHull returns the smallest convex ring containing all the points,
+// computed using Andrew's monotone chain algorithm. The ring is closed,
+// the first point is repeated at the end, and is in counter-clockwise order.
+// Collinear points along the edges of the hull are not included.
+// The original point set is not modified.
+func (ps PointSet) ConvexHull() *PointSet {
+	hull := convexHull(ps)
+	if len(hull) != 0 {
+		hull = append(hull, hull[0])
+	}
+
+	return &hull
+}
+
+// convexHull returns the open, counter-clockwise convex hull of the points.
+func convexHull(ps PointSet) PointSet {
+	points := make(PointSet, len(ps))
+	copy(points, ps)
+
+	sort.Sort(pointsByXY(points))
+
+	// remove duplicates
+	unique := points[:0]
+	for i := range points {
+		if i == 0 || points[i] != points[i-1] {
+			unique = append(unique, points[i])
+		}
+	}
+
+	if len(unique) < 3 {
+		return unique
+	}
+
+	hull := make(PointSet, 0, 2*len(unique))
+
+	// lower hull
+	for i := range unique {
+		for len(hull) >= 2 && isLeft(&hull[len(hull)-2], &hull[len(hull)-1], &unique[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, unique[i])
+	}
+
+	// upper hull
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		for len(hull) >= lower && isLeft(&hull[len(hull)-2], &hull[len(hull)-1], &unique[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, unique[i])
+	}
+
+	// the last point is the same as the first
+	return hull[:len(hull)-1]
+}
+
+// ConcaveHull returns a ring containing all the points that follows their shape
+// more closely than the convex hull. It starts with the convex hull and digs into
+// an edge, replacing it with two edges to the nearest inner point, if the
+// edge length divided by the distance to that point is larger than concavity.
+// Lower values create more concave shapes, values around 2 work well and
+// math.Inf(1) returns the convex hull. The ring will not self intersect,
+// is closed and is in counter-clockwise order. This can be slow for large
+// point sets, which should be reduced or clustered first.
+func (ps PointSet) ConcaveHull(concavity float64) *PointSet {
+	hull := convexHull(ps)
+	if len(hull) < 3 {
+		if len(hull) != 0 {
+			hull = append(hull, hull[0])
+		}
+		return &hull
+	}
+
+	// the points not on the convex hull
+	onHull := make(map[Point]bool, len(hull))
+	for _, p := range hull {
+		onHull[p] = true
+	}
+
+	var inner PointSet
+	for _, p := range ps {
+		if !onHull[p] {
+			onHull[p] = true // skip duplicates too
+			inner = append(inner, p)
+		}
+	}
+
+	ring := list.New()
+	for _, p := range hull {
+		ring.PushBack(p)
+	}
+
+	next := func(e *list.Element) *list.Element {
+		if e.Next() == nil {
+			return ring.Front()
+		}
+		return e.Next()
+	}
+
+	prev := func(e *list.Element) *list.Element {
+		if e.Prev() == nil {
+			return ring.Back()
+		}
+		return e.Prev()
+	}
+
+	// edges are identified by their starting element
+	queue := make([]*list.Element, 0, ring.Len())
+	for e := ring.Front(); e != nil; e = e.Next() {
+		queue = append(queue, e)
+	}
+
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+
+		before := prev(e).Value.(Point)
+		a := e.Value.(Point)
+		b := next(e).Value.(Point)
+		after := next(next(e)).Value.(Point)
+
+		edge := NewLine(&a, &b)
+		candidates := concaveCandidates(inner, edge, NewLine(&before, &a), NewLine(&b, &after))
+
+		// use the closest candidate where digging keeps the ring valid
+		index := -1
+		for _, i := range candidates {
+			if concaveDigValid(ring, e, &inner[i]) && !containsOtherPoints(inner, i, &a, &inner[i], &b) {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			continue
+		}
+
+		p := inner[index]
+		d := math.Min(p.DistanceFrom(&a), p.DistanceFrom(&b))
+		if edge.Distance()/d <= concavity {
+			continue
+		}
+
+		inserted := ring.InsertAfter(p, e)
+		inner = append(inner[:index], inner[index+1:]...)
+
+		queue = append(queue, e, inserted)
+	}
+
+	result := make(PointSet, 0, ring.Len()+1)
+	for e := ring.Front(); e != nil; e = e.Next() {
+		result = append(result, e.Value.(Point))
+	}
+	result = append(result, result[0])
+
+	return &result
+}
+
+// concaveCandidates returns the indexes of the inner points that are not
+// closer to either of the neighboring edges, sorted by distance to the edge.
+func concaveCandidates(inner PointSet, edge, prevEdge, nextEdge *Line) []int {
+	var candidates []int
+	var distances []float64
+
+	for i := range inner {
+		d := edge.SquaredDistanceFrom(&inner[i])
+		if prevEdge.SquaredDistanceFrom(&inner[i]) < d || nextEdge.SquaredDistanceFrom(&inner[i]) < d {
+			continue
+		}
+
+		candidates = append(candidates, i)
+		distances = append(distances, d)
+	}
+
+	sort.Sort(&candidatesByDistance{candidates, distances})
+	return candidates
+}
+
+type candidatesByDistance struct {
+	indexes   []int
+	distances []float64
+}
+
+func (c *candidatesByDistance) Len() int           { return len(c.indexes) }
+func (c *candidatesByDistance) Less(i, j int) bool { return c.distances[i] < c.distances[j] }
+func (c *candidatesByDistance) Swap(i, j int) {
+	c.indexes[i], c.indexes[j] = c.indexes[j], c.indexes[i]
+	c.distances[i], c.distances[j] = c.distances[j], c.distances[i]
+}
+
+// concaveDigValid checks that the two new edges, from the element to p and p to
+// the next element, do not intersect any of the other edges of the ring.
+func concaveDigValid(ring *list.List, start *list.Element, p *Point) bool {
+	a := start.Value.(Point)
+
+	end := start.Next()
+	if end == nil {
+		end = ring.Front()
+	}
+	b := end.Value.(Point)
+
+	first := NewLine(&a, p)
+	second := NewLine(p, &b)
+
+	for e := ring.Front(); e != nil; e = e.Next() {
+		n := e.Next()
+		if n == nil {
+			n = ring.Front()
+		}
+
+		if e == start {
+			continue
+		}
+
+		c := e.Value.(Point)
+		d := n.Value.(Point)
+		edge := NewLine(&c, &d)
+
+		// the neighboring edges share a point with one of the new edges
+		if n != start && edge.Intersects(first) {
+			return false
+		}
+
+		if e != end && edge.Intersects(second) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsOtherPoints checks if any of the inner points, other than the one
+// at the index, are inside the triangle that would be removed from the ring.
+func containsOtherPoints(inner PointSet, index int, a, p, b *Point) bool {
+	if isLeft(a, b, p) == 0 {
+		return false // p is on the edge so nothing is removed
+	}
+
+	for i := range inner {
+		if i == index {
+			continue
+		}
+
+		// a, p, b is clockwise since p is inside the counter-clockwise ring
+		if isLeft(a, p, &inner[i]) <= 0 && isLeft(p, b, &inner[i]) <= 0 && isLeft(b, a, &inner[i]) <= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+type pointsByXY PointSet
+
+func (ps pointsByXY) Len() int      { return len(ps) }
+func (ps pointsByXY) Swap(i, j int) { ps[i], ps[j] = ps[j], ps[i] }
+func (ps pointsByXY) Less(i, j int) bool {
+	if ps[i][0] != ps[j][0] {
+		return ps[i][0] < ps[j][0]
+	}
+
+	return ps[i][1] < ps[j][1]
+}
@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointSetConvexHull(t *testing.T) {
+	ps := append(PointSet{},
+		Point{0, 0}, Point{2, 2}, Point{1, 1}, Point{2, 0},
+		Point{0, 2}, Point{1, 0}, Point{0.5, 1.5}, Point{2, 2})
+
+	expected := append(PointSet{}, Point{0, 0}, Point{2, 0}, Point{2, 2}, Point{0, 2}, Point{0, 0})
+	if hull := ps.ConvexHull(); !hull.Equals(&expected) {
+		t.Errorf("pointset, convexHull expected %v, got %v", expected, hull)
+	}
+
+	// should not modify the original
+	if ps[1] != (Point{2, 2}) {
+		t.Errorf("pointset, convexHull should not modify the point set")
+	}
+
+	// all the points should be inside
+	hull := ps.ConvexHull()
+	for i := range ps {
+		if !hull.RingContains(&ps[i]) {
+			t.Errorf("pointset, convexHull should contain %v", ps[i])
+		}
+	}
+
+	if !hull.IsCounterClockwise() {
+		t.Errorf("pointset, convexHull should be counter-clockwise")
+	}
+
+	// degenerate cases
+	if hull := (PointSet{}).ConvexHull(); hull.Length() != 0 {
+		t.Errorf("pointset, convexHull of empty should be empty, got %v", hull)
+	}
+
+	single := append(PointSet{}, Point{1, 1}, Point{1, 1})
+	expected = append(PointSet{}, Point{1, 1}, Point{1, 1})
+	if hull := single.ConvexHull(); !hull.Equals(&expected) {
+		t.Errorf("pointset, convexHull of single point incorrect, got %v", hull)
+	}
+
+	collinear := append(PointSet{}, Point{0, 0}, Point{2, 2}, Point{1, 1})
+	expected = append(PointSet{}, Point{0, 0}, Point{2, 2}, Point{0, 0})
+	if hull := collinear.ConvexHull(); !hull.Equals(&expected) {
+		t.Errorf("pointset, convexHull of collinear points incorrect, got %v", hull)
+	}
+}
+
+func TestPointSetConcaveHull(t *testing.T) {
+	// a U shape, 0 to 10 wide with the gap from 3 to 7 above y=3
+	var ps PointSet
+	for x := 0.0; x <= 10; x += 0.5 {
+		for y := 0.0; y <= 10; y += 0.5 {
+			if x > 3 && x < 7 && y > 3 {
+				continue
+			}
+			ps = append(ps, Point{x, y})
+		}
+	}
+
+	convex := ps.ConvexHull()
+	if h := ps.ConcaveHull(math.Inf(1)); !h.Equals(convex) {
+		t.Errorf("pointset, concaveHull with infinite concavity should be convex hull, got %v", h)
+	}
+
+	hull := ps.ConcaveHull(2)
+	if !hull.IsCounterClockwise() {
+		t.Errorf("pointset, concaveHull should be counter-clockwise")
+	}
+
+	if hull.First() == nil || !hull.First().Equals(hull.Last()) {
+		t.Errorf("pointset, concaveHull should be closed")
+	}
+
+	for i := range ps {
+		if !hull.RingContains(&ps[i]) {
+			t.Errorf("pointset, concaveHull should contain %v", ps[i])
+		}
+	}
+
+	if hull.RingContains(NewPoint(5, 8)) {
+		t.Errorf("pointset, concaveHull should not contain the gap")
+	}
+
+	if a := hull.Area(); a >= convex.Area() || math.Abs(a-(100-4*7)) > 5 {
+		t.Errorf("pointset, concaveHull area incorrect, got %f", a)
+	}
+
+	// should not self intersect
+	path := &Path{*hull}
+	for i := 0; i < hull.Length()-1; i++ {
+		for j := i + 2; j < hull.Length()-1; j++ {
+			if i == 0 && j == hull.Length()-2 {
+				continue
+			}
+
+			a := NewLine(path.GetAt(i), path.GetAt(i+1))
+			b := NewLine(path.GetAt(j), path.GetAt(j+1))
+			if a.Intersects(b) {
+				t.Fatalf("pointset, concaveHull self intersects at %d and %d", i, j)
+			}
+		}
+	}
+
+	// degenerate cases
+	if hull := (PointSet{}).ConcaveHull(2); hull.Length() != 0 {
+		t.Errorf("pointset, concaveHull of empty should be empty, got %v", hull)
+	}
+
+	triangle := append(PointSet{}, Point{0, 0}, Point{1, 0}, Point{0, 1})
+	if hull := triangle.ConcaveHull(0); hull.Length() != 4 {
+		t.Errorf("pointset, concaveHull of triangle should be the triangle, got %v", hull)
+	}
+}
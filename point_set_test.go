@@ -371,3 +371,183 @@ func TestPointSetString(t *testing.T) {
 		t.Errorf("pointset, string expected %s, got %s", answer, s)
 	}
 }
+
+func TestPointSetArea(t *testing.T) {
+	ccw := append(PointSet{}, Point{0, 0}, Point{4, 0}, Point{4, 3}, Point{0, 3})
+	if a := ccw.SignedArea(); a != 12 {
+		t.Errorf("pointset, signedArea expected 12, got %f", a)
+	}
+
+	cw := append(PointSet{}, Point{0, 0}, Point{0, 3}, Point{4, 3}, Point{4, 0}, Point{0, 0})
+	if a := cw.SignedArea(); a != -12 {
+		t.Errorf("pointset, signedArea expected -12, got %f", a)
+	}
+
+	if a := cw.Area(); a != 12 {
+		t.Errorf("pointset, area expected 12, got %f", a)
+	}
+
+	if a := append(PointSet{}, Point{0, 0}, Point{1, 1}).Area(); a != 0 {
+		t.Errorf("pointset, area of two points should be 0, got %f", a)
+	}
+}
+
+func TestPointSetGeoArea(t *testing.T) {
+	b := NewBound(-1, 1, 10, 12)
+	ring := NewPolygonFromBound(b).Outer()
+
+	if a, e := ring.GeoArea(), b.GeoArea(); math.Abs(a-e) > 1e-3 {
+		t.Errorf("pointset, geoArea expected %f, got %f", e, a)
+	}
+
+	if a, e := ring.GeodesicArea(), b.GeodesicArea(); math.Abs(a-e) > 1e-3 {
+		t.Errorf("pointset, geodesicArea expected %f, got %f", e, a)
+	}
+
+	// should be the same with the other orientation and without closing
+	ring.Reverse()
+	ring.Pop()
+	if a, e := ring.GeoArea(), b.GeoArea(); math.Abs(a-e) > 1e-3 {
+		t.Errorf("pointset, geoArea expected %f, got %f", e, a)
+	}
+
+	// across the antimeridian
+	wrapped := append(PointSet{}, Point{179, 10}, Point{-179, 10}, Point{-179, 12}, Point{179, 12})
+	if a, e := wrapped.GeoArea(), b.GeoArea(); math.Abs(a-e) > 1e-3 {
+		t.Errorf("pointset, geoArea across antimeridian expected %f, got %f", e, a)
+	}
+
+	// the ellipsoidal area should be within about half a percent of the spherical
+	triangle := append(PointSet{}, Point{-122.4, 37.8}, Point{-118.2, 34.1}, Point{-115.1, 36.2})
+	if g, s := triangle.GeodesicArea(), triangle.GeoArea(); math.Abs(g-s)/g > 0.005 {
+		t.Errorf("pointset, geodesicArea not close to geoArea, %f != %f", g, s)
+	}
+
+	if a := (PointSet{}).GeoArea(); a != 0 {
+		t.Errorf("pointset, geoArea of empty should be 0, got %f", a)
+	}
+}
+
+func TestPointSetOrientation(t *testing.T) {
+	ps := append(PointSet{}, Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 0})
+	if !ps.IsCounterClockwise() || ps.IsClockwise() {
+		t.Errorf("pointset, should be counter-clockwise")
+	}
+
+	ps.ForceCounterClockwise()
+	if !ps.IsCounterClockwise() {
+		t.Errorf("pointset, should still be counter-clockwise")
+	}
+
+	ps.ForceClockwise()
+	if !ps.IsClockwise() || ps.IsCounterClockwise() {
+		t.Errorf("pointset, should be clockwise")
+	}
+
+	expected := append(PointSet{}, Point{0, 0}, Point{1, 1}, Point{1, 0}, Point{0, 0})
+	if !ps.Equals(&expected) {
+		t.Errorf("pointset, forceClockwise incorrect, got %v", ps)
+	}
+
+	ps.ForceCounterClockwise()
+	if !ps.IsCounterClockwise() {
+		t.Errorf("pointset, should be counter-clockwise")
+	}
+
+	// no area
+	line := append(PointSet{}, Point{0, 0}, Point{1, 1})
+	if line.IsClockwise() || line.IsCounterClockwise() {
+		t.Errorf("pointset, line should have no orientation")
+	}
+
+	line.ForceClockwise()
+	if line[0] != (Point{0, 0}) {
+		t.Errorf("pointset, forceClockwise should not change line, got %v", line)
+	}
+}
+
+func TestPointSetReverse(t *testing.T) {
+	ps := append(PointSet{}, Point{1, 1}, Point{2, 2}, Point{3, 3})
+	ps.Reverse()
+
+	expected := append(PointSet{}, Point{3, 3}, Point{2, 2}, Point{1, 1})
+	if !ps.Equals(&expected) {
+		t.Errorf("pointset, reverse incorrect, got %v", ps)
+	}
+
+	empty := PointSet{}
+	if empty.Reverse().Length() != 0 {
+		t.Errorf("pointset, reverse of empty should be empty")
+	}
+}
+
+func TestPointSetWindingNumber(t *testing.T) {
+	ccw := append(PointSet{}, Point{0, 0}, Point{2, 0}, Point{2, 2}, Point{0, 2})
+	if wn := ccw.WindingNumber(NewPoint(1, 1)); wn != 1 {
+		t.Errorf("pointset, windingNumber expected 1, got %d", wn)
+	}
+
+	if wn := ccw.WindingNumber(NewPoint(3, 1)); wn != 0 {
+		t.Errorf("pointset, windingNumber expected 0, got %d", wn)
+	}
+
+	cw := ccw.Clone().Reverse()
+	if wn := cw.WindingNumber(NewPoint(1, 1)); wn != -1 {
+		t.Errorf("pointset, windingNumber expected -1, got %d", wn)
+	}
+
+	// loops twice around the center
+	double := append(PointSet{}, ccw...)
+	double = append(double, ccw...)
+	if wn := double.WindingNumber(NewPoint(1, 1)); wn != 2 {
+		t.Errorf("pointset, windingNumber expected 2, got %d", wn)
+	}
+}
+
+func TestPointSetRingContains(t *testing.T) {
+	// closed, concave ring
+	ring := append(PointSet{}, Point{0, 0}, Point{4, 0}, Point{4, 4}, Point{2, 2}, Point{0, 4}, Point{0, 0})
+
+	type testData struct {
+		point    *Point
+		contains bool
+		boundary bool
+	}
+
+	tests := []testData{
+		{NewPoint(1, 1), true, false},
+		{NewPoint(2, 3), false, false},
+		{NewPoint(5, 1), false, false},
+		{NewPoint(0, 0), true, true},
+		{NewPoint(2, 0), true, true},
+		{NewPoint(0, 2), true, true},    // closing edge
+		{NewPoint(3, 3), true, true},    // diagonal edge
+		{NewPoint(2, 2), true, true},    // concave vertex
+		{NewPoint(-1, 0), false, false}, // in line with an edge
+	}
+
+	for i, test := range tests {
+		if v := ring.RingContains(test.point); v != test.contains {
+			t.Errorf("test %d, ringContains expected %v, got %v", i, test.contains, v)
+		}
+
+		if v := ring.OnRingBoundary(test.point); v != test.boundary {
+			t.Errorf("test %d, onRingBoundary expected %v, got %v", i, test.boundary, v)
+		}
+
+		// should be the same without the closing point and for paths
+		open := ring[:len(ring)-1]
+		if v := open.RingContains(test.point); v != test.contains {
+			t.Errorf("test %d, open ringContains expected %v, got %v", i, test.contains, v)
+		}
+
+		path := &Path{ring}
+		if v := path.RingContains(test.point); v != test.contains {
+			t.Errorf("test %d, path ringContains expected %v, got %v", i, test.contains, v)
+		}
+	}
+
+	if (PointSet{}).RingContains(NewPoint(0, 0)) {
+		t.Errorf("pointset, empty should not contain anything")
+	}
+}
@@ -237,6 +237,47 @@ func TestPointNormalize(t *testing.T) {
 	}
 }
 
+func TestPointNormalizeLng(t *testing.T) {
+	cases := []struct {
+		lng, expected float64
+	}{
+		{0, 0},
+		{180, 180},
+		{-180, -180},
+		{190, -170},
+		{-190, 170},
+		{540, -180},
+		{725, 5},
+		{-725, -5},
+	}
+
+	for _, tc := range cases {
+		if p := NewPoint(tc.lng, 10).NormalizeLng(); !p.Equals(NewPoint(tc.expected, 10)) {
+			t.Errorf("point, normalizeLng %v expected %v, got %v", tc.lng, tc.expected, p)
+		}
+	}
+}
+
+func TestPointNormalizeLatLng(t *testing.T) {
+	cases := []struct {
+		point, expected *Point
+	}{
+		{NewPoint(10, 45), NewPoint(10, 45)},
+		{NewPoint(10, 90), NewPoint(10, 90)},
+		{NewPoint(10, -90), NewPoint(10, -90)},
+		{NewPoint(10, 95), NewPoint(-170, 85)},
+		{NewPoint(10, -95), NewPoint(-170, -85)},
+		{NewPoint(-170, 180), NewPoint(10, 0)},
+		{NewPoint(190, 365), NewPoint(-170, 5)},
+	}
+
+	for _, tc := range cases {
+		if p := tc.point.Clone().NormalizeLatLng(); !p.Equals(tc.expected) {
+			t.Errorf("point, normalizeLatLng %v expected %v, got %v", tc.point, tc.expected, p)
+		}
+	}
+}
+
 func TestPointScale(t *testing.T) {
 	var p, answer *Point
 
@@ -483,3 +524,31 @@ func TestPointRoundDefault(t *testing.T) {
 		t.Errorf("point, round expected %v == %v", res, expected)
 	}
 }
+
+func TestPointGeoDestination(t *testing.T) {
+	p1 := NewPoint(-1.8444, 53.1506)
+	p2 := NewPoint(0.1406, 52.2047)
+
+	p := p1.GeoDestination(p1.BearingTo(p2), p1.GeoDistanceFrom(p2, true))
+	if math.Abs(p.Lat()-p2.Lat()) > epsilon || math.Abs(p.Lng()-p2.Lng()) > epsilon {
+		t.Errorf("point, geoDestination expected %v, got %v", p2, p)
+	}
+
+	// one degree along the equator
+	p = NewPoint(0, 0).GeoDestination(90, EarthRadius*math.Pi/180)
+	if math.Abs(p.Lng()-1) > epsilon || math.Abs(p.Lat()) > epsilon {
+		t.Errorf("point, geoDestination expected [1, 0], got %v", p)
+	}
+
+	// across the antimeridian
+	p = NewPoint(179.5, 0).GeoDestination(90, EarthRadius*math.Pi/180)
+	if math.Abs(p.Lng()+179.5) > epsilon || math.Abs(p.Lat()) > epsilon {
+		t.Errorf("point, geoDestination expected [-179.5, 0], got %v", p)
+	}
+
+	// zero distance
+	p = p1.GeoDestination(45, 0)
+	if math.Abs(p.Lat()-p1.Lat()) > epsilon || math.Abs(p.Lng()-p1.Lng()) > epsilon {
+		t.Errorf("point, geoDestination expected %v, got %v", p1, p)
+	}
+}
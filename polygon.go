@@ -0,0 +1,653 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/paulmach/go.geojson"
+)
+
+// A Polygon is a set of closed rings. The first ring is the outer boundary
+// and any other rings are holes inside of it. Rings may be stored
+// with or without the first point repeated at the end.
+type Polygon []PointSet
+
+// A MultiPolygon is a set of polygons.
+type MultiPolygon []Polygon
+
+// NewPolygon creates a new polygon with the given outer ring and holes.
+// The rings are copied.
+func NewPolygon(outer *PointSet, holes ...*PointSet) *Polygon {
+	p := make(Polygon, 0, 1+len(holes))
+	p = append(p, *outer.Clone())
+	for _, h := range holes {
+		p = append(p, *h.Clone())
+	}
+
+	return &p
+}
+
+// NewPolygonFromBound creates a new polygon with the four corners
+// of the bound as the outer ring.
+func NewPolygonFromBound(b *Bound) *Polygon {
+	return &Polygon{
+		PointSet{
+			*b.SouthWest(),
+			*b.NorthWest(),
+			*b.NorthEast(),
+			*b.SouthEast(),
+			*b.SouthWest(),
+		},
+	}
+}
+
+// NewMultiPolygon creates a new multi polygon from copies of the given polygons.
+func NewMultiPolygon(polygons ...*Polygon) *MultiPolygon {
+	mp := make(MultiPolygon, 0, len(polygons))
+	for _, p := range polygons {
+		mp = append(mp, *p.Clone())
+	}
+
+	return &mp
+}
+
+// Outer returns the outer ring of the polygon.
+// Will return nil if the polygon has no rings.
+func (p Polygon) Outer() *PointSet {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return &p[0]
+}
+
+// Holes returns the inner rings of the polygon.
+func (p Polygon) Holes() []PointSet {
+	if len(p) < 2 {
+		return nil
+	}
+
+	return p[1:]
+}
+
+// Transform applies a given projection or inverse projection to all
+// the points in all the rings of the polygon.
+func (p *Polygon) Transform(projector Projector) *Polygon {
+	for _, ring := range *p {
+		for i := range ring {
+			projector(&ring[i])
+		}
+	}
+
+	return p
+}
+
+// Area returns the area of the outer ring minus the area of the holes.
+// Uses standard Euclidean geometry, so the result is in the units
+// of the points squared.
+func (p Polygon) Area() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+
+	area := math.Abs(ringSignedArea(p[0]))
+	for _, h := range p[1:] {
+		area -= math.Abs(ringSignedArea(h))
+	}
+
+	return area
+}
+
+// GeoArea returns the area of the outer ring minus the area of the holes
+// in square meters on a sphere. Only applies if the data is Lng/Lat degrees.
+func (p Polygon) GeoArea() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+
+	area := p[0].GeoArea()
+	for _, h := range p[1:] {
+		area -= h.GeoArea()
+	}
+
+	return area
+}
+
+// GeodesicArea returns the area of the outer ring minus the area of the holes
+// in square meters on the WGS84 ellipsoid. Only applies if the data is Lng/Lat degrees.
+func (p Polygon) GeodesicArea() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+
+	area := p[0].GeodesicArea()
+	for _, h := range p[1:] {
+		area -= h.GeodesicArea()
+	}
+
+	return area
+}
+
+// Centroid returns the area weighted centroid of the polygon,
+// taking the holes into account. Falls back to the average of the outer
+// ring points if the polygon has zero area.
+func (p Polygon) Centroid() *Point {
+	if len(p) == 0 {
+		return nil
+	}
+
+	x, y, area := p.centroidSums()
+	if area == 0 {
+		return p[0].Centroid()
+	}
+
+	return &Point{x / area, y / area}
+}
+
+// centroidSums returns the area weighted x, y sums and the total area.
+// Holes contribute negatively.
+func (p Polygon) centroidSums() (float64, float64, float64) {
+	var x, y, area float64
+	for i, ring := range p {
+		c, a := ringCentroid(ring)
+		a = math.Abs(a)
+		if i != 0 {
+			a = -a
+		}
+
+		x += a * c[0]
+		y += a * c[1]
+		area += a
+	}
+
+	return x, y, area
+}
+
+// Perimeter returns the total length of all the rings of the polygon,
+// including the closing segments. Uses standard Euclidean geometry.
+func (p Polygon) Perimeter() float64 {
+	sum := 0.0
+	for _, ring := range p {
+		for i := range ring {
+			sum += ring[i].DistanceFrom(&ring[(i+1)%len(ring)])
+		}
+	}
+
+	return sum
+}
+
+// GeoPerimeter returns the total length of all the rings of the polygon
+// in meters. Only applies if the data is Lng/Lat degrees.
+func (p Polygon) GeoPerimeter(haversine ...bool) float64 {
+	yesgeo := yesHaversine(haversine)
+
+	sum := 0.0
+	for _, ring := range p {
+		for i := range ring {
+			sum += ring[i].GeoDistanceFrom(&ring[(i+1)%len(ring)], yesgeo)
+		}
+	}
+
+	return sum
+}
+
+// Bound returns a bound around the outer ring of the polygon.
+func (p Polygon) Bound() *Bound {
+	if len(p) == 0 {
+		return NewBound(0, 0, 0, 0)
+	}
+
+	return p[0].Bound()
+}
+
+// Contains determines if the point is inside the outer ring
+// and not inside any of the holes. Points on the boundary,
+// including the boundary of a hole, are considered within.
+func (p Polygon) Contains(point *Point) bool {
+	if len(p) == 0 || !p[0].RingContains(point) {
+		return false
+	}
+
+	for _, h := range p[1:] {
+		if h.WindingNumber(point) != 0 && !h.OnRingBoundary(point) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContainsPath determines if all of the path is within the polygon.
+// Paths touching or running along the boundary are considered within.
+func (p Polygon) ContainsPath(path *Path) bool {
+	return containsPath(p, p.Contains, path)
+}
+
+// IntersectsPath determines if any part of the path is within the polygon,
+// including on its boundary.
+func (p Polygon) IntersectsPath(path *Path) bool {
+	for i := range path.PointSet {
+		if p.Contains(&path.PointSet[i]) {
+			return true
+		}
+	}
+
+	for _, ring := range p {
+		if ringIntersectsPath(ring, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Equals compares two polygons. Returns true if they have
+// the same number of rings and all the rings are Equal.
+func (p Polygon) Equals(polygon *Polygon) bool {
+	if len(p) != len(*polygon) {
+		return false
+	}
+
+	for i := range p {
+		if !p[i].Equals(&(*polygon)[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the polygon.
+func (p Polygon) Clone() *Polygon {
+	np := make(Polygon, len(p))
+	for i := range p {
+		np[i] = *p[i].Clone()
+	}
+
+	return &np
+}
+
+// ToGeoJSON creates a new geojson feature with a polygon geometry.
+func (p Polygon) ToGeoJSON() *geojson.Feature {
+	return geojson.NewPolygonFeature(p.geoJSONCoordinates())
+}
+
+func (p Polygon) geoJSONCoordinates() [][][]float64 {
+	coords := make([][][]float64, 0, len(p))
+	for _, ring := range p {
+		r := make([][]float64, 0, len(ring))
+		for _, v := range ring {
+			r = append(r, []float64{v[0], v[1]})
+		}
+		coords = append(coords, r)
+	}
+
+	return coords
+}
+
+// ToWKT returns the polygon in WKT format,
+// eg. POLYGON((30 10,40 40,20 40,10 20,30 10))
+// For empty polygons the result will be 'EMPTY'.
+func (p Polygon) ToWKT() string {
+	return p.String()
+}
+
+// String returns a string representation of the polygon.
+// The format is WKT, e.g. POLYGON((30 10,40 40,20 40,10 20,30 10))
+// For empty polygons the result will be 'EMPTY'.
+func (p Polygon) String() string {
+	if len(p) == 0 {
+		return "EMPTY"
+	}
+
+	buff := bytes.NewBuffer(nil)
+	buff.WriteString("POLYGON")
+	p.writeWKTRings(buff)
+
+	return buff.String()
+}
+
+func (p Polygon) writeWKTRings(buff *bytes.Buffer) {
+	buff.WriteString("(")
+	for i, ring := range p {
+		if i != 0 {
+			buff.WriteString(",")
+		}
+
+		buff.WriteString("(")
+		for j, v := range ring {
+			if j != 0 {
+				buff.WriteString(",")
+			}
+			fmt.Fprintf(buff, "%g %g", v[0], v[1])
+		}
+		buff.WriteString(")")
+	}
+	buff.WriteString(")")
+}
+
+// Transform applies a given projection or inverse projection to all
+// the points in all the polygons.
+func (mp *MultiPolygon) Transform(projector Projector) *MultiPolygon {
+	for i := range *mp {
+		(&(*mp)[i]).Transform(projector)
+	}
+
+	return mp
+}
+
+// Area returns the sum of the areas of the polygons.
+func (mp MultiPolygon) Area() float64 {
+	sum := 0.0
+	for _, p := range mp {
+		sum += p.Area()
+	}
+
+	return sum
+}
+
+// GeoArea returns the sum of the areas of the polygons in square meters on a sphere.
+func (mp MultiPolygon) GeoArea() float64 {
+	sum := 0.0
+	for _, p := range mp {
+		sum += p.GeoArea()
+	}
+
+	return sum
+}
+
+// GeodesicArea returns the sum of the areas of the polygons
+// in square meters on the WGS84 ellipsoid.
+func (mp MultiPolygon) GeodesicArea() float64 {
+	sum := 0.0
+	for _, p := range mp {
+		sum += p.GeodesicArea()
+	}
+
+	return sum
+}
+
+// Centroid returns the area weighted centroid of all the polygons.
+// Falls back to the average of all the outer ring points if
+// the multi polygon has zero area.
+func (mp MultiPolygon) Centroid() *Point {
+	if len(mp) == 0 {
+		return nil
+	}
+
+	var x, y, area float64
+	for _, p := range mp {
+		px, py, pa := p.centroidSums()
+		x += px
+		y += py
+		area += pa
+	}
+
+	if area == 0 {
+		var outers PointSet
+		for _, p := range mp {
+			if len(p) != 0 {
+				outers = append(outers, p[0]...)
+			}
+		}
+
+		return outers.Centroid()
+	}
+
+	return &Point{x / area, y / area}
+}
+
+// Perimeter returns the sum of the perimeters of the polygons.
+func (mp MultiPolygon) Perimeter() float64 {
+	sum := 0.0
+	for _, p := range mp {
+		sum += p.Perimeter()
+	}
+
+	return sum
+}
+
+// GeoPerimeter returns the sum of the perimeters of the polygons in meters.
+// Only applies if the data is Lng/Lat degrees.
+func (mp MultiPolygon) GeoPerimeter(haversine ...bool) float64 {
+	sum := 0.0
+	for _, p := range mp {
+		sum += p.GeoPerimeter(haversine...)
+	}
+
+	return sum
+}
+
+// Bound returns a bound around all the polygons.
+func (mp MultiPolygon) Bound() *Bound {
+	if len(mp) == 0 {
+		return NewBound(0, 0, 0, 0)
+	}
+
+	b := mp[0].Bound()
+	for _, p := range mp[1:] {
+		b.Union(p.Bound())
+	}
+
+	return b
+}
+
+// Contains determines if the point is within any of the polygons.
+func (mp MultiPolygon) Contains(point *Point) bool {
+	for _, p := range mp {
+		if p.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainsPath determines if all of the path is within the multi polygon.
+// The path may go from one polygon to another if they share a boundary.
+func (mp MultiPolygon) ContainsPath(path *Path) bool {
+	var rings []PointSet
+	for _, p := range mp {
+		rings = append(rings, p...)
+	}
+
+	return containsPath(rings, mp.Contains, path)
+}
+
+// IntersectsPath determines if any part of the path is within any of the polygons.
+func (mp MultiPolygon) IntersectsPath(path *Path) bool {
+	for _, p := range mp {
+		if p.IntersectsPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Equals compares two multi polygons. Returns true if they have
+// the same number of polygons and all the polygons are Equal.
+func (mp MultiPolygon) Equals(multiPolygon *MultiPolygon) bool {
+	if len(mp) != len(*multiPolygon) {
+		return false
+	}
+
+	for i := range mp {
+		if !mp[i].Equals(&(*multiPolygon)[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the multi polygon.
+func (mp MultiPolygon) Clone() *MultiPolygon {
+	nmp := make(MultiPolygon, len(mp))
+	for i := range mp {
+		nmp[i] = *mp[i].Clone()
+	}
+
+	return &nmp
+}
+
+// ToGeoJSON creates a new geojson feature with a multipolygon geometry.
+func (mp MultiPolygon) ToGeoJSON() *geojson.Feature {
+	coords := make([][][][]float64, 0, len(mp))
+	for _, p := range mp {
+		coords = append(coords, p.geoJSONCoordinates())
+	}
+
+	return geojson.NewMultiPolygonFeature(coords...)
+}
+
+// ToWKT returns the multi polygon in WKT format,
+// eg. MULTIPOLYGON(((30 20,45 40,10 40,30 20)),((15 5,40 10,10 20,5 10,15 5)))
+// For empty multi polygons the result will be 'EMPTY'.
+func (mp MultiPolygon) ToWKT() string {
+	return mp.String()
+}
+
+// String returns a string representation of the multi polygon.
+// The format is WKT, e.g. MULTIPOLYGON(((30 20,45 40,10 40,30 20)),((15 5,40 10,10 20,5 10,15 5)))
+// For empty multi polygons the result will be 'EMPTY'.
+func (mp MultiPolygon) String() string {
+	if len(mp) == 0 {
+		return "EMPTY"
+	}
+
+	buff := bytes.NewBuffer(nil)
+	buff.WriteString("MULTIPOLYGON(")
+	for i, p := range mp {
+		if i != 0 {
+			buff.WriteString(",")
+		}
+		p.writeWKTRings(buff)
+	}
+	buff.WriteString(")")
+
+	return buff.String()
+}
+
+// ringSignedArea computes the shoelace area of the ring. The closing
+// segment is included whether or not the ring repeats its first point.
+// Positive for counter-clockwise rings.
+func ringSignedArea(ring PointSet) float64 {
+	sum := 0.0
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		sum += ring[i][0]*ring[j][1] - ring[j][0]*ring[i][1]
+	}
+
+	return sum / 2
+}
+
+// ringCentroid returns the centroid of the area enclosed
+// by the ring along with the signed area of the ring.
+func ringCentroid(ring PointSet) (*Point, float64) {
+	if len(ring) == 0 {
+		return &Point{}, 0
+	}
+
+	// translate to the first point to reduce round off
+	origin := ring[0]
+
+	var x, y, area float64
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		ax, ay := ring[i][0]-origin[0], ring[i][1]-origin[1]
+		bx, by := ring[j][0]-origin[0], ring[j][1]-origin[1]
+
+		f := ax*by - bx*ay
+		x += (ax + bx) * f
+		y += (ay + by) * f
+		area += f
+	}
+
+	if area == 0 {
+		return ring.Centroid(), 0
+	}
+
+	area /= 2
+	return &Point{origin[0] + x/(6*area), origin[1] + y/(6*area)}, area
+}
+
+// ringPath returns the ring as a path with the first point
+// repeated at the end if it is not already closed.
+func ringPath(ring PointSet) *Path {
+	path := &Path{ring}
+	if len(ring) > 1 && ring[0] != ring[len(ring)-1] {
+		path = &Path{append(ring[:len(ring):len(ring)], ring[0])}
+	}
+
+	return path
+}
+
+// ringIntersectsPath checks if any of the ring edges, including
+// the closing edge, intersect the path.
+func ringIntersectsPath(ring PointSet, path *Path) bool {
+	rp := ringPath(ring)
+	for i := 0; i < len(rp.PointSet)-1; i++ {
+		if path.IntersectsLine(NewLine(&rp.PointSet[i], &rp.PointSet[i+1])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsPath checks that every point of the path is contained and then
+// splits every segment where it meets the rings. Each piece must be
+// completely inside or outside, so checking the midpoints is enough.
+func containsPath(rings []PointSet, contains func(*Point) bool, path *Path) bool {
+	if len(path.PointSet) == 0 {
+		return false
+	}
+
+	for i := range path.PointSet {
+		if !contains(&path.PointSet[i]) {
+			return false
+		}
+	}
+
+	for i := 0; i < len(path.PointSet)-1; i++ {
+		segment := NewLine(&path.PointSet[i], &path.PointSet[i+1])
+		splits := []float64{0, 1}
+
+		for _, ring := range rings {
+			rp := ringPath(ring)
+			if !rp.IntersectsLine(segment) {
+				continue
+			}
+
+			points, indexes := rp.IntersectionLine(segment)
+			for j, point := range points {
+				if point != InfinityPoint {
+					splits = append(splits, segment.Project(point))
+					continue
+				}
+
+				// collinear, split at the ends of the overlapping ring edge
+				k := indexes[j][0]
+				splits = append(splits,
+					segment.Project(&rp.PointSet[k]),
+					segment.Project(&rp.PointSet[k+1]))
+			}
+		}
+
+		sort.Float64s(splits)
+		for j := 0; j < len(splits)-1; j++ {
+			if splits[j] < 0 || splits[j+1] > 1 || splits[j+1]-splits[j] < epsilon {
+				continue
+			}
+
+			if !contains(segment.Interpolate((splits[j] + splits[j+1]) / 2)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
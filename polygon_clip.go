@@ -0,0 +1,864 @@
+package geo
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Union returns the area covered by either of the polygons.
+// Uses the Martinez-Rueda-Feito clipping algorithm, see MultiPolygon.Union.
+func (p Polygon) Union(polygon *Polygon) *MultiPolygon {
+	return clip(MultiPolygon{p}, MultiPolygon{*polygon}, clipUnion)
+}
+
+// Intersection returns the area covered by both of the polygons.
+// Uses the Martinez-Rueda-Feito clipping algorithm, see MultiPolygon.Union.
+func (p Polygon) Intersection(polygon *Polygon) *MultiPolygon {
+	return clip(MultiPolygon{p}, MultiPolygon{*polygon}, clipIntersection)
+}
+
+// Difference returns the area of this polygon not covered by the given polygon.
+// Uses the Martinez-Rueda-Feito clipping algorithm, see MultiPolygon.Union.
+func (p Polygon) Difference(polygon *Polygon) *MultiPolygon {
+	return clip(MultiPolygon{p}, MultiPolygon{*polygon}, clipDifference)
+}
+
+// Xor returns the area covered by exactly one of the polygons, the symmetric difference.
+// Uses the Martinez-Rueda-Feito clipping algorithm, see MultiPolygon.Union.
+func (p Polygon) Xor(polygon *Polygon) *MultiPolygon {
+	return clip(MultiPolygon{p}, MultiPolygon{*polygon}, clipXor)
+}
+
+// Union returns the area covered by either of the multi polygons.
+// The boolean operations use the Martinez-Rueda-Feito plane sweep algorithm,
+// "A new algorithm for computing Boolean operations on polygons" (2009),
+// and support holes, overlapping edges and rings touching at a point. The polygons
+// within each multi polygon are expected to not overlap each other.
+// The result is a new multi polygon where the outer rings are counter-clockwise,
+// the holes are clockwise and all the rings are closed. Uses standard Euclidean geometry.
+func (mp MultiPolygon) Union(multiPolygon *MultiPolygon) *MultiPolygon {
+	return clip(mp, *multiPolygon, clipUnion)
+}
+
+// Intersection returns the area covered by both of the multi polygons.
+// See Union for more information about the result.
+func (mp MultiPolygon) Intersection(multiPolygon *MultiPolygon) *MultiPolygon {
+	return clip(mp, *multiPolygon, clipIntersection)
+}
+
+// Difference returns the area of this multi polygon not covered by the given one.
+// See Union for more information about the result.
+func (mp MultiPolygon) Difference(multiPolygon *MultiPolygon) *MultiPolygon {
+	return clip(mp, *multiPolygon, clipDifference)
+}
+
+// Xor returns the area covered by exactly one of the multi polygons, the symmetric difference.
+// See Union for more information about the result.
+func (mp MultiPolygon) Xor(multiPolygon *MultiPolygon) *MultiPolygon {
+	return clip(mp, *multiPolygon, clipXor)
+}
+
+// clipSnapTolerance is the relative distance within which an
+// intersection point is snapped to the endpoint of an edge.
+// It is relative to the length of the edges and the size of the coordinates.
+const clipSnapTolerance = 1e-12
+
+// clipMaxRequeues is the number of times an event can be processed again after
+// its edge is split. Valid polygons need it rarely, if ever, while invalid
+// ones can keep splitting edges without end.
+const clipMaxRequeues = 4
+
+type clipOperation int
+
+const (
+	clipIntersection clipOperation = iota
+	clipUnion
+	clipDifference
+	clipXor
+)
+
+type clipEdgeType int
+
+const (
+	clipEdgeNormal clipEdgeType = iota
+	clipEdgeNonContributing
+	clipEdgeSameTransition
+	clipEdgeDifferentTransition
+)
+
+// A sweepEvent is one of the endpoints of an edge.
+// The left event of the edge is processed before the right event.
+type sweepEvent struct {
+	point     Point
+	left      bool
+	other     *sweepEvent
+	isSubject bool
+	edgeType  clipEdgeType
+	contourID int
+
+	// inOut is true if the edge represents an inside-outside
+	// transition of its polygon going upwards, otherInOut is the
+	// same for the closest edge of the other polygon below this one.
+	inOut      bool
+	otherInOut bool
+
+	// resultTransition is 1 if the area above the edge is in the
+	// result, -1 if it is below and 0 if the edge is not in the result.
+	resultTransition int
+
+	// requeued counts the times the event was processed again after
+	// its edge was split, see clipSubdivide.
+	requeued int
+}
+
+func (e *sweepEvent) inResult() bool {
+	return e.resultTransition != 0
+}
+
+// isBelow returns true if the edge is below the point.
+func (e *sweepEvent) isBelow(p Point) bool {
+	if e.left {
+		return clipSignedArea(e.point, e.other.point, p) > 0
+	}
+
+	return clipSignedArea(e.other.point, e.point, p) > 0
+}
+
+func (e *sweepEvent) isVertical() bool {
+	return e.point[0] == e.other.point[0]
+}
+
+func clipSignedArea(p0, p1, p2 Point) float64 {
+	return (p0[0]-p2[0])*(p1[1]-p2[1]) - (p1[0]-p2[0])*(p0[1]-p2[1])
+}
+
+// compareEvents returns 1 if e1 should be processed after e2, -1 otherwise.
+func compareEvents(e1, e2 *sweepEvent) int {
+	p1, p2 := e1.point, e2.point
+
+	if p1[0] != p2[0] {
+		if p1[0] > p2[0] {
+			return 1
+		}
+		return -1
+	}
+
+	if p1[1] != p2[1] {
+		if p1[1] > p2[1] {
+			return 1
+		}
+		return -1
+	}
+
+	// same point, right endpoints first
+	if e1.left != e2.left {
+		if e1.left {
+			return 1
+		}
+		return -1
+	}
+
+	// not collinear, the event of the bottom edge first
+	if clipSignedArea(p1, e1.other.point, e2.other.point) != 0 {
+		if !e1.isBelow(e2.other.point) {
+			return 1
+		}
+		return -1
+	}
+
+	if !e1.isSubject && e2.isSubject {
+		return 1
+	}
+
+	return -1
+}
+
+// compareSegments orders the edges in the sweep line from bottom to top.
+func compareSegments(le1, le2 *sweepEvent) int {
+	if le1 == le2 {
+		return 0
+	}
+
+	// not collinear
+	if clipSignedArea(le1.point, le1.other.point, le2.point) != 0 ||
+		clipSignedArea(le1.point, le1.other.point, le2.other.point) != 0 {
+
+		// same left endpoint, use the right endpoint to sort
+		if le1.point == le2.point {
+			if le1.isBelow(le2.other.point) {
+				return -1
+			}
+			return 1
+		}
+
+		// different left endpoints but same x coordinate
+		if le1.point[0] == le2.point[0] {
+			if le1.point[1] < le2.point[1] {
+				return -1
+			}
+			return 1
+		}
+
+		// has the edge associated to le1 been inserted into the sweep line after le2?
+		if compareEvents(le1, le2) == 1 {
+			if !le2.isBelow(le1.point) {
+				return -1
+			}
+			return 1
+		}
+
+		if le1.isBelow(le2.point) {
+			return -1
+		}
+		return 1
+	}
+
+	// collinear
+	if le1.isSubject == le2.isSubject {
+		if le1.point == le2.point {
+			if le1.other.point == le2.other.point {
+				return 0
+			}
+
+			if le1.contourID > le2.contourID {
+				return 1
+			}
+			return -1
+		}
+	} else {
+		if le1.isSubject {
+			return -1
+		}
+		return 1
+	}
+
+	if compareEvents(le1, le2) == 1 {
+		return 1
+	}
+
+	return -1
+}
+
+// eventQueue is a priority queue of the events, ordered by compareEvents.
+type eventQueue []*sweepEvent
+
+func (q eventQueue) Len() int            { return len(q) }
+func (q eventQueue) Less(i, j int) bool  { return compareEvents(q[i], q[j]) < 0 }
+func (q eventQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*sweepEvent)) }
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return e
+}
+
+// sweepLine is the ordered list of edges intersecting the sweep line.
+type sweepLine []*sweepEvent
+
+func (s *sweepLine) insert(e *sweepEvent) int {
+	// find the first edge above the new one
+	lo, hi := 0, len(*s)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if compareSegments(e, (*s)[mid]) < 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	*s = append(*s, nil)
+	copy((*s)[lo+1:], (*s)[lo:])
+	(*s)[lo] = e
+
+	return lo
+}
+
+func (s sweepLine) index(e *sweepEvent) int {
+	for i := range s {
+		if s[i] == e {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *sweepLine) remove(i int) {
+	copy((*s)[i:], (*s)[i+1:])
+	(*s)[len(*s)-1] = nil
+	*s = (*s)[:len(*s)-1]
+}
+
+func clip(subject, clipping MultiPolygon, op clipOperation) *MultiPolygon {
+	q := &eventQueue{}
+	contourID := 0
+
+	sbound := clipFillQueue(q, subject, true, &contourID)
+	cbound := clipFillQueue(q, clipping, false, &contourID)
+
+	// trivial results
+	if sbound == nil || cbound == nil {
+		var result MultiPolygon
+		switch {
+		case op == clipDifference, sbound != nil && op != clipIntersection:
+			result = clipTrivial(subject)
+		case cbound != nil && op != clipIntersection:
+			result = clipTrivial(clipping)
+		}
+		return &result
+	}
+
+	if !sbound.Intersects(cbound) {
+		var result MultiPolygon
+		switch op {
+		case clipDifference:
+			result = clipTrivial(subject)
+		case clipUnion, clipXor:
+			result = append(clipTrivial(subject), clipTrivial(clipping)...)
+		}
+		return &result
+	}
+
+	sorted := clipSubdivide(q, sbound, cbound, op)
+	return clipConnectEdges(sorted)
+}
+
+// clipFillQueue adds the events for all the edges of the multi polygon
+// to the queue and returns its bound, or nil if there are no edges.
+func clipFillQueue(q *eventQueue, mp MultiPolygon, isSubject bool, contourID *int) *Bound {
+	var bound *Bound
+	for _, p := range mp {
+		for j, ring := range p {
+			if j == 0 {
+				*contourID++
+			}
+
+			for k := range ring {
+				s1, s2 := ring[k], ring[(k+1)%len(ring)]
+				if s1 == s2 {
+					continue // skip collapsed edges and the closing edge of closed rings
+				}
+
+				e1 := &sweepEvent{point: s1, isSubject: isSubject, contourID: *contourID}
+				e2 := &sweepEvent{point: s2, isSubject: isSubject, contourID: *contourID, other: e1}
+				e1.other = e2
+
+				if compareEvents(e1, e2) > 0 {
+					e2.left = true
+				} else {
+					e1.left = true
+				}
+
+				if bound == nil {
+					bound = NewBoundFromPoints(&s1, &s1)
+				}
+				bound.Extend(&s1)
+
+				heap.Push(q, e1)
+				heap.Push(q, e2)
+			}
+		}
+	}
+
+	return bound
+}
+
+// clipTrivial returns a copy of the multi polygon with the
+// rings in the same form as the results of the clipping.
+func clipTrivial(mp MultiPolygon) MultiPolygon {
+	result := make(MultiPolygon, 0, len(mp))
+	for _, p := range mp {
+		if len(p) == 0 || len(p[0]) == 0 {
+			continue
+		}
+
+		polygon := make(Polygon, 0, len(p))
+		for j, ring := range p {
+			polygon = append(polygon, clipResultRing(ring, j == 0))
+		}
+		result = append(result, polygon)
+	}
+
+	return result
+}
+
+// clipResultRing copies and closes the ring, forcing the orientation.
+func clipResultRing(ring PointSet, exterior bool) PointSet {
+	r := make(PointSet, len(ring), len(ring)+1)
+	copy(r, ring)
+
+	if len(r) > 0 && r[0] != r[len(r)-1] {
+		r = append(r, r[0])
+	}
+
+	if exterior {
+		r.ForceCounterClockwise()
+	} else {
+		r.ForceClockwise()
+	}
+
+	return r
+}
+
+// clipSubdivide processes the events, splitting the edges at
+// intersections and computing which edges are part of the result.
+func clipSubdivide(q *eventQueue, sbound, cbound *Bound, op clipOperation) []*sweepEvent {
+	var sorted []*sweepEvent
+	var sl sweepLine
+
+	rightBound := math.Min(sbound.East(), cbound.East())
+	for q.Len() > 0 {
+		event := heap.Pop(q).(*sweepEvent)
+		sorted = append(sorted, event)
+
+		// no more results possible
+		if (op == clipIntersection && event.point[0] > rightBound) ||
+			(op == clipDifference && event.point[0] > sbound.East()) {
+			break
+		}
+
+		if event.left {
+			pos := sl.insert(event)
+
+			var prev, next *sweepEvent
+			if pos > 0 {
+				prev = sl[pos-1]
+			}
+
+			if pos < len(sl)-1 {
+				next = sl[pos+1]
+			}
+
+			clipComputeFields(event, prev, op)
+
+			if next != nil && clipPossibleIntersection(event, next, q) == 2 {
+				clipComputeFields(event, prev, op)
+				clipComputeFields(next, event, op)
+			}
+
+			if prev != nil && clipPossibleIntersection(prev, event, q) == 2 {
+				var prevprev *sweepEvent
+				if pos > 1 {
+					prevprev = sl[pos-2]
+				}
+
+				clipComputeFields(prev, prevprev, op)
+				clipComputeFields(event, prev, op)
+			}
+
+			// an edge was split at the left endpoint, so the sweep line order
+			// may be wrong, process the event again after the new events.
+			// Invalid input, such as self intersecting rings, can keep splitting
+			// the edges so the number of times is limited to always finish.
+			if q.Len() > 0 && compareEvents((*q)[0], event) < 0 && event.requeued < clipMaxRequeues {
+				event.requeued++
+				sl.remove(sl.index(event))
+				sorted = sorted[:len(sorted)-1]
+				heap.Push(q, event)
+			}
+		} else {
+			event = event.other
+
+			pos := sl.index(event)
+			if pos < 0 {
+				continue
+			}
+
+			var prev, next *sweepEvent
+			if pos > 0 {
+				prev = sl[pos-1]
+			}
+
+			if pos < len(sl)-1 {
+				next = sl[pos+1]
+			}
+
+			sl.remove(pos)
+
+			if prev != nil && next != nil {
+				clipPossibleIntersection(prev, next, q)
+			}
+		}
+	}
+
+	return sorted
+}
+
+func clipComputeFields(event, prev *sweepEvent, op clipOperation) {
+	if prev == nil {
+		event.inOut = false
+		event.otherInOut = true
+	} else {
+		if event.isSubject == prev.isSubject {
+			event.inOut = !prev.inOut
+			event.otherInOut = prev.otherInOut
+		} else {
+			event.inOut = !prev.otherInOut
+			if prev.isVertical() {
+				event.otherInOut = !prev.inOut
+			} else {
+				event.otherInOut = prev.inOut
+			}
+		}
+	}
+
+	event.resultTransition = 0
+	if clipInResult(event, op) {
+		event.resultTransition = clipResultTransition(event, op)
+	}
+}
+
+func clipInResult(event *sweepEvent, op clipOperation) bool {
+	switch event.edgeType {
+	case clipEdgeNormal:
+		switch op {
+		case clipIntersection:
+			return !event.otherInOut
+		case clipUnion:
+			return event.otherInOut
+		case clipDifference:
+			return (event.isSubject && event.otherInOut) || (!event.isSubject && !event.otherInOut)
+		case clipXor:
+			return true
+		}
+	case clipEdgeSameTransition:
+		return op == clipIntersection || op == clipUnion
+	case clipEdgeDifferentTransition:
+		return op == clipDifference
+	}
+
+	return false
+}
+
+// clipResultTransition returns 1 if the area above the edge is in the result, -1 if not.
+func clipResultTransition(event *sweepEvent, op clipOperation) int {
+	thisIn := !event.inOut
+	thatIn := !event.otherInOut
+
+	// overlapping edges, the other polygon has the same or the opposite transition
+	switch event.edgeType {
+	case clipEdgeSameTransition:
+		thatIn = thisIn
+	case clipEdgeDifferentTransition:
+		thatIn = !thisIn
+	}
+
+	var isIn bool
+	switch op {
+	case clipIntersection:
+		isIn = thisIn && thatIn
+	case clipUnion:
+		isIn = thisIn || thatIn
+	case clipXor:
+		isIn = thisIn != thatIn
+	case clipDifference:
+		if event.isSubject {
+			isIn = thisIn && !thatIn
+		} else {
+			isIn = thatIn && !thisIn
+		}
+	}
+
+	if isIn {
+		return 1
+	}
+
+	return -1
+}
+
+// clipPossibleIntersection splits the edges if they intersect. Returns 0 if there
+// is no intersection to handle, 1 for a single point, 2 if the edges overlap and
+// share the left endpoint and 3 for other overlapping cases.
+func clipPossibleIntersection(se1, se2 *sweepEvent, q *eventQueue) int {
+	points := clipSegmentIntersection(se1.point, se1.other.point, se2.point, se2.other.point)
+
+	switch {
+	case len(points) == 0:
+		return 0
+	case len(points) == 1 && (se1.point == se2.point || se1.other.point == se2.other.point):
+		return 0 // the edges intersect at an endpoint of both
+	case len(points) == 2 && se1.isSubject == se2.isSubject:
+		return 0 // overlapping edges of the same polygon
+	}
+
+	if len(points) == 1 {
+		if se1.point != points[0] && se1.other.point != points[0] {
+			clipDivideSegment(se1, points[0], q)
+		}
+
+		if se2.point != points[0] && se2.other.point != points[0] {
+			clipDivideSegment(se2, points[0], q)
+		}
+
+		return 1
+	}
+
+	// the edges overlap
+	var events []*sweepEvent
+	leftCoincide := false
+	rightCoincide := false
+
+	if se1.point == se2.point {
+		leftCoincide = true
+	} else if compareEvents(se1, se2) == 1 {
+		events = append(events, se2, se1)
+	} else {
+		events = append(events, se1, se2)
+	}
+
+	if se1.other.point == se2.other.point {
+		rightCoincide = true
+	} else if compareEvents(se1.other, se2.other) == 1 {
+		events = append(events, se2.other, se1.other)
+	} else {
+		events = append(events, se1.other, se2.other)
+	}
+
+	if leftCoincide {
+		// both edges are equal or share the left endpoint
+		se2.edgeType = clipEdgeNonContributing
+		if se2.inOut == se1.inOut {
+			se1.edgeType = clipEdgeSameTransition
+		} else {
+			se1.edgeType = clipEdgeDifferentTransition
+		}
+
+		if !rightCoincide {
+			clipDivideSegment(events[1].other, events[0].point, q)
+		}
+
+		return 2
+	}
+
+	// the edges share the right endpoint
+	if rightCoincide {
+		clipDivideSegment(events[0], events[1].point, q)
+		return 3
+	}
+
+	// neither edge completely includes the other
+	if events[0] != events[3].other {
+		clipDivideSegment(events[0], events[1].point, q)
+		clipDivideSegment(events[1], events[2].point, q)
+		return 3
+	}
+
+	// one edge includes the other
+	clipDivideSegment(events[0], events[1].point, q)
+	clipDivideSegment(events[3].other, events[2].point, q)
+
+	return 3
+}
+
+// clipDivideSegment splits the edge of the left event at the point.
+func clipDivideSegment(se *sweepEvent, p Point, q *eventQueue) {
+	r := &sweepEvent{point: p, other: se, isSubject: se.isSubject, contourID: se.contourID}
+	l := &sweepEvent{point: p, left: true, other: se.other, isSubject: se.isSubject, contourID: se.contourID}
+
+	// avoid a rounding error, the left event would be processed after the right event
+	if compareEvents(l, se.other) > 0 {
+		se.other.left = true
+		l.left = false
+	}
+
+	se.other.other = l
+	se.other = r
+
+	heap.Push(q, l)
+	heap.Push(q, r)
+}
+
+// clipSegmentIntersection returns the intersection of the segments a1-a2 and b1-b2,
+// nil if they do not intersect, one point if they cross or two points for the
+// endpoints of the overlap if they are collinear. Endpoints are returned exactly.
+func clipSegmentIntersection(a1, a2, b1, b2 Point) []Point {
+	va := Point{a2[0] - a1[0], a2[1] - a1[1]}
+	vb := Point{b2[0] - b1[0], b2[1] - b1[1]}
+	e := Point{b1[0] - a1[0], b1[1] - a1[1]}
+
+	kross := va[0]*vb[1] - va[1]*vb[0]
+	if kross != 0 {
+		// the tolerance allows for rounding errors in previously computed intersections
+		s := (e[0]*vb[1] - e[1]*vb[0]) / kross
+		if s < -clipSnapTolerance || s > 1+clipSnapTolerance {
+			return nil
+		}
+
+		t := (e[0]*va[1] - e[1]*va[0]) / kross
+		if t < -clipSnapTolerance || t > 1+clipSnapTolerance {
+			return nil
+		}
+
+		switch {
+		case math.Abs(s) <= clipSnapTolerance:
+			return []Point{a1}
+		case math.Abs(s-1) <= clipSnapTolerance:
+			return []Point{a2}
+		case math.Abs(t) <= clipSnapTolerance:
+			return []Point{b1}
+		case math.Abs(t-1) <= clipSnapTolerance:
+			return []Point{b2}
+		}
+
+		// snap to the endpoints to avoid tiny edges
+		p := Point{a1[0] + s*va[0], a1[1] + s*va[1]}
+		for _, end := range [...]Point{a1, a2, b1, b2} {
+			tolerance := clipSnapTolerance * math.Max(1, math.Max(math.Abs(end[0]), math.Abs(end[1])))
+			if math.Abs(p[0]-end[0]) <= tolerance && math.Abs(p[1]-end[1]) <= tolerance {
+				return []Point{end}
+			}
+		}
+
+		return []Point{p}
+	}
+
+	// parallel, but not collinear
+	if e[0]*va[1]-e[1]*va[0] != 0 {
+		return nil
+	}
+
+	// collinear, find the overlap along a
+	sqrLenA := va.Dot(&va)
+	sa := va.Dot(&e) / sqrLenA
+	sb := sa + va.Dot(&vb)/sqrLenA
+
+	pmin, pmax := b1, b2
+	smin, smax := sa, sb
+	if sb < sa {
+		pmin, pmax = b2, b1
+		smin, smax = sb, sa
+	}
+
+	if smin > 1 || smax < 0 {
+		return nil
+	}
+
+	start := a1
+	if smin > 0 {
+		start = pmin
+	}
+
+	end := a2
+	if smax < 1 {
+		end = pmax
+	}
+
+	if start == end {
+		return []Point{start}
+	}
+
+	return []Point{start, end}
+}
+
+// A clipEdge is an edge of the result, directed so the inside is on the left.
+type clipEdge struct {
+	from, to Point
+	used     bool
+}
+
+// clipConnectEdges joins the edges that are in the result into rings.
+// At vertices shared by several rings the edge making the sharpest right turn
+// is followed, so rings touching at a point are kept separate. Counter-clockwise
+// rings are then the outer rings and clockwise rings are the holes.
+func clipConnectEdges(sorted []*sweepEvent) *MultiPolygon {
+	var edges []*clipEdge
+	outgoing := make(map[Point][]*clipEdge)
+
+	for _, e := range sorted {
+		if !e.left || !e.inResult() || e.point == e.other.point {
+			continue
+		}
+
+		// the result is above edges with a positive transition
+		edge := &clipEdge{from: e.point, to: e.other.point}
+		if e.resultTransition < 0 {
+			edge.from, edge.to = edge.to, edge.from
+		}
+
+		edges = append(edges, edge)
+		outgoing[edge.from] = append(outgoing[edge.from], edge)
+	}
+
+	var outers, holes []PointSet
+	for _, start := range edges {
+		if start.used {
+			continue
+		}
+
+		ring := PointSet{start.from}
+		for edge := start; edge != nil; {
+			edge.used = true
+			ring = append(ring, edge.to)
+
+			edge = clipNextEdge(edge, outgoing[edge.to], start)
+			if edge == start {
+				break
+			}
+		}
+
+		if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+			continue
+		}
+
+		switch area := ring.SignedArea(); {
+		case area > 0:
+			outers = append(outers, ring)
+		case area < 0:
+			holes = append(holes, ring)
+		}
+	}
+
+	result := make(MultiPolygon, len(outers))
+	for i := range outers {
+		result[i] = Polygon{outers[i]}
+	}
+
+	// a hole belongs to the smallest outer ring containing it
+	for _, hole := range holes {
+		// the midpoint of an edge is not on any of the other rings
+		mid := Point{(hole[0][0] + hole[1][0]) / 2, (hole[0][1] + hole[1][1]) / 2}
+
+		parent := -1
+		for i := range outers {
+			if outers[i].RingContains(&mid) &&
+				(parent < 0 || outers[i].Area() < outers[parent].Area()) {
+				parent = i
+			}
+		}
+
+		if parent >= 0 {
+			result[parent] = append(result[parent], hole)
+		}
+	}
+
+	return &result
+}
+
+// clipNextEdge returns the unused edge, or the start edge, leaving the vertex
+// that is first clockwise from the direction back along the edge.
+func clipNextEdge(edge *clipEdge, candidates []*clipEdge, start *clipEdge) *clipEdge {
+	back := math.Atan2(edge.from[1]-edge.to[1], edge.from[0]-edge.to[0])
+
+	var next *clipEdge
+	min := math.Inf(1)
+	for _, c := range candidates {
+		if c.used && c != start {
+			continue
+		}
+
+		turn := back - math.Atan2(c.to[1]-c.from[1], c.to[0]-c.from[0])
+		for turn <= 0 {
+			turn += 2 * math.Pi
+		}
+
+		if turn < min {
+			min = turn
+			next = c
+		}
+	}
+
+	return next
+}
@@ -0,0 +1,268 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func clipSquare(west, south, size float64) *Polygon {
+	return NewPolygon(&PointSet{
+		{west, south}, {west + size, south}, {west + size, south + size}, {west, south + size}, {west, south},
+	})
+}
+
+func TestPolygonClipOperations(t *testing.T) {
+	type expected struct {
+		union, intersection, difference, xor float64
+	}
+
+	cases := []struct {
+		name     string
+		subject  *Polygon
+		clipping *Polygon
+		expected expected
+	}{
+		{
+			name:     "overlapping",
+			subject:  clipSquare(0, 0, 2),
+			clipping: clipSquare(1, 1, 2),
+			expected: expected{7, 1, 3, 6},
+		},
+		{
+			name:     "disjoint",
+			subject:  clipSquare(0, 0, 1),
+			clipping: clipSquare(5, 5, 1),
+			expected: expected{2, 0, 1, 2},
+		},
+		{
+			name:     "sharing an edge",
+			subject:  clipSquare(0, 0, 1),
+			clipping: clipSquare(1, 0, 1),
+			expected: expected{2, 0, 1, 2},
+		},
+		{
+			name:     "contained",
+			subject:  clipSquare(0, 0, 4),
+			clipping: clipSquare(1, 1, 1),
+			expected: expected{16, 1, 15, 15},
+		},
+		{
+			name:     "equal",
+			subject:  clipSquare(0, 0, 2),
+			clipping: clipSquare(0, 0, 2),
+			expected: expected{4, 4, 0, 0},
+		},
+		{
+			name:     "with hole",
+			subject:  testPolygon(),
+			clipping: clipSquare(1.5, 1.5, 3),
+			expected: expected{18, 6, 9, 12},
+		},
+		{
+			name:     "clockwise triangle",
+			subject:  NewPolygon(&PointSet{{0, 0}, {0, 2}, {2, 0}}),
+			clipping: clipSquare(0, 0, 1),
+			expected: expected{2, 1, 1, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		results := map[string]struct {
+			mp       *MultiPolygon
+			expected float64
+		}{
+			"union":        {tc.subject.Union(tc.clipping), tc.expected.union},
+			"intersection": {tc.subject.Intersection(tc.clipping), tc.expected.intersection},
+			"difference":   {tc.subject.Difference(tc.clipping), tc.expected.difference},
+			"xor":          {tc.subject.Xor(tc.clipping), tc.expected.xor},
+		}
+
+		for op, r := range results {
+			if a := r.mp.Area(); math.Abs(a-r.expected) > epsilon {
+				t.Errorf("polygon, %s %s expected area %v, got %v", tc.name, op, r.expected, a)
+			}
+
+			checkClipResult(t, tc.name+" "+op, r.mp)
+		}
+	}
+}
+
+func TestPolygonClipResult(t *testing.T) {
+	// the hole is inside the intersection
+	subject := testPolygon()
+	mp := subject.Intersection(clipSquare(0.5, 0.5, 2))
+
+	if l := len(*mp); l != 1 {
+		t.Fatalf("polygon, intersection expected 1 polygon, got %d", l)
+	}
+
+	if l := len((*mp)[0]); l != 2 {
+		t.Errorf("polygon, intersection expected a hole, got %d rings", l)
+	}
+
+	if a := mp.Area(); a != 3 {
+		t.Errorf("polygon, intersection expected area 3, got %v", a)
+	}
+
+	// the difference splits the polygon in two
+	mp = clipSquare(0, 0, 3).Difference(NewPolygon(&PointSet{{1, -1}, {2, -1}, {2, 4}, {1, 4}}))
+	if l := len(*mp); l != 2 {
+		t.Errorf("polygon, difference expected 2 polygons, got %d", l)
+	}
+
+	// union of touching squares becomes a single ring
+	mp = clipSquare(0, 0, 1).Union(clipSquare(1, 0, 1))
+	if l := len(*mp); l != 1 {
+		t.Errorf("polygon, union expected 1 polygon, got %d", l)
+	}
+
+	// union of a ring around a square fills the hole
+	ring := NewPolygon(
+		&PointSet{{0, 0}, {3, 0}, {3, 3}, {0, 3}},
+		&PointSet{{1, 1}, {2, 1}, {2, 2}, {1, 2}},
+	)
+	mp = ring.Union(clipSquare(1, 1, 1))
+	if l := len(*mp); l != 1 || len((*mp)[0]) != 1 {
+		t.Errorf("polygon, union expected 1 polygon without holes, got %v", mp)
+	}
+
+	if a := mp.Area(); a != 9 {
+		t.Errorf("polygon, union expected area 9, got %v", a)
+	}
+
+	// empty polygons
+	mp = clipSquare(0, 0, 1).Union(&Polygon{})
+	if a := mp.Area(); a != 1 {
+		t.Errorf("polygon, union with empty expected area 1, got %v", a)
+	}
+
+	mp = clipSquare(0, 0, 1).Intersection(&Polygon{})
+	if l := len(*mp); l != 0 {
+		t.Errorf("polygon, intersection with empty expected nothing, got %v", mp)
+	}
+
+	mp = (&Polygon{}).Difference(clipSquare(0, 0, 1))
+	if l := len(*mp); l != 0 {
+		t.Errorf("polygon, difference of empty expected nothing, got %v", mp)
+	}
+}
+
+func TestMultiPolygonClipOperations(t *testing.T) {
+	subject := NewMultiPolygon(clipSquare(0, 0, 2), clipSquare(4, 0, 2))
+	clipping := NewMultiPolygon(clipSquare(1, 1, 4))
+
+	if a := subject.Union(clipping).Area(); a != 22 {
+		t.Errorf("multi polygon, union expected area 22, got %v", a)
+	}
+
+	if a := subject.Intersection(clipping).Area(); a != 2 {
+		t.Errorf("multi polygon, intersection expected area 2, got %v", a)
+	}
+
+	if a := subject.Difference(clipping).Area(); a != 6 {
+		t.Errorf("multi polygon, difference expected area 6, got %v", a)
+	}
+
+	if a := subject.Xor(clipping).Area(); a != 20 {
+		t.Errorf("multi polygon, xor expected area 20, got %v", a)
+	}
+}
+
+func TestPolygonClipRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	randomPolygon := func(grid bool) *Polygon {
+		// a star shaped ring is not self intersecting
+		cx, cy := r.Float64()*4, r.Float64()*4
+		n := 3 + r.Intn(12)
+
+		ring := make(PointSet, 0, n)
+		for i := 0; i < n; i++ {
+			angle := 2 * math.Pi * (float64(i) + r.Float64()*0.9) / float64(n)
+			d := 0.5 + 2*r.Float64()
+			ring = append(ring, Point{cx + d*math.Cos(angle), cy + d*math.Sin(angle)})
+		}
+
+		// snapping to a grid creates many shared vertices and overlapping edges,
+		// but may also create invalid rings
+		if grid {
+			for i := range ring {
+				ring[i] = Point{math.Round(ring[i][0]), math.Round(ring[i][1])}
+			}
+
+			if ring.Area() == 0 || ring.ConvexHull().Area() != ring.Area() {
+				return nil
+			}
+		}
+
+		return NewPolygon(&ring)
+	}
+
+	for i := 0; i < 2000; i++ {
+		a, b := randomPolygon(i%2 == 0), randomPolygon(i%2 == 0)
+		if a == nil || b == nil {
+			continue
+		}
+
+		union := a.Union(b).Area()
+		intersection := a.Intersection(b).Area()
+		difference := a.Difference(b).Area()
+		xor := a.Xor(b).Area()
+
+		tolerance := 1e-9 * (a.Area() + b.Area())
+		if d := union - (a.Area() + b.Area() - intersection); math.Abs(d) > tolerance {
+			t.Fatalf("polygon, union area mismatch %v for %v and %v", d, a, b)
+		}
+
+		if d := difference - (a.Area() - intersection); math.Abs(d) > tolerance {
+			t.Fatalf("polygon, difference area mismatch %v for %v and %v", d, a, b)
+		}
+
+		if d := xor - (union - intersection); math.Abs(d) > tolerance {
+			t.Fatalf("polygon, xor area mismatch %v for %v and %v", d, a, b)
+		}
+	}
+}
+
+func TestPolygonClipInvalid(t *testing.T) {
+	// the hole crosses the outer ring
+	a := NewPolygon(&PointSet{{8, 6}, {7, 8}, {3, 8}, {3, 4}, {7, 2}, {8, 6}})
+	b := NewPolygon(
+		&PointSet{{11, 5}, {8, 6}, {7, 7}, {5, 7}, {3, 5}, {6, 4}, {7, 4}, {7, 5}, {11, 5}},
+		&PointSet{{11.1, 5}, {9.9, 5}, {9.9, 4.7}, {9.6, 4.7}, {8.7, 5}, {9.3, 5.6}, {9.9, 5.6}, {10.2, 5.3}, {11.1, 5}},
+	)
+
+	// should finish, the result is undefined
+	a.Intersection(b)
+	a.Difference(b)
+	a.Union(b)
+	a.Xor(b)
+
+	// repeated and overlapping edges
+	a = NewPolygon(&PointSet{{1, 3}, {-1, 2}, {1, 1}})
+	b = NewPolygon(&PointSet{{2, 4}, {2, 5}, {0, 5}, {0, 4}, {0, 5}, {-2, 5}, {-1, 4}, {0, 3}, {0, 3}, {2, 2}, {2, 3}})
+
+	a.Intersection(b)
+	a.Difference(b)
+	a.Union(b)
+	a.Xor(b)
+}
+
+func checkClipResult(t *testing.T, name string, mp *MultiPolygon) {
+	for _, p := range *mp {
+		for i, ring := range p {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				t.Errorf("polygon, %s expected closed rings, got %v", name, ring)
+			}
+
+			if i == 0 && !ring.IsCounterClockwise() {
+				t.Errorf("polygon, %s expected counter-clockwise outer ring, got %v", name, ring)
+			}
+
+			if i != 0 && !ring.IsClockwise() {
+				t.Errorf("polygon, %s expected clockwise holes, got %v", name, ring)
+			}
+		}
+	}
+}
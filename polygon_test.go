@@ -0,0 +1,390 @@
+package geo
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func testPolygon() *Polygon {
+	return NewPolygon(
+		&PointSet{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		&PointSet{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	)
+}
+
+func TestNewPolygon(t *testing.T) {
+	outer := &PointSet{{0, 0}, {1, 0}, {1, 1}}
+	p := NewPolygon(outer)
+
+	outer.SetAt(0, NewPoint(5, 5))
+	if !p.Outer().GetAt(0).Equals(NewPoint(0, 0)) {
+		t.Errorf("should copy the rings, got %v", p)
+	}
+
+	if l := len(p.Holes()); l != 0 {
+		t.Errorf("should have no holes, got %d", l)
+	}
+
+	p = testPolygon()
+	if l := len(p.Holes()); l != 1 {
+		t.Errorf("should have 1 hole, got %d", l)
+	}
+}
+
+func TestNewPolygonFromBound(t *testing.T) {
+	p := NewPolygonFromBound(NewBound(0, 2, 0, 3))
+
+	if a := p.Area(); a != 6 {
+		t.Errorf("incorrect area, got %v", a)
+	}
+
+	if !p.Bound().Equals(NewBound(0, 2, 0, 3)) {
+		t.Errorf("incorrect bound, got %v", p.Bound())
+	}
+}
+
+func TestPolygonArea(t *testing.T) {
+	p := testPolygon()
+	if a := p.Area(); a != 15 {
+		t.Errorf("incorrect area, got %v", a)
+	}
+
+	// open ring, reversed orientation
+	p = NewPolygon(&PointSet{{0, 0}, {0, 2}, {2, 2}, {2, 0}})
+	if a := p.Area(); a != 4 {
+		t.Errorf("incorrect area, got %v", a)
+	}
+
+	if a := (Polygon{}).Area(); a != 0 {
+		t.Errorf("empty polygon should have zero area, got %v", a)
+	}
+}
+
+func TestPolygonCentroid(t *testing.T) {
+	p := NewPolygon(&PointSet{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}})
+	if c := p.Centroid(); !c.Equals(NewPoint(2, 2)) {
+		t.Errorf("incorrect centroid, got %v", c)
+	}
+
+	// hole in the lower left pulls the centroid up and right
+	p = NewPolygon(
+		&PointSet{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		&PointSet{{0, 0}, {0, 2}, {2, 2}, {2, 0}, {0, 0}},
+	)
+
+	expected := NewPoint(7.0/3.0, 7.0/3.0)
+	if c := p.Centroid(); c.DistanceFrom(expected) > epsilon {
+		t.Errorf("incorrect centroid, got %v", c)
+	}
+
+	// degenerate
+	p = NewPolygon(&PointSet{{0, 0}, {2, 2}, {4, 4}})
+	if c := p.Centroid(); !c.Equals(NewPoint(2, 2)) {
+		t.Errorf("incorrect centroid, got %v", c)
+	}
+}
+
+func TestPolygonPerimeter(t *testing.T) {
+	p := testPolygon()
+	if d := p.Perimeter(); d != 20 {
+		t.Errorf("incorrect perimeter, got %v", d)
+	}
+
+	// should close open rings
+	p = NewPolygon(&PointSet{{0, 0}, {0, 2}, {2, 2}, {2, 0}})
+	if d := p.Perimeter(); d != 8 {
+		t.Errorf("incorrect perimeter, got %v", d)
+	}
+
+	p = NewPolygonFromBound(NewBound(0, 1, 0, 1))
+	expected := 4 * NewPoint(0, 0).GeoDistanceFrom(NewPoint(0, 1))
+	if d := p.GeoPerimeter(); d < expected*0.99 || d > expected*1.01 {
+		t.Errorf("incorrect geo perimeter, got %v", d)
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	p := testPolygon()
+
+	if !p.Contains(NewPoint(3, 3)) {
+		t.Errorf("should contain point")
+	}
+
+	if p.Contains(NewPoint(1.5, 1.5)) {
+		t.Errorf("should not contain point in hole")
+	}
+
+	if p.Contains(NewPoint(5, 1)) {
+		t.Errorf("should not contain point outside")
+	}
+
+	if (Polygon{}).Contains(NewPoint(0, 0)) {
+		t.Errorf("empty polygon should not contain anything")
+	}
+
+	// boundaries
+	if !p.Contains(NewPoint(0, 2)) || !p.Contains(NewPoint(4, 4)) {
+		t.Errorf("should contain point on outer boundary")
+	}
+
+	if !p.Contains(NewPoint(1, 1.5)) || !p.Contains(NewPoint(2, 2)) {
+		t.Errorf("should contain point on hole boundary")
+	}
+}
+
+func TestPolygonContainsPath(t *testing.T) {
+	// a U shape with the opening at the top
+	u := NewPolygon(&PointSet{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}})
+
+	type testData struct {
+		path     *Path
+		expected bool
+	}
+
+	tests := []testData{
+		{NewPathFromXYData([][2]float64{{0.5, 0.5}, {2.5, 0.5}, {2.5, 2.5}}), true},
+		{NewPathFromXYData([][2]float64{{0.5, 2.5}, {2.5, 2.5}}), false},      // across the opening
+		{NewPathFromXYData([][2]float64{{0, 0}, {3, 0}, {3, 3}}), true},       // along the boundary
+		{NewPathFromXYData([][2]float64{{1, 1}, {2, 1}}), true},               // along the inner boundary
+		{NewPathFromXYData([][2]float64{{1, 3}, {2, 3}}), false},              // between two boundary points
+		{NewPathFromXYData([][2]float64{{0.5, 0.5}, {0.5, 4}}), false},        // leaving
+		{NewPathFromXYData([][2]float64{{1, 0.5}, {1, 2}, {0.5, 2.5}}), true}, // touching the inner corner
+		{NewPathFromXYData([][2]float64{{0.5, 0.5}}), true},                   // single point
+		{NewPath(), false},
+	}
+
+	for i, test := range tests {
+		if v := u.ContainsPath(test.path); v != test.expected {
+			t.Errorf("test %d, containsPath expected %v, got %v", i, test.expected, v)
+		}
+	}
+
+	// holes
+	p := testPolygon()
+	if p.ContainsPath(NewPathFromXYData([][2]float64{{0.5, 1.5}, {3, 1.5}})) {
+		t.Errorf("should not contain path through hole")
+	}
+
+	if !p.ContainsPath(NewPathFromXYData([][2]float64{{0.5, 0.5}, {3, 0.5}, {3, 3}})) {
+		t.Errorf("should contain path around hole")
+	}
+}
+
+func TestPolygonIntersectsPath(t *testing.T) {
+	p := testPolygon()
+
+	type testData struct {
+		path     *Path
+		expected bool
+	}
+
+	tests := []testData{
+		{NewPathFromXYData([][2]float64{{3, 3}, {3.5, 3.5}}), true},         // inside
+		{NewPathFromXYData([][2]float64{{-1, 2}, {5, 2}}), true},            // crossing
+		{NewPathFromXYData([][2]float64{{-1, -1}, {-1, 5}, {5, 5}}), false}, // outside
+		{NewPathFromXYData([][2]float64{{-1, 0}, {5, 0}}), true},            // along the boundary
+		{NewPathFromXYData([][2]float64{{1.2, 1.2}, {1.8, 1.8}}), false},    // in the hole
+		{NewPathFromXYData([][2]float64{{1.5, 1.5}, {1.5, 3}}), true},       // leaving the hole
+		{NewPathFromXYData([][2]float64{{-1, 5}, {5, -1}}), true},           // through, no points inside
+		{NewPath(), false},
+	}
+
+	for i, test := range tests {
+		if v := p.IntersectsPath(test.path); v != test.expected {
+			t.Errorf("test %d, intersectsPath expected %v, got %v", i, test.expected, v)
+		}
+	}
+}
+
+func TestPolygonTransform(t *testing.T) {
+	p := NewPolygon(&PointSet{{0, 0}, {1, 1}})
+	p.Transform(func(p *Point) { p.Scale(2) })
+
+	expected := NewPolygon(&PointSet{{0, 0}, {2, 2}})
+	if !p.Equals(expected) {
+		t.Errorf("incorrect transform, got %v", p)
+	}
+}
+
+func TestPolygonEquals(t *testing.T) {
+	p := testPolygon()
+	if !p.Equals(p.Clone()) {
+		t.Errorf("should be equal to clone")
+	}
+
+	if p.Equals(NewPolygon(p.Outer())) {
+		t.Errorf("should not be equal with different number of rings")
+	}
+
+	c := p.Clone()
+	c.Outer().SetAt(0, NewPoint(1, 1))
+	if p.Equals(c) {
+		t.Errorf("should not be equal")
+	}
+}
+
+func TestPolygonToGeoJSON(t *testing.T) {
+	f := testPolygon().ToGeoJSON()
+
+	if !f.Geometry.IsPolygon() {
+		t.Errorf("should be polygon geometry")
+	}
+
+	if l := len(f.Geometry.Polygon); l != 2 {
+		t.Errorf("should have 2 rings, got %d", l)
+	}
+
+	if l := len(f.Geometry.Polygon[1]); l != 5 {
+		t.Errorf("should have 5 points in hole, got %d", l)
+	}
+}
+
+func TestPolygonToWKT(t *testing.T) {
+	p := testPolygon()
+
+	answer := "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1))"
+	if s := p.ToWKT(); s != answer {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+
+	if s := (Polygon{}).ToWKT(); s != "EMPTY" {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+}
+
+func TestPolygonJSON(t *testing.T) {
+	p1 := NewPolygon(&PointSet{{1, 2}, {3, 4}, {5, 6}})
+
+	data, err := json.Marshal(p1)
+	if err != nil {
+		t.Errorf("should marshal just fine, %v", err)
+	}
+
+	if string(data) != "[[[1,2],[3,4],[5,6]]]" {
+		t.Errorf("json encoding incorrect, got %v", string(data))
+	}
+
+	var p2 *Polygon
+	err = json.Unmarshal(data, &p2)
+	if err != nil {
+		t.Errorf("should unmarshal just fine, %v", err)
+	}
+
+	if !p1.Equals(p2) {
+		t.Errorf("unmarshal incorrect, got %v", p2)
+	}
+}
+
+func TestMultiPolygon(t *testing.T) {
+	mp := NewMultiPolygon(
+		testPolygon(),
+		NewPolygon(&PointSet{{10, 10}, {11, 10}, {10, 11}, {10, 10}}),
+	)
+
+	if a := mp.Area(); a != 15.5 {
+		t.Errorf("incorrect area, got %v", a)
+	}
+
+	if !mp.Contains(NewPoint(10.1, 10.1)) {
+		t.Errorf("should contain point")
+	}
+
+	if mp.Contains(NewPoint(1.5, 1.5)) {
+		t.Errorf("should not contain point in hole")
+	}
+
+	if !mp.Bound().Equals(NewBound(0, 11, 0, 11)) {
+		t.Errorf("incorrect bound, got %v", mp.Bound())
+	}
+
+	expected := 20 + 2 + 1.4142135623730951
+	if d := mp.Perimeter(); d != expected {
+		t.Errorf("incorrect perimeter, got %v", d)
+	}
+
+	// weighted by area
+	c := mp.Centroid()
+	expectedCentroid := NewPoint((30.5+0.5*(31.0/3))/15.5, (30.5+0.5*(31.0/3))/15.5)
+	if c.DistanceFrom(expectedCentroid) > epsilon {
+		t.Errorf("incorrect centroid, got %v", c)
+	}
+
+	if !mp.Equals(mp.Clone()) {
+		t.Errorf("should be equal to clone")
+	}
+
+	if f := mp.ToGeoJSON(); !f.Geometry.IsMultiPolygon() {
+		t.Errorf("should be multipolygon geometry")
+	}
+
+	answer := "MULTIPOLYGON(((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1)),((10 10,11 10,10 11,10 10)))"
+	if s := mp.ToWKT(); s != answer {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+
+	if s := (MultiPolygon{}).ToWKT(); s != "EMPTY" {
+		t.Errorf("incorrect wkt, got %v", s)
+	}
+
+	mp.Transform(func(p *Point) { p.Scale(2) })
+	if a := mp.Area(); a != 62 {
+		t.Errorf("incorrect area after transform, got %v", a)
+	}
+}
+
+func TestPolygonGeoArea(t *testing.T) {
+	p := NewPolygonFromBound(NewBound(0, 2, 0, 2))
+	hole := NewPolygonFromBound(NewBound(0.5, 1.5, 0.5, 1.5)).Outer()
+	p = NewPolygon(p.Outer(), hole)
+
+	expected := NewBound(0, 2, 0, 2).GeoArea() - NewBound(0.5, 1.5, 0.5, 1.5).GeoArea()
+	if a := p.GeoArea(); math.Abs(a-expected) > 1e-3 {
+		t.Errorf("polygon, geoArea expected %f, got %f", expected, a)
+	}
+
+	expected = NewBound(0, 2, 0, 2).GeodesicArea() - NewBound(0.5, 1.5, 0.5, 1.5).GeodesicArea()
+	if a := p.GeodesicArea(); math.Abs(a-expected) > 1e-3 {
+		t.Errorf("polygon, geodesicArea expected %f, got %f", expected, a)
+	}
+
+	mp := NewMultiPolygon(p, p)
+	if a := mp.GeodesicArea(); math.Abs(a-2*expected) > 1e-3 {
+		t.Errorf("multipolygon, geodesicArea expected %f, got %f", 2*expected, a)
+	}
+
+	if a := mp.GeoArea(); math.Abs(a-2*p.GeoArea()) > 1e-3 {
+		t.Errorf("multipolygon, geoArea expected %f, got %f", 2*p.GeoArea(), a)
+	}
+
+	if a := (Polygon{}).GeodesicArea(); a != 0 {
+		t.Errorf("polygon, geodesicArea of empty should be 0, got %f", a)
+	}
+}
+
+func TestMultiPolygonContainsPath(t *testing.T) {
+	// two squares sharing an edge
+	mp := NewMultiPolygon(
+		NewPolygonFromBound(NewBound(0, 1, 0, 1)),
+		NewPolygonFromBound(NewBound(1, 2, 0, 1)),
+	)
+
+	path := NewPathFromXYData([][2]float64{{0.5, 0.5}, {1.5, 0.5}})
+	if !mp.ContainsPath(path) {
+		t.Errorf("should contain path across shared edge")
+	}
+
+	if !mp.IntersectsPath(path) {
+		t.Errorf("should intersect path")
+	}
+
+	path = NewPathFromXYData([][2]float64{{0.5, 0.5}, {2.5, 0.5}})
+	if mp.ContainsPath(path) {
+		t.Errorf("should not contain path leaving the polygons")
+	}
+
+	path = NewPathFromXYData([][2]float64{{3, 0}, {3, 1}})
+	if mp.IntersectsPath(path) {
+		t.Errorf("should not intersect path")
+	}
+}
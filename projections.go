@@ -30,6 +30,71 @@ var Mercator = Projection{
 	},
 }
 
+// EllipsoidalMercator projection, performs EPSG:3395, the World Mercator projection
+// on the WGS84 ellipsoid. The inverse is computed iteratively. Like Mercator, the
+// projected y values are clamped to the square world of EPSG:3857 so the two
+// can share tile and pixel coordinates, see BuildMercatorPixelConversion.
+var EllipsoidalMercator = Projection{
+	Project: func(p *Point) {
+		ecc := math.Sqrt(WGS84.F * (2 - WGS84.F))
+
+		p.SetX(mercatorPole / 180.0 * p.Lng())
+
+		y := -WGS84.A * math.Log(conicT(ecc, deg2rad(p.Lat())))
+		p.SetY(math.Max(-mercatorPole, math.Min(y, mercatorPole)))
+	},
+	Inverse: func(p *Point) {
+		ecc := math.Sqrt(WGS84.F * (2 - WGS84.F))
+
+		p.SetLng(p.X() * 180.0 / mercatorPole)
+		p.SetLat(rad2deg(conicLatitude(ecc, math.Exp(-p.Y()/WGS84.A))))
+	},
+}
+
+// MercatorToEllipsoidalMercator converts between the spherical and ellipsoidal Mercator
+// projections. Project converts EPSG:3857 coordinates to EPSG:3395, Inverse the reverse.
+// Only the y value changes, by up to 40km at high latitudes.
+var MercatorToEllipsoidalMercator = Projection{
+	Project: func(p *Point) {
+		Mercator.Inverse(p)
+		EllipsoidalMercator.Project(p)
+	},
+	Inverse: func(p *Point) {
+		EllipsoidalMercator.Inverse(p)
+		Mercator.Project(p)
+	},
+}
+
+// BuildMercatorPixelConversion builds a projection that converts pixel coordinates,
+// with the origin in the top left, of an EPSG:3857 map to the pixel coordinates of an
+// EPSG:3395 map with the same extent. The worldSize is the width of the whole world
+// in pixels, for example 256 << zoom for 256 pixel tiles.
+// Project converts from EPSG:3857 to EPSG:3395, Inverse the reverse.
+func BuildMercatorPixelConversion(worldSize float64) Projection {
+	toMeters := func(p *Point) {
+		p.SetX(2*mercatorPole*p.X()/worldSize - mercatorPole)
+		p.SetY(mercatorPole - 2*mercatorPole*p.Y()/worldSize)
+	}
+
+	toPixels := func(p *Point) {
+		p.SetX((p.X() + mercatorPole) / (2 * mercatorPole) * worldSize)
+		p.SetY((mercatorPole - p.Y()) / (2 * mercatorPole) * worldSize)
+	}
+
+	return Projection{
+		Project: func(p *Point) {
+			toMeters(p)
+			MercatorToEllipsoidalMercator.Project(p)
+			toPixels(p)
+		},
+		Inverse: func(p *Point) {
+			toMeters(p)
+			MercatorToEllipsoidalMercator.Inverse(p)
+			toPixels(p)
+		},
+	}
+}
+
 // MercatorScaleFactor returns the mercator scaling factor for a given degree latitude.
 func MercatorScaleFactor(degreesLatitude float64) float64 {
 	if degreesLatitude < -90.0 || degreesLatitude > 90.0 {
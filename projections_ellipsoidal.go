@@ -0,0 +1,458 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// BuildEllipsoidalTransverseMercator builds a transverse Mercator projection
+// on the ellipsoid using Krüger's series to the sixth order of the third flattening,
+// as described by Karney in "Transverse Mercator with an accuracy of a few nanometers" (2011).
+// It is accurate to better than a millimeter within 4000 km of the central meridian.
+// The projected coordinates are in meters, with the origin at the center longitude
+// and origin latitude, scaled by scale and offset by the false easting and northing.
+func BuildEllipsoidalTransverseMercator(e *Ellipsoid, centerLng, originLat, scale, falseEasting, falseNorthing float64) Projection {
+	s := newKruegerSeries(e)
+	k := scale * s.A
+
+	// the northing of the origin
+	originY := k * s.rectifyingLatitude(deg2rad(originLat))
+
+	return Projection{
+		Project: func(p *Point) {
+			lng := deg2rad(normalizeLng(p.Lng() - centerLng))
+			tauPrime := s.conformalTau(math.Tan(deg2rad(p.Lat())))
+
+			sinLng, cosLng := math.Sincos(lng)
+			xiPrime := math.Atan2(tauPrime, cosLng)
+			etaPrime := math.Asinh(sinLng / math.Hypot(tauPrime, cosLng))
+
+			xi, eta := xiPrime, etaPrime
+			for j := 1; j <= 6; j++ {
+				sin, cos := math.Sincos(2 * float64(j) * xiPrime)
+				xi += s.alpha[j] * sin * math.Cosh(2*float64(j)*etaPrime)
+				eta += s.alpha[j] * cos * math.Sinh(2*float64(j)*etaPrime)
+			}
+
+			p.SetX(falseEasting + k*eta)
+			p.SetY(falseNorthing + k*xi - originY)
+		},
+		Inverse: func(p *Point) {
+			eta := (p.X() - falseEasting) / k
+			xi := (p.Y() - falseNorthing + originY) / k
+
+			xiPrime, etaPrime := xi, eta
+			for j := 1; j <= 6; j++ {
+				sin, cos := math.Sincos(2 * float64(j) * xi)
+				xiPrime -= s.beta[j] * sin * math.Cosh(2*float64(j)*eta)
+				etaPrime -= s.beta[j] * cos * math.Sinh(2*float64(j)*eta)
+			}
+
+			sinhEta := math.Sinh(etaPrime)
+			sinXi, cosXi := math.Sincos(xiPrime)
+			tauPrime := sinXi / math.Hypot(sinhEta, cosXi)
+
+			p.SetLat(rad2deg(math.Atan(s.tauFromConformal(tauPrime))))
+			p.SetLng(normalizeLng(centerLng + rad2deg(math.Atan2(sinhEta, cosXi))))
+		},
+	}
+}
+
+// UTMZone returns the Universal Transverse Mercator zone, 1 to 60, and hemisphere
+// of the point, including the exceptions for southwest Norway and Svalbard.
+// UTM is defined between 80S and 84N but zones are returned for all latitudes.
+func UTMZone(point *Point) (zone int, north bool) {
+	lng := normalizeLng(point.Lng())
+	lat := point.Lat()
+
+	zone = int(math.Floor((lng+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	// southwest Norway
+	if lat >= 56 && lat < 64 && lng >= 3 && lng < 12 {
+		zone = 32
+	}
+
+	// Svalbard
+	if lat >= 72 && lat < 84 && lng >= 0 && lng < 42 {
+		switch {
+		case lng < 9:
+			zone = 31
+		case lng < 21:
+			zone = 33
+		case lng < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+
+	return zone, lat >= 0
+}
+
+// BuildUTM builds the Universal Transverse Mercator projection, on the WGS84 ellipsoid,
+// for the zone and hemisphere. Projected coordinates are the easting and northing in meters.
+// Panics if the zone is not between 1 and 60.
+func BuildUTM(zone int, north bool) Projection {
+	return buildUTM(WGS84, zone, north)
+}
+
+// buildUTM builds the UTM projection on the ellipsoid, used for the UTM
+// grids of other datums, such as NAD83 and ETRS89.
+func buildUTM(e *Ellipsoid, zone int, north bool) Projection {
+	if zone < 1 || zone > 60 {
+		panic(fmt.Sprintf("geo: utm zone out of range, given %d", zone))
+	}
+
+	falseNorthing := 0.0
+	if !north {
+		falseNorthing = 10000000
+	}
+
+	return BuildEllipsoidalTransverseMercator(e, float64(6*zone-183), 0, 0.9996, 500000, falseNorthing)
+}
+
+// BuildUTMForPoint builds the Universal Transverse Mercator projection
+// for the zone and hemisphere containing the point, see UTMZone.
+func BuildUTMForPoint(point *Point) Projection {
+	return BuildUTM(UTMZone(point))
+}
+
+// BuildLambertConformalConic builds a Lambert conformal conic projection on the ellipsoid
+// with two standard parallels, the same as EPSG method 9802. The origin is at the
+// center longitude and origin latitude, offset by the false easting and northing.
+// If the standard parallels are the same it is the one standard parallel variant
+// with a scale of 1. Projected coordinates are in meters.
+func BuildLambertConformalConic(e *Ellipsoid, centerLng, originLat, parallel1, parallel2, falseEasting, falseNorthing float64) Projection {
+	ecc := math.Sqrt(e.F * (2 - e.F))
+
+	phi1, phi2 := deg2rad(parallel1), deg2rad(parallel2)
+	m1, m2 := conicM(ecc, phi1), conicM(ecc, phi2)
+	t1, t2 := conicT(ecc, phi1), conicT(ecc, phi2)
+
+	n := math.Sin(phi1)
+	if phi1 != phi2 {
+		n = (math.Log(m1) - math.Log(m2)) / (math.Log(t1) - math.Log(t2))
+	}
+
+	aF := e.A * m1 / (n * math.Pow(t1, n))
+	rho0 := aF * math.Pow(conicT(ecc, deg2rad(originLat)), n)
+
+	return Projection{
+		Project: func(p *Point) {
+			rho := 0.0
+			if lat := deg2rad(p.Lat()); math.Abs(lat) < math.Pi/2 || lat*n < 0 {
+				rho = aF * math.Pow(conicT(ecc, lat), n)
+			}
+
+			theta := n * deg2rad(normalizeLng(p.Lng()-centerLng))
+
+			p.SetX(falseEasting + rho*math.Sin(theta))
+			p.SetY(falseNorthing + rho0 - rho*math.Cos(theta))
+		},
+		Inverse: func(p *Point) {
+			x := p.X() - falseEasting
+			y := rho0 - (p.Y() - falseNorthing)
+			if n < 0 {
+				x, y = -x, -y
+			}
+
+			rho := math.Copysign(math.Hypot(x, y), n)
+			theta := math.Atan2(x, y)
+
+			lat := math.Copysign(math.Pi/2, n)
+			if rho != 0 {
+				lat = conicLatitude(ecc, math.Pow(rho/aF, 1/n))
+			}
+
+			p.SetLat(rad2deg(lat))
+			p.SetLng(normalizeLng(centerLng + rad2deg(theta/n)))
+		},
+	}
+}
+
+// BuildAlbersEqualArea builds an Albers equal area conic projection on the ellipsoid
+// with two standard parallels, the same as EPSG method 9822. The origin is at the
+// center longitude and origin latitude, offset by the false easting and northing.
+// Projected coordinates are in meters.
+func BuildAlbersEqualArea(e *Ellipsoid, centerLng, originLat, parallel1, parallel2, falseEasting, falseNorthing float64) Projection {
+	e2 := e.F * (2 - e.F)
+	ecc := math.Sqrt(e2)
+
+	phi1, phi2 := deg2rad(parallel1), deg2rad(parallel2)
+	m1, m2 := conicM(ecc, phi1), conicM(ecc, phi2)
+	q1, q2 := e.authalicQ(phi1), e.authalicQ(phi2)
+
+	n := math.Sin(phi1)
+	if phi1 != phi2 {
+		n = (m1*m1 - m2*m2) / (q2 - q1)
+	}
+
+	C := m1*m1 + n*q1
+	rho0 := e.A * math.Sqrt(C-n*e.authalicQ(deg2rad(originLat))) / n
+
+	return Projection{
+		Project: func(p *Point) {
+			rho := e.A * math.Sqrt(C-n*e.authalicQ(deg2rad(p.Lat()))) / n
+			theta := n * deg2rad(normalizeLng(p.Lng()-centerLng))
+
+			p.SetX(falseEasting + rho*math.Sin(theta))
+			p.SetY(falseNorthing + rho0 - rho*math.Cos(theta))
+		},
+		Inverse: func(p *Point) {
+			x := p.X() - falseEasting
+			y := rho0 - (p.Y() - falseNorthing)
+			if n < 0 {
+				x, y = -x, -y
+			}
+
+			rho := math.Hypot(x, y)
+			theta := math.Atan2(x, y)
+			q := (C - rho*rho*n*n/(e.A*e.A)) / n
+
+			p.SetLat(rad2deg(authalicLatitude(e, q)))
+			p.SetLng(normalizeLng(centerLng + rad2deg(theta/n)))
+		},
+	}
+}
+
+// BuildPolarStereographic builds a polar stereographic projection on the ellipsoid.
+// The projection is centered on the north pole if trueScaleLat is positive and the
+// south pole if negative. The scale is 1 at the latitude of true scale, the same as
+// EPSG method 9829, or scale at the pole if trueScaleLat is 90 or -90, the same as
+// EPSG method 9810. The center longitude points down from the north pole, or up from
+// the south pole. Projected coordinates are in meters, offset by the false easting
+// and northing. For example the Universal Polar Stereographic projection for
+// the north is BuildPolarStereographic(WGS84, 0, 90, 0.994, 2000000, 2000000).
+func BuildPolarStereographic(e *Ellipsoid, centerLng, trueScaleLat, scale, falseEasting, falseNorthing float64) Projection {
+	ecc := math.Sqrt(e.F * (2 - e.F))
+
+	// computed for the north pole, the south pole is mirrored
+	sign := 1.0
+	if trueScaleLat < 0 {
+		sign = -1
+	}
+
+	phiC := deg2rad(math.Abs(trueScaleLat))
+
+	var k float64 // rho = k*t
+	if phiC == math.Pi/2 {
+		k = 2 * e.A * scale / math.Sqrt(math.Pow(1+ecc, 1+ecc)*math.Pow(1-ecc, 1-ecc))
+	} else {
+		k = e.A * scale * conicM(ecc, phiC) / conicT(ecc, phiC)
+	}
+
+	return Projection{
+		Project: func(p *Point) {
+			rho := k * conicT(ecc, sign*deg2rad(p.Lat()))
+			sin, cos := math.Sincos(sign * deg2rad(normalizeLng(p.Lng()-centerLng)))
+
+			p.SetX(falseEasting + sign*rho*sin)
+			p.SetY(falseNorthing - rho*cos)
+		},
+		Inverse: func(p *Point) {
+			x := sign * (p.X() - falseEasting)
+			y := -(p.Y() - falseNorthing)
+
+			rho := math.Hypot(x, y)
+			lat := conicLatitude(ecc, rho/k)
+
+			lng := centerLng
+			if rho != 0 {
+				lng += sign * rad2deg(math.Atan2(x, y))
+			}
+
+			p.SetLat(sign * rad2deg(lat))
+			p.SetLng(normalizeLng(lng))
+		},
+	}
+}
+
+// BuildEquirectangular builds an equidistant cylindrical projection on the ellipsoid,
+// the same as EPSG method 1028. The distances along the meridians are true
+// and the scale is 1 along the standard parallel, so EPSG:4087 is
+// BuildEquirectangular(WGS84, 0, 0, 0, 0). Projected coordinates are in meters.
+func BuildEquirectangular(e *Ellipsoid, centerLng, standardParallel, falseEasting, falseNorthing float64) Projection {
+	s := newKruegerSeries(e)
+
+	e2 := e.F * (2 - e.F)
+	sinPhi1, cosPhi1 := math.Sincos(deg2rad(standardParallel))
+	radius := e.A * cosPhi1 / math.Sqrt(1-e2*sinPhi1*sinPhi1)
+
+	return Projection{
+		Project: func(p *Point) {
+			p.SetX(falseEasting + radius*deg2rad(normalizeLng(p.Lng()-centerLng)))
+			p.SetY(falseNorthing + s.A*s.rectifyingLatitude(deg2rad(p.Lat())))
+		},
+		Inverse: func(p *Point) {
+			p.SetLng(normalizeLng(centerLng + rad2deg((p.X()-falseEasting)/radius)))
+			p.SetLat(rad2deg(s.latitudeFromRectifying((p.Y() - falseNorthing) / s.A)))
+		},
+	}
+}
+
+// kruegerSeries holds the coefficients of the series in the third flattening
+// used to convert between geodetic, conformal and rectifying latitudes.
+type kruegerSeries struct {
+	ecc float64 // eccentricity
+	e2  float64
+
+	// A is the radius of the rectifying sphere,
+	// 2*pi*A is the circumference of a meridian.
+	A float64
+
+	alpha, beta [7]float64 // 1 indexed
+}
+
+func newKruegerSeries(e *Ellipsoid) *kruegerSeries {
+	n := e.F / (2 - e.F)
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	s := &kruegerSeries{
+		e2: e.F * (2 - e.F),
+		A:  e.A / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+	}
+	s.ecc = math.Sqrt(s.e2)
+
+	s.alpha = [7]float64{0,
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+		13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+		61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+		49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+		34729*n5/80640 - 3418889*n6/1995840,
+		212378941 * n6 / 319334400,
+	}
+
+	s.beta = [7]float64{0,
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+		n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+		17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+		4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+		4583*n5/161280 - 108847*n6/3991680,
+		20648693 * n6 / 638668800,
+	}
+
+	return s
+}
+
+// conformalTau returns the tangent of the conformal latitude
+// given the tangent of the geodetic latitude.
+func (s *kruegerSeries) conformalTau(tau float64) float64 {
+	if math.IsInf(tau, 0) {
+		return tau
+	}
+
+	sigma := math.Sinh(s.ecc * math.Atanh(s.ecc*tau/math.Sqrt(1+tau*tau)))
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+// tauFromConformal inverts conformalTau using Newton's method.
+func (s *kruegerSeries) tauFromConformal(tauPrime float64) float64 {
+	if math.IsInf(tauPrime, 0) {
+		return tauPrime
+	}
+
+	tau := tauPrime
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		tp := s.conformalTau(tau)
+		delta := (tauPrime - tp) / math.Sqrt(1+tp*tp) *
+			(1 + (1-s.e2)*tau*tau) / ((1 - s.e2) * math.Sqrt(1+tau*tau))
+
+		tau += delta
+		if math.Abs(delta) < geodesicTolerance {
+			break
+		}
+	}
+
+	return tau
+}
+
+// rectifyingLatitude returns the rectifying latitude, the distance along
+// the meridian from the equator divided by A, of the latitude in radians.
+func (s *kruegerSeries) rectifyingLatitude(lat float64) float64 {
+	chi := math.Atan(s.conformalTau(math.Tan(lat)))
+
+	mu := chi
+	for j := 1; j <= 6; j++ {
+		mu += s.alpha[j] * math.Sin(2*float64(j)*chi)
+	}
+
+	return mu
+}
+
+// latitudeFromRectifying inverts rectifyingLatitude.
+func (s *kruegerSeries) latitudeFromRectifying(mu float64) float64 {
+	chi := mu
+	for j := 1; j <= 6; j++ {
+		chi -= s.beta[j] * math.Sin(2*float64(j)*mu)
+	}
+
+	return math.Atan(s.tauFromConformal(math.Tan(chi)))
+}
+
+// conicM returns cos(lat)/sqrt(1 - e^2 sin^2(lat)), the radius of the parallel divided by a.
+func conicM(ecc, lat float64) float64 {
+	sin, cos := math.Sincos(lat)
+	return cos / math.Sqrt(1-ecc*ecc*sin*sin)
+}
+
+// conicT returns the function t(lat) used by the conformal conic and stereographic projections.
+func conicT(ecc, lat float64) float64 {
+	sin := math.Sin(lat)
+	return math.Tan(math.Pi/4-lat/2) / math.Pow((1-ecc*sin)/(1+ecc*sin), ecc/2)
+}
+
+// conicLatitude inverts conicT by iteration.
+func conicLatitude(ecc, t float64) float64 {
+	lat := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		sin := math.Sin(lat)
+
+		prev := lat
+		lat = math.Pi/2 - 2*math.Atan(t*math.Pow((1-ecc*sin)/(1+ecc*sin), ecc/2))
+
+		if math.Abs(lat-prev) < geodesicTolerance {
+			break
+		}
+	}
+
+	return lat
+}
+
+// authalicLatitude returns the geodetic latitude for the given value
+// of the authalic q function, see Ellipsoid.authalicQ.
+func authalicLatitude(e *Ellipsoid, q float64) float64 {
+	e2 := e.F * (2 - e.F)
+	ecc := math.Sqrt(e2)
+
+	// at the poles the iteration does not converge
+	qp := e.authalicQ(math.Pi / 2)
+	if math.Abs(q) >= qp {
+		return math.Copysign(math.Pi/2, q)
+	}
+
+	lat := math.Asin(q / 2)
+	if e2 == 0 {
+		return lat
+	}
+
+	for i := 0; i < GeodesicMaxIterations; i++ {
+		sin, cos := math.Sincos(lat)
+		d := 1 - e2*sin*sin
+
+		delta := d * d / (2 * cos) * (q/(1-e2) - sin/d + math.Log((1-ecc*sin)/(1+ecc*sin))/(2*ecc))
+		lat += delta
+
+		if math.Abs(delta) < geodesicTolerance {
+			break
+		}
+	}
+
+	return lat
+}
@@ -0,0 +1,221 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// testEllipsoidalRoundTrip projects and inverts the cities accepted by the filter.
+func testEllipsoidalRoundTrip(t *testing.T, name string, proj Projection, filter func(lat, lng float64) bool) {
+	tested := 0
+	for _, city := range cities {
+		if !filter(city[0], city[1]) {
+			continue
+		}
+		tested++
+
+		p := NewPoint(city[1], city[0])
+		proj.Project(p)
+		proj.Inverse(p)
+
+		if math.Abs(p.Lat()-city[0]) > 1e-9 {
+			t.Errorf("%s, latitude miss match: %f != %f", name, p.Lat(), city[0])
+		}
+
+		if math.Abs(p.Lng()-city[1]) > 1e-9 {
+			t.Errorf("%s, longitude miss match: %f != %f", name, p.Lng(), city[1])
+		}
+	}
+
+	if tested == 0 {
+		t.Errorf("%s, no cities tested", name)
+	}
+}
+
+func testProjectedPoint(t *testing.T, name string, proj Projection, point, expected *Point, tolerance float64) {
+	p := point.Clone()
+	proj.Project(p)
+
+	if math.Abs(p.X()-expected.X()) > tolerance || math.Abs(p.Y()-expected.Y()) > tolerance {
+		t.Errorf("%s, project expected %v, got %v", name, expected, p)
+	}
+
+	proj.Inverse(p)
+	if math.Abs(p.Lng()-point.Lng()) > 1e-9 || math.Abs(p.Lat()-point.Lat()) > 1e-9 {
+		t.Errorf("%s, inverse expected %v, got %v", name, point, p)
+	}
+}
+
+func TestBuildEllipsoidalTransverseMercator(t *testing.T) {
+	// Ordnance Survey, "A guide to coordinate systems in Great Britain", example on Airy 1830
+	airy := &Ellipsoid{A: 6377563.396, F: (6377563.396 - 6356256.909) / 6377563.396}
+	proj := BuildEllipsoidalTransverseMercator(airy, -2, 49, 0.9996012717, 400000, -100000)
+
+	point := NewPoint(1+43.0/60+4.5177/3600, 52+39.0/60+27.2531/3600)
+	testProjectedPoint(t, "ellipsoidal transverse mercator", proj, point, NewPoint(651409.903, 313177.270), 0.001)
+
+	proj = BuildEllipsoidalTransverseMercator(WGS84, 0, 0, 1, 0, 0)
+	testEllipsoidalRoundTrip(t, "ellipsoidal transverse mercator", proj, func(lat, lng float64) bool {
+		return math.Abs(lng) < 30
+	})
+}
+
+func TestUTMZone(t *testing.T) {
+	cases := []struct {
+		point *Point
+		zone  int
+		north bool
+	}{
+		{NewPoint(-122.3, 47.6), 10, true},
+		{NewPoint(151.2, -33.9), 56, false},
+		{NewPoint(-180, 0), 1, true},
+		{NewPoint(180, 0), 60, true},
+		{NewPoint(5.3, 60.4), 32, true},  // Bergen
+		{NewPoint(5.3, 55.9), 31, true},  // south of Norway exception
+		{NewPoint(15.6, 78.2), 33, true}, // Svalbard
+		{NewPoint(8.9, 78.2), 31, true},
+		{NewPoint(35, 80), 37, true},
+	}
+
+	for i, tc := range cases {
+		zone, north := UTMZone(tc.point)
+		if zone != tc.zone || north != tc.north {
+			t.Errorf("utmZone %d expected %d %v, got %d %v", i, tc.zone, tc.north, zone, north)
+		}
+	}
+}
+
+func TestBuildUTM(t *testing.T) {
+	// on the central meridian the northing is the scaled meridian distance
+	proj := BuildUTM(31, true)
+	testProjectedPoint(t, "utm", proj, NewPoint(3, 0), NewPoint(500000, 0), 1e-6)
+	testProjectedPoint(t, "utm", proj, NewPoint(3, 90), NewPoint(500000, 0.9996*10001965.729), 0.001)
+
+	proj = BuildUTM(31, false)
+	testProjectedPoint(t, "utm", proj, NewPoint(3, -90), NewPoint(500000, 10000000-0.9996*10001965.729), 0.001)
+
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+		proj := BuildUTMForPoint(p)
+
+		proj.Project(p)
+		if p.X() < 100000 || p.X() > 900000 {
+			t.Errorf("utm, easting out of range for %v, got %v", city, p.X())
+		}
+
+		if p.Y() < 0 || p.Y() > 10000000 {
+			t.Errorf("utm, northing out of range for %v, got %v", city, p.Y())
+		}
+
+		proj.Inverse(p)
+		if math.Abs(p.Lat()-city[0]) > 1e-9 || math.Abs(p.Lng()-city[1]) > 1e-9 {
+			t.Errorf("utm, round trip expected %v, got %v", city, p)
+		}
+	}
+}
+
+func TestBuildUTMPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("utm, expected panic for invalid zone")
+		}
+	}()
+
+	BuildUTM(61, true)
+}
+
+func TestBuildLambertConformalConic(t *testing.T) {
+	// EPSG guidance note 7-2 example, NAD27 / Texas South Central in US survey feet
+	clarke1866 := &Ellipsoid{A: 6378206.4, F: 1 / 294.9786982}
+	feet := 1200.0 / 3937
+
+	proj := BuildLambertConformalConic(clarke1866, -99, 27+50.0/60, 28+23.0/60, 30+17.0/60, 2000000*feet, 0)
+	point := NewPoint(-96, 28.5)
+	testProjectedPoint(t, "lambert conformal conic", proj, point, NewPoint(2963503.91*feet, 254759.80*feet), 0.01)
+
+	proj = BuildLambertConformalConic(WGS84, -96, 23, 33, 45, 0, 0)
+	testEllipsoidalRoundTrip(t, "lambert conformal conic", proj, func(lat, lng float64) bool {
+		return lat > 0
+	})
+
+	// southern hemisphere cone
+	proj = BuildLambertConformalConic(WGS84, 135, 0, -18, -36, 0, 0)
+	testEllipsoidalRoundTrip(t, "lambert conformal conic", proj, func(lat, lng float64) bool {
+		return lat < 0
+	})
+
+	// one standard parallel
+	proj = BuildLambertConformalConic(WGS84, 0, 45, 45, 45, 0, 0)
+	testProjectedPoint(t, "lambert conformal conic", proj, NewPoint(0, 45), NewPoint(0, 0), 1e-6)
+}
+
+func TestBuildAlbersEqualArea(t *testing.T) {
+	// Snyder, "Map Projections: A Working Manual", numerical example on Clarke 1866
+	clarke1866 := &Ellipsoid{A: 6378206.4, F: 1 / 294.9786982}
+
+	proj := BuildAlbersEqualArea(clarke1866, -96, 23, 29.5, 45.5, 0, 0)
+	testProjectedPoint(t, "albers equal area", proj, NewPoint(-75, 35), NewPoint(1885472.7, 1535925.0), 0.1)
+
+	proj = BuildAlbersEqualArea(WGS84, -96, 23, 29.5, 45.5, 0, 0)
+	testEllipsoidalRoundTrip(t, "albers equal area", proj, func(lat, lng float64) bool {
+		return true
+	})
+
+	// the area of a cell should match the ellipsoidal area
+	expected := NewBound(-100, -99, 40, 41).GeodesicArea()
+	corners := []*Point{NewPoint(-100, 40), NewPoint(-99, 40), NewPoint(-99, 41), NewPoint(-100, 41)}
+
+	projected := NewPath()
+	for i := 0; i < 4; i++ {
+		// densify the edges since the parallels are arcs
+		for j := 0; j < 100; j++ {
+			a, b := corners[i], corners[(i+1)%4]
+			f := float64(j) / 100
+
+			p := NewPoint(a.Lng()+f*(b.Lng()-a.Lng()), a.Lat()+f*(b.Lat()-a.Lat()))
+			proj.Project(p)
+			projected.Push(p)
+		}
+	}
+
+	if a := math.Abs(projected.PointSet.Area()); math.Abs(a-expected)/expected > 1e-6 {
+		t.Errorf("albers equal area, expected area %v, got %v", expected, a)
+	}
+}
+
+func TestBuildPolarStereographic(t *testing.T) {
+	// EPSG guidance note 7-2 example, WGS 84 / UPS North
+	proj := BuildPolarStereographic(WGS84, 0, 90, 0.994, 2000000, 2000000)
+	testProjectedPoint(t, "polar stereographic", proj, NewPoint(44, 73), NewPoint(3320416.75, 632668.43), 0.01)
+	testProjectedPoint(t, "polar stereographic", proj, NewPoint(0, 90), NewPoint(2000000, 2000000), 1e-6)
+
+	testEllipsoidalRoundTrip(t, "polar stereographic", proj, func(lat, lng float64) bool {
+		return lat > 0
+	})
+
+	// the scale is true at the standard parallel
+	proj = BuildPolarStereographic(WGS84, 0, -71, 1, 0, 0)
+	testEllipsoidalRoundTrip(t, "polar stereographic", proj, func(lat, lng float64) bool {
+		return lat < 0
+	})
+
+	a, b := NewPoint(0, -71), NewPoint(0.001, -71)
+	distance, _, _ := WGS84.Inverse(a, b)
+
+	proj.Project(a)
+	proj.Project(b)
+	if d := a.DistanceFrom(b); math.Abs(d-distance)/distance > 1e-6 {
+		t.Errorf("polar stereographic, expected true scale %v, got %v", distance, d)
+	}
+}
+
+func TestBuildEquirectangular(t *testing.T) {
+	// EPSG guidance note 7-2 example, WGS 84 / World Equidistant Cylindrical
+	proj := BuildEquirectangular(WGS84, 0, 0, 0, 0)
+	testProjectedPoint(t, "equirectangular", proj, NewPoint(10, 55), NewPoint(1113194.91, 6097230.31), 0.01)
+
+	proj = BuildEquirectangular(WGS84, 0, 45, 0, 0)
+	testEllipsoidalRoundTrip(t, "equirectangular", proj, func(lat, lng float64) bool {
+		return true
+	})
+}
@@ -256,3 +256,98 @@ func TestScalarMercator(t *testing.T) {
 		t.Errorf("Scalar Mercator, bottom of the world error, got %d", y)
 	}
 }
+
+func TestEllipsoidalMercator(t *testing.T) {
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+
+		EllipsoidalMercator.Project(p)
+		EllipsoidalMercator.Inverse(p)
+
+		if math.Abs(p.Lat()-city[0]) > epsilon {
+			t.Errorf("EllipsoidalMercator, latitude miss match: %f != %f", p.Lat(), city[0])
+		}
+
+		if math.Abs(p.Lng()-city[1]) > epsilon {
+			t.Errorf("EllipsoidalMercator, longitude miss match: %f != %f", p.Lng(), city[1])
+		}
+	}
+
+	// reference value of EPSG:3395
+	p := NewPoint(45, 45)
+	EllipsoidalMercator.Project(p)
+	if math.Abs(p.X()-5009377.085) > 0.01 || math.Abs(p.Y()-5591295.9185) > 0.01 {
+		t.Errorf("EllipsoidalMercator, expected POINT(5009377.085 5591295.9185), got %v", p)
+	}
+
+	// clamped like Mercator
+	p = NewPoint(0, 90)
+	EllipsoidalMercator.Project(p)
+	if p.Y() != mercatorPole {
+		t.Errorf("EllipsoidalMercator, expected clamped y, got %v", p.Y())
+	}
+}
+
+func TestMercatorToEllipsoidalMercator(t *testing.T) {
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+		Mercator.Project(p)
+		MercatorToEllipsoidalMercator.Project(p)
+
+		expected := NewPoint(city[1], city[0])
+		EllipsoidalMercator.Project(expected)
+
+		if math.Abs(p.X()-expected.X()) > 1e-6 || math.Abs(p.Y()-expected.Y()) > 1e-6 {
+			t.Errorf("MercatorToEllipsoidalMercator, expected %v, got %v", expected, p)
+		}
+
+		MercatorToEllipsoidalMercator.Inverse(p)
+		Mercator.Inverse(p)
+		if math.Abs(p.Lat()-city[0]) > epsilon || math.Abs(p.Lng()-city[1]) > epsilon {
+			t.Errorf("MercatorToEllipsoidalMercator, round trip expected %v, got %v", city, p)
+		}
+	}
+}
+
+func TestBuildMercatorPixelConversion(t *testing.T) {
+	worldSize := float64(256 << 10)
+	conversion := BuildMercatorPixelConversion(worldSize)
+
+	// the equator and prime meridian are the same
+	p := NewPoint(worldSize/2, worldSize/2)
+	conversion.Project(p)
+	if math.Abs(p.X()-worldSize/2) > 1e-6 || math.Abs(p.Y()-worldSize/2) > 1e-6 {
+		t.Errorf("MercatorPixelConversion, expected center, got %v", p)
+	}
+
+	// at 45 degrees north the ellipsoidal y is about 30km smaller,
+	// so the pixel is lower on the map.
+	web := NewPoint(-122.4167, 45)
+	Mercator.Project(web)
+	world := NewPoint(-122.4167, 45)
+	EllipsoidalMercator.Project(world)
+
+	pixel := NewPoint(
+		(web.X()+mercatorPole)/(2*mercatorPole)*worldSize,
+		(mercatorPole-web.Y())/(2*mercatorPole)*worldSize,
+	)
+	expected := NewPoint(
+		(world.X()+mercatorPole)/(2*mercatorPole)*worldSize,
+		(mercatorPole-world.Y())/(2*mercatorPole)*worldSize,
+	)
+
+	p = pixel.Clone()
+	conversion.Project(p)
+	if math.Abs(p.X()-expected.X()) > 1e-6 || math.Abs(p.Y()-expected.Y()) > 1e-6 {
+		t.Errorf("MercatorPixelConversion, expected %v, got %v", expected, p)
+	}
+
+	if d := p.Y() - pixel.Y(); d < 190 || d > 200 {
+		t.Errorf("MercatorPixelConversion, expected shift of about 196 pixels, got %v", d)
+	}
+
+	conversion.Inverse(p)
+	if math.Abs(p.X()-pixel.X()) > 1e-6 || math.Abs(p.Y()-pixel.Y()) > 1e-6 {
+		t.Errorf("MercatorPixelConversion, round trip expected %v, got %v", pixel, p)
+	}
+}
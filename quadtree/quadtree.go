@@ -31,10 +31,11 @@ type Quadtree struct {
 	// sometimes but not very often.
 	Threshold float64
 
-	bound     *geo.Bound
-	root      *node
-	freeNodes []node
-	freeIndex int
+	bound        *geo.Bound
+	root         *node
+	freeNodes    []node
+	freeIndex    int
+	removedNodes []*node
 }
 
 // A Filter is a function that returns a boolean value for a given geo.Pointer.
@@ -135,7 +136,14 @@ func (q *Quadtree) Insert(p geo.Pointer) error {
 
 // nextNode returns the next node from a preallocated list.
 // This resulted in about 15% improvement in quadtree creation.
+// Nodes released by Remove are reused first.
 func (q *Quadtree) nextNode() *node {
+	if l := len(q.removedNodes); l > 0 {
+		n := q.removedNodes[l-1]
+		q.removedNodes = q.removedNodes[:l-1]
+		return n
+	}
+
 	if l := len(q.freeNodes); q.freeIndex >= l {
 		// Exponentially decrease the preallocation size.
 		// On a handful of tests, number of nodes was about 1.5 times pointers.
@@ -222,6 +230,129 @@ func (q *Quadtree) insert(n *node, p geo.Pointer, left, right, bottom, top float
 	q.insert(n, p, left, right, bottom, top)
 }
 
+// Remove deletes the pointer from the quad tree, returning false if it was not found.
+// The pointer is found by comparing with == along the path to its current point,
+// so the point must not have changed since it was inserted, see Move.
+// Nodes left empty are collapsed and reused by later inserts.
+// This function is not thread-safe.
+func (q *Quadtree) Remove(p geo.Pointer) bool {
+	if p == nil || q.root == nil {
+		return false
+	}
+
+	point := p.Point()
+	if point == nil || !q.bound.Contains(point) {
+		return false
+	}
+
+	return q.remove(q.root, p, point,
+		q.bound.Left(), q.bound.Right(),
+		q.bound.Bottom(), q.bound.Top(),
+	)
+}
+
+// Move changes the location of the pointer in the quad tree by setting
+// the point returned by p.Point() to the new point. Returns false, and makes no changes,
+// if the pointer was not found or the new point is outside the quadtree bounds.
+// This function is not thread-safe.
+func (q *Quadtree) Move(p geo.Pointer, point *geo.Point) bool {
+	if point == nil || !q.bound.Contains(point) {
+		return false
+	}
+
+	if !q.Remove(p) {
+		return false
+	}
+
+	p.Point().SetX(point.X()).SetY(point.Y())
+	q.Insert(p)
+
+	return true
+}
+
+func (q *Quadtree) remove(n *node, p geo.Pointer, point *geo.Point, left, right, bottom, top float64) bool {
+	if n.pointer == p {
+		n.pointer = nil
+	} else {
+		if !n.internal {
+			return false
+		}
+
+		cx := (left + right) / 2.0
+		cy := (bottom + top) / 2.0
+
+		// the child of this internal node the point was inserted into.
+		i := childIndex(cx, cy, point)
+		child := n.children[i]
+		if child == nil {
+			return false
+		}
+
+		if i < 2 {
+			bottom = cy
+		} else {
+			top = cy
+		}
+
+		if i%2 == 1 {
+			left = cx
+		} else {
+			right = cx
+		}
+
+		if !q.remove(child, p, point, left, right, bottom, top) {
+			return false
+		}
+
+		if child.pointer == nil && !child.internal {
+			n.children[i] = nil
+			q.releaseNode(child)
+		}
+	}
+
+	q.collapse(n)
+	return true
+}
+
+// collapse turns an internal node back into a leaf if it has no children,
+// or pulls up the pointer of its only child if that child is a leaf.
+func (q *Quadtree) collapse(n *node) {
+	if !n.internal {
+		return
+	}
+
+	count, last := 0, -1
+	for i, c := range n.children {
+		if c != nil {
+			count++
+			last = i
+		}
+	}
+
+	if count == 0 {
+		n.internal = false
+		return
+	}
+
+	if count == 1 && n.pointer == nil {
+		child := n.children[last]
+		if child.internal {
+			return
+		}
+
+		n.pointer = child.pointer
+		n.children[last] = nil
+		n.internal = false
+		q.releaseNode(child)
+	}
+}
+
+// releaseNode adds the node to the list reused by nextNode.
+func (q *Quadtree) releaseNode(n *node) {
+	*n = node{}
+	q.removedNodes = append(q.removedNodes, n)
+}
+
 // Find returns the closest Value/Pointer in the quadtree.
 // This function is thread safe. Multiple goroutines can read from
 // a pre-created tree.
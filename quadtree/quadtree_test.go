@@ -334,3 +334,130 @@ func TestQuadtreeInBoundMatching(t *testing.T) {
 		}
 	}
 }
+
+func TestQuadtreeRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+
+	var pointers []geo.Pointer
+	for i := 0; i < 1000; i++ {
+		pointers = append(pointers, geo.NewPoint(r.Float64(), r.Float64()))
+	}
+
+	// duplicates are stored on internal nodes
+	for i := 0; i < 10; i++ {
+		pointers = append(pointers, geo.NewPoint(0.5, 0.5))
+	}
+
+	qt := New(geo.NewBound(0, 1, 0, 1))
+	for _, p := range pointers {
+		qt.Insert(p)
+	}
+
+	if qt.Remove(geo.NewPoint(0.5, 0.5)) {
+		t.Errorf("should not remove a pointer not in the tree")
+	}
+
+	if qt.Remove(geo.NewPoint(2, 2)) {
+		t.Errorf("should not remove a pointer outside the bound")
+	}
+
+	all := geo.NewBound(0, 1, 0, 1)
+	for i := len(pointers) - 1; i >= 0; i -= 2 {
+		if !qt.Remove(pointers[i]) {
+			t.Fatalf("index: %d, pointer not found", i)
+		}
+
+		if qt.Remove(pointers[i]) {
+			t.Errorf("index: %d, pointer removed twice", i)
+		}
+	}
+
+	if l := len(qt.InBound(all)); l != len(pointers)/2 {
+		t.Errorf("expected %d pointers, got %d", len(pointers)/2, l)
+	}
+
+	for i := 0; i < len(pointers); i += 2 {
+		if v := qt.Find(pointers[i].Point()); v.Point().DistanceFrom(pointers[i].Point()) != 0 {
+			t.Errorf("index: %d, should still find the pointer, got %v", i, v)
+		}
+
+		if !qt.Remove(pointers[i]) {
+			t.Fatalf("index: %d, pointer not found", i)
+		}
+	}
+
+	if l := len(qt.InBound(all)); l != 0 {
+		t.Errorf("expected empty tree, got %d pointers", l)
+	}
+
+	// everything collapsed back into the root
+	if qt.root.internal || qt.root.pointer != nil {
+		t.Errorf("root should be an empty leaf")
+	}
+
+	if len(qt.removedNodes) == 0 {
+		t.Errorf("removed nodes should be available for reuse")
+	}
+
+	// removed nodes are reused
+	count := len(qt.removedNodes)
+	for _, p := range pointers[:10] {
+		qt.Insert(p)
+	}
+
+	if len(qt.removedNodes) >= count {
+		t.Errorf("removed nodes should be reused")
+	}
+
+	if l := len(qt.InBound(all)); l != 10 {
+		t.Errorf("expected 10 pointers, got %d", l)
+	}
+}
+
+func TestQuadtreeMove(t *testing.T) {
+	r := rand.New(rand.NewSource(45))
+
+	var pointers []geo.Pointer
+	for i := 0; i < 500; i++ {
+		pointers = append(pointers, geo.NewPoint(r.Float64(), r.Float64()))
+	}
+	qt := NewFromPointers(pointers)
+	bound := qt.Bound().Clone()
+
+	for i := 0; i < 2000; i++ {
+		p := pointers[r.Intn(len(pointers))]
+		to := geo.NewPoint(
+			bound.Left()+r.Float64()*bound.Width(),
+			bound.Bottom()+r.Float64()*bound.Height(),
+		)
+
+		if !qt.Move(p, to) {
+			t.Fatalf("index: %d, pointer not found", i)
+		}
+
+		if !p.Point().Equals(to) {
+			t.Errorf("index: %d, point should be updated, got %v", i, p.Point())
+		}
+
+		if v := qt.Find(to); v != p {
+			t.Errorf("index: %d, should find moved pointer, got %v", i, v)
+		}
+	}
+
+	if l := len(qt.InBound(bound)); l != len(pointers) {
+		t.Errorf("expected %d pointers, got %d", len(pointers), l)
+	}
+
+	p := pointers[0]
+	if qt.Move(p, geo.NewPoint(5, 5)) {
+		t.Errorf("should not move outside the bound")
+	}
+
+	if l := len(qt.InBound(bound)); l != len(pointers) {
+		t.Errorf("failed move should not change the tree, got %d pointers", l)
+	}
+
+	if qt.Move(geo.NewPoint(0.5, 0.5), geo.NewPoint(0.1, 0.1)) {
+		t.Errorf("should not move a pointer not in the tree")
+	}
+}
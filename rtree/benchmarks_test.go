@@ -0,0 +1,75 @@
+package rtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/paulmach/go.geo"
+)
+
+func BenchmarkInsert(b *testing.B) {
+	r := rand.New(rand.NewSource(22))
+	items := randomLines(r, b.N)
+	tree := New()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Insert(items[i])
+	}
+}
+
+func BenchmarkFromItems1000(b *testing.B) {
+	r := rand.New(rand.NewSource(32))
+	items := toBounders(randomLines(r, 1000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewFromItems(items)
+	}
+}
+
+func BenchmarkRandomIntersecting1000(b *testing.B) {
+	r := rand.New(rand.NewSource(43))
+	tree := NewFromItems(toBounders(randomLines(r, 1000)))
+
+	var buf []Bounder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := geo.NewPoint(r.Float64(), r.Float64())
+		buf = tree.Intersecting(geo.NewBoundFromPoints(p, p).Pad(0.1), buf)
+	}
+}
+
+func BenchmarkRandomIntersecting1000Naive(b *testing.B) {
+	r := rand.New(rand.NewSource(43))
+	items := randomLines(r, 1000)
+
+	var buf []Bounder
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := geo.NewPoint(r.Float64(), r.Float64())
+		bound := geo.NewBoundFromPoints(p, p).Pad(0.1)
+
+		buf = buf[:0]
+		for _, item := range items {
+			if item.Bound().Intersects(bound) {
+				buf = append(buf, item)
+			}
+		}
+	}
+}
+
+func BenchmarkRandomFindKNearest1000(b *testing.B) {
+	r := rand.New(rand.NewSource(43))
+	tree := NewFromItems(toBounders(randomLines(r, 1000)))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.FindKNearest(geo.NewPoint(r.Float64(), r.Float64()), 5)
+	}
+}
@@ -0,0 +1,44 @@
+package rtree_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/paulmach/go.geo"
+	"github.com/paulmach/go.geo/rtree"
+)
+
+func ExampleRTree_Intersecting() {
+	r := rand.New(rand.NewSource(42)) // to make things reproducible
+
+	tree := rtree.New()
+
+	// insert 1000 random short lines
+	for i := 0; i < 1000; i++ {
+		a := geo.NewPoint(r.Float64(), r.Float64())
+		tree.Insert(geo.NewLine(a, geo.NewPoint(a.X()+0.01, a.Y()+0.01)))
+	}
+
+	intersecting := tree.Intersecting(geo.NewBound(0.5, 0.5, 0.5, 0.5).Pad(0.05))
+	fmt.Printf("intersecting: %v\n", len(intersecting))
+
+	// Output:
+	// intersecting: 15
+}
+
+func ExampleRTree_FindKNearest() {
+	tree := rtree.NewFromItems([]rtree.Bounder{
+		geo.NewLine(geo.NewPoint(0, 0), geo.NewPoint(1, 0)),
+		geo.NewLine(geo.NewPoint(0, 2), geo.NewPoint(1, 2)),
+		geo.NewLine(geo.NewPoint(0, 5), geo.NewPoint(1, 5)),
+	})
+
+	nearest := tree.FindKNearest(geo.NewPoint(0.5, 1.8), 2)
+	for _, item := range nearest {
+		fmt.Printf("nearest: %v\n", item)
+	}
+
+	// Output:
+	// nearest: LINESTRING(0 2,1 2)
+	// nearest: LINESTRING(0 0,1 0)
+}
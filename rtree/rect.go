@@ -0,0 +1,83 @@
+package rtree
+
+import (
+	"math"
+
+	"github.com/paulmach/go.geo"
+)
+
+// rect is the bound of an entry. Storing the values directly,
+// rather than a *geo.Bound, saves memory and pointer chasing.
+type rect struct {
+	minX, minY, maxX, maxY float64
+}
+
+func newRect(b *geo.Bound) rect {
+	return rect{b.Left(), b.Bottom(), b.Right(), b.Top()}
+}
+
+// bound returns the rect containing all the entries.
+func bound(entries []entry) rect {
+	if len(entries) == 0 {
+		return rect{}
+	}
+
+	r := entries[0].rect
+	for _, e := range entries[1:] {
+		r = r.union(e.rect)
+	}
+
+	return r
+}
+
+func (r rect) union(o rect) rect {
+	return rect{
+		math.Min(r.minX, o.minX), math.Min(r.minY, o.minY),
+		math.Max(r.maxX, o.maxX), math.Max(r.maxY, o.maxY),
+	}
+}
+
+func (r rect) area() float64 {
+	return (r.maxX - r.minX) * (r.maxY - r.minY)
+}
+
+// margin returns half the perimeter of the rect.
+func (r rect) margin() float64 {
+	return (r.maxX - r.minX) + (r.maxY - r.minY)
+}
+
+// overlap returns the area of the intersection of the rects.
+func (r rect) overlap(o rect) float64 {
+	dx := math.Min(r.maxX, o.maxX) - math.Max(r.minX, o.minX)
+	dy := math.Min(r.maxY, o.maxY) - math.Max(r.minY, o.minY)
+	if dx <= 0 || dy <= 0 {
+		return 0
+	}
+
+	return dx * dy
+}
+
+func (r rect) intersects(o rect) bool {
+	return r.minX <= o.maxX && o.minX <= r.maxX &&
+		r.minY <= o.maxY && o.minY <= r.maxY
+}
+
+func (r rect) contains(o rect) bool {
+	return r.minX <= o.minX && o.maxX <= r.maxX &&
+		r.minY <= o.minY && o.maxY <= r.maxY
+}
+
+// centerDistance returns the squared distance from the center of the rect to the point.
+func (r rect) centerDistance(x, y float64) float64 {
+	dx := (r.minX+r.maxX)/2 - x
+	dy := (r.minY+r.maxY)/2 - y
+	return dx*dx + dy*dy
+}
+
+// squaredDistance returns the squared distance from the point to the closest
+// point of the rect, zero if the point is inside.
+func (r rect) squaredDistance(x, y float64) float64 {
+	dx := math.Max(math.Max(r.minX-x, x-r.maxX), 0)
+	dy := math.Max(math.Max(r.minY-y, y-r.maxY), 0)
+	return dx*dx + dy*dy
+}
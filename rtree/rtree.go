@@ -0,0 +1,635 @@
+// Package rtree implements an R*-tree, a spatial index of items by their bound.
+// Items can be inserted one at a time, using the R* heuristics to keep the
+// tree balanced, or bulk loaded using Sort-Tile-Recursive packing. The tree can be
+// searched by bound, by point and by distance to a point.
+// Bounds are treated as rectangles in the plane, so bounds crossing the anti-meridian
+// should be split first, see geo.Bound.SplitAtAntimeridian.
+package rtree
+
+import (
+	"container/heap"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/paulmach/go.geo"
+)
+
+// A Bounder is the interface for something with an extent,
+// such as a geo.Line, geo.Path, geo.PointSet or geo.Bound.
+type Bounder interface {
+	// Bound is called when the item is inserted and removed,
+	// so it should not change while the item is in the tree.
+	Bound() *geo.Bound
+}
+
+// DefaultMaxEntries is the maximum number of entries in a node
+// if no other value is given to New or NewFromItems.
+const DefaultMaxEntries = 16
+
+// RTree indexes Bounders by their bound. The zero value is not valid,
+// create trees using New or NewFromItems.
+type RTree struct {
+	maxEntries int
+	minEntries int
+
+	root *node
+	size int
+
+	// the levels, from the leaves, where entries have been removed and
+	// reinserted during the current insert, as R* only does it once per level.
+	reinserted []bool
+	pending    []pendingEntry
+}
+
+// node represents a node of the tree. Leaves are at level 0
+// and store items, the other nodes store their child nodes.
+type node struct {
+	level   int
+	entries []entry
+}
+
+type entry struct {
+	rect  rect
+	child *node
+	item  Bounder
+}
+
+// pendingEntry is an entry waiting to be inserted into a node at the level.
+type pendingEntry struct {
+	entry entry
+	level int
+}
+
+// New creates a new empty tree. The maximum number of entries in a node can
+// be given, the default is DefaultMaxEntries and the smallest allowed is 4.
+func New(maxEntries ...int) *RTree {
+	max := DefaultMaxEntries
+	if len(maxEntries) > 0 {
+		max = maxEntries[0]
+	}
+
+	if max < 4 {
+		max = 4
+	}
+
+	// 40% was found to be best in the R* paper.
+	return &RTree{
+		maxEntries: max,
+		minEntries: int(math.Ceil(0.4 * float64(max))),
+	}
+}
+
+// NewFromItems creates a tree with the items using Sort-Tile-Recursive bulk loading.
+// This is much faster than inserting the items one at a time and gives a tree
+// with better query performance. Nil items are ignored.
+func NewFromItems(items []Bounder, maxEntries ...int) *RTree {
+	t := New(maxEntries...)
+
+	entries := make([]entry, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		entries = append(entries, entry{rect: newRect(item.Bound()), item: item})
+	}
+
+	if len(entries) == 0 {
+		return t
+	}
+
+	t.size = len(entries)
+	for level := 0; ; level++ {
+		nodes := t.pack(entries, level)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			return t
+		}
+
+		entries = make([]entry, len(nodes))
+		for i, n := range nodes {
+			entries[i] = entry{rect: n.bound(), child: n}
+		}
+	}
+}
+
+// pack groups the entries into nodes at the level. The entries are sorted into
+// vertical slices by the x of their centers, then each slice is sorted by y and
+// cut into nodes. Entries are spread evenly so nodes are about the same size.
+func (t *RTree) pack(entries []entry, level int) []*node {
+	count := (len(entries) + t.maxEntries - 1) / t.maxEntries
+	slices := int(math.Ceil(math.Sqrt(float64(count))))
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].rect.minX+entries[i].rect.maxX < entries[j].rect.minX+entries[j].rect.maxX
+	})
+
+	nodes := make([]*node, 0, count)
+	for s := 0; s < slices; s++ {
+		slice := entries[s*len(entries)/slices : (s+1)*len(entries)/slices]
+		sort.Slice(slice, func(i, j int) bool {
+			return slice[i].rect.minY+slice[i].rect.maxY < slice[j].rect.minY+slice[j].rect.maxY
+		})
+
+		n := (len(slice) + t.maxEntries - 1) / t.maxEntries
+		for i := 0; i < n; i++ {
+			group := slice[i*len(slice)/n : (i+1)*len(slice)/n]
+
+			node := &node{level: level, entries: make([]entry, len(group), t.maxEntries+1)}
+			copy(node.entries, group)
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// Len returns the number of items in the tree.
+func (t *RTree) Len() int {
+	return t.size
+}
+
+// Bound returns the bound of all the items in the tree, nil if the tree is empty.
+func (t *RTree) Bound() *geo.Bound {
+	if t.root == nil || len(t.root.entries) == 0 {
+		return nil
+	}
+
+	r := t.root.bound()
+	return geo.NewBound(r.minX, r.maxX, r.minY, r.maxY)
+}
+
+// Insert adds the item to the tree. Nil items are ignored.
+// This function is not thread-safe, ie. multiple goroutines cannot insert into
+// a single tree.
+func (t *RTree) Insert(item Bounder) {
+	if item == nil {
+		return
+	}
+
+	level := 0
+	if t.root != nil {
+		level = t.root.level
+	}
+
+	t.reinserted = t.reinserted[:0]
+	for i := 0; i <= level; i++ {
+		t.reinserted = append(t.reinserted, false)
+	}
+
+	t.insertEntry(entry{rect: newRect(item.Bound()), item: item}, 0)
+	t.size++
+
+	// entries removed for reinsertion, the closest to the center of
+	// their old node first, see overflow.
+	for i := 0; i < len(t.pending); i++ {
+		t.insertEntry(t.pending[i].entry, t.pending[i].level)
+		t.pending[i] = pendingEntry{}
+	}
+	t.pending = t.pending[:0]
+}
+
+// insertEntry adds the entry to a node at the given level, growing the tree if the root splits.
+func (t *RTree) insertEntry(e entry, level int) {
+	if t.root == nil {
+		t.root = &node{entries: make([]entry, 0, t.maxEntries+1)}
+	}
+
+	if level > t.root.level {
+		// the tree got shorter, after a remove, than the node these
+		// entries came from so their children are added instead.
+		for _, c := range e.child.entries {
+			t.insertEntry(c, level-1)
+		}
+		return
+	}
+
+	split := t.insert(t.root, e, level)
+	if split == nil {
+		return
+	}
+
+	root := &node{level: t.root.level + 1, entries: make([]entry, 2, t.maxEntries+1)}
+	root.entries[0] = entry{rect: t.root.bound(), child: t.root}
+	root.entries[1] = entry{rect: split.bound(), child: split}
+	t.root = root
+}
+
+// insert adds the entry to the subtree of n, returning the new sibling if n was split.
+func (t *RTree) insert(n *node, e entry, level int) *node {
+	if n.level == level {
+		n.entries = append(n.entries, e)
+	} else {
+		i := chooseSubtree(n, e.rect)
+		child := n.entries[i].child
+
+		split := t.insert(child, e, level)
+		n.entries[i].rect = child.bound()
+
+		if split != nil {
+			n.entries = append(n.entries, entry{rect: split.bound(), child: split})
+		}
+	}
+
+	if len(n.entries) <= t.maxEntries {
+		return nil
+	}
+
+	return t.overflow(n)
+}
+
+// overflow handles a node with too many entries. The first time at each level during
+// an insert some of the entries furthest from the center are removed and reinserted,
+// which often finds them a better node. Otherwise the node is split.
+func (t *RTree) overflow(n *node) *node {
+	if n == t.root || n.level >= len(t.reinserted) || t.reinserted[n.level] {
+		return t.split(n)
+	}
+
+	t.reinserted[n.level] = true
+
+	r := n.bound()
+	cx, cy := (r.minX+r.maxX)/2, (r.minY+r.maxY)/2
+
+	sort.Slice(n.entries, func(i, j int) bool {
+		return n.entries[i].rect.centerDistance(cx, cy) < n.entries[j].rect.centerDistance(cx, cy)
+	})
+
+	// 30% was found to be best in the R* paper.
+	count := int(math.Ceil(0.3 * float64(t.maxEntries)))
+	keep := len(n.entries) - count
+
+	for i := keep; i < len(n.entries); i++ {
+		t.pending = append(t.pending, pendingEntry{n.entries[i], n.level})
+		n.entries[i] = entry{}
+	}
+	n.entries = n.entries[:keep]
+
+	return nil
+}
+
+// split divides the entries of the node in two, returning the new node.
+// The axis is chosen by the smallest total margin, or perimeter, of the possible
+// distributions, then the distribution with the least overlap is used.
+func (t *RTree) split(n *node) *node {
+	entries := n.entries
+	sorts := [4]func(i, j int) bool{
+		func(i, j int) bool { return entries[i].rect.minX < entries[j].rect.minX },
+		func(i, j int) bool { return entries[i].rect.maxX < entries[j].rect.maxX },
+		func(i, j int) bool { return entries[i].rect.minY < entries[j].rect.minY },
+		func(i, j int) bool { return entries[i].rect.maxY < entries[j].rect.maxY },
+	}
+
+	// the entries are sorted by both the min and max values along each axis.
+	axis, bestMargin := 0, math.Inf(1)
+	for a := 0; a < 2; a++ {
+		margin := 0.0
+		for _, s := range sorts[2*a : 2*a+2] {
+			sort.Slice(entries, s)
+			for k := t.minEntries; k <= len(entries)-t.minEntries; k++ {
+				margin += bound(entries[:k]).margin() + bound(entries[k:]).margin()
+			}
+		}
+
+		if margin < bestMargin {
+			axis, bestMargin = a, margin
+		}
+	}
+
+	bestSort, bestK := 2*axis, t.minEntries
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+	for i := 2 * axis; i < 2*axis+2; i++ {
+		sort.Slice(entries, sorts[i])
+		for k := t.minEntries; k <= len(entries)-t.minEntries; k++ {
+			a, b := bound(entries[:k]), bound(entries[k:])
+
+			overlap, area := a.overlap(b), a.area()+b.area()
+			if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+				bestSort, bestK = i, k
+				bestOverlap, bestArea = overlap, area
+			}
+		}
+	}
+
+	sort.Slice(entries, sorts[bestSort])
+
+	sibling := &node{level: n.level, entries: make([]entry, len(entries)-bestK, t.maxEntries+1)}
+	copy(sibling.entries, entries[bestK:])
+
+	for i := bestK; i < len(entries); i++ {
+		entries[i] = entry{}
+	}
+	n.entries = entries[:bestK]
+
+	return sibling
+}
+
+// chooseSubtree returns the index of the entry of the node to insert the rect into.
+// Above the leaves this is the entry needing the least enlargement, while for
+// the nodes just above the leaves the least increase in overlap with the other entries.
+func chooseSubtree(n *node, r rect) int {
+	best := 0
+	bestOverlap, bestEnlargement, bestArea := math.Inf(1), math.Inf(1), math.Inf(1)
+
+	for i, e := range n.entries {
+		union := e.rect.union(r)
+		area := e.rect.area()
+		enlargement := union.area() - area
+
+		overlap := 0.0
+		if n.level == 1 {
+			for j, o := range n.entries {
+				if i != j {
+					overlap += union.overlap(o.rect) - e.rect.overlap(o.rect)
+				}
+			}
+		}
+
+		if overlap < bestOverlap ||
+			(overlap == bestOverlap && enlargement < bestEnlargement) ||
+			(overlap == bestOverlap && enlargement == bestEnlargement && area < bestArea) {
+			best = i
+			bestOverlap, bestEnlargement, bestArea = overlap, enlargement, area
+		}
+	}
+
+	return best
+}
+
+// Remove deletes the item from the tree, returning false if it was not found.
+// Items are compared using ==, so items of uncomparable types, such as geo.PointSet
+// or geo.Polygon, are never found and must be removed using RemoveFunc.
+// The item is found using its bound so it must not have changed since the item was inserted.
+// This function is not thread-safe.
+func (t *RTree) Remove(item Bounder) bool {
+	if item == nil || !reflect.TypeOf(item).Comparable() {
+		return false
+	}
+
+	return t.RemoveFunc(item, func(a, b Bounder) bool { return a == b })
+}
+
+// RemoveFunc deletes the first item in the tree for which equal returns true,
+// returning false if none was found. The items are found using the bound of the given item,
+// which is the first argument of equal, the item in the tree is the second.
+// This function is not thread-safe.
+func (t *RTree) RemoveFunc(item Bounder, equal func(a, b Bounder) bool) bool {
+	if item == nil || t.root == nil {
+		return false
+	}
+
+	var orphans []*node
+	if !t.remove(t.root, item, equal, newRect(item.Bound()), &orphans) {
+		return false
+	}
+	t.size--
+
+	// shorten the tree if the root has only one child.
+	for t.root.level > 0 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+
+	if len(t.root.entries) == 0 {
+		t.root = nil
+	}
+
+	// the entries of nodes with too few entries are reinserted at the same level,
+	// without the R* reinsertion as this is not the insert of a new item.
+	t.reinserted = t.reinserted[:0]
+	for _, o := range orphans {
+		for _, e := range o.entries {
+			t.insertEntry(e, o.level)
+		}
+	}
+
+	return true
+}
+
+// remove deletes the item from the subtree of n, adding child nodes
+// left with too few entries to the orphans.
+func (t *RTree) remove(n *node, item Bounder, equal func(a, b Bounder) bool, r rect, orphans *[]*node) bool {
+	for i := range n.entries {
+		e := &n.entries[i]
+		if !e.rect.contains(r) {
+			continue
+		}
+
+		if n.level == 0 {
+			if !equal(item, e.item) {
+				continue
+			}
+
+			n.removeEntry(i)
+			return true
+		}
+
+		if !t.remove(e.child, item, equal, r, orphans) {
+			continue
+		}
+
+		if len(e.child.entries) < t.minEntries {
+			*orphans = append(*orphans, e.child)
+			n.removeEntry(i)
+		} else {
+			e.rect = e.child.bound()
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// removeEntry deletes the entry at the index, the order of the entries is not kept.
+func (n *node) removeEntry(i int) {
+	last := len(n.entries) - 1
+	n.entries[i] = n.entries[last]
+	n.entries[last] = entry{}
+	n.entries = n.entries[:last]
+}
+
+// Intersecting returns the items whose bound intersects the given bound.
+// An optional buffer parameter is provided to allow for the reuse of result slice memory.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) Intersecting(b *geo.Bound, buf ...[]Bounder) []Bounder {
+	return t.search(newRect(b), intersects, buf)
+}
+
+// InBound returns the items whose bound is completely within the given bound.
+// An optional buffer parameter is provided to allow for the reuse of result slice memory.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) InBound(b *geo.Bound, buf ...[]Bounder) []Bounder {
+	return t.search(newRect(b), within, buf)
+}
+
+// Containing returns the items whose bound contains the point. For example the
+// polygons the point may be in, the polygons themselves then need to be checked.
+// An optional buffer parameter is provided to allow for the reuse of result slice memory.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) Containing(p *geo.Point, buf ...[]Bounder) []Bounder {
+	return t.search(rect{p.X(), p.Y(), p.X(), p.Y()}, contains, buf)
+}
+
+func (t *RTree) search(r rect, m match, buf [][]Bounder) []Bounder {
+	var result []Bounder
+	if len(buf) > 0 {
+		result = buf[0][:0]
+	}
+
+	if t.root != nil {
+		t.root.each(r, m, func(item Bounder) bool {
+			result = append(result, item)
+			return true
+		})
+	}
+
+	return result
+}
+
+// Each calls the function for every item in the tree, stopping if it returns false.
+// No memory is allocated so this is the fastest way to go through all the items.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) Each(f func(item Bounder) bool) {
+	if t.root != nil {
+		t.root.each(rect{}, all, f)
+	}
+}
+
+// EachIntersecting calls the function for the items whose bound intersects the given bound,
+// stopping if it returns false. Unlike Intersecting no memory is allocated.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) EachIntersecting(b *geo.Bound, f func(item Bounder) bool) {
+	if t.root != nil {
+		t.root.each(newRect(b), intersects, f)
+	}
+}
+
+// match is the relation between the bounds of the items and
+// the search rect for an item to be in the results.
+type match int
+
+const (
+	all match = iota
+	intersects
+	within
+	contains
+)
+
+// each calls the function for the matching items of the subtree, returning false
+// if the function returned false to stop the search.
+func (n *node) each(r rect, m match, f func(item Bounder) bool) bool {
+	for i := range n.entries {
+		e := &n.entries[i]
+
+		switch m {
+		case intersects, within:
+			if !e.rect.intersects(r) {
+				continue
+			}
+		case contains:
+			if !e.rect.contains(r) {
+				continue
+			}
+		}
+
+		if n.level > 0 {
+			if !e.child.each(r, m, f) {
+				return false
+			}
+			continue
+		}
+
+		if m == within && !r.contains(e.rect) {
+			continue
+		}
+
+		if !f(e.item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Find returns the item with the closest bound to the point, nil if the tree is empty.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+func (t *RTree) Find(p *geo.Point) Bounder {
+	if result := t.FindKNearest(p, 1); len(result) > 0 {
+		return result[0]
+	}
+
+	return nil
+}
+
+// FindKNearest returns the k items with the closest bounds to the point, nearest first.
+// Items whose bound contains the point have a distance of zero.
+// This function is thread safe. Multiple goroutines can read from a pre-created tree.
+// This function allows defining a maximum distance in order to reduce search iterations.
+func (t *RTree) FindKNearest(p *geo.Point, k int, maxDistance ...float64) []Bounder {
+	if t.root == nil || k <= 0 {
+		return nil
+	}
+
+	maxDistSquared := math.Inf(1)
+	if len(maxDistance) > 0 {
+		maxDistSquared = maxDistance[0] * maxDistance[0]
+	}
+
+	// nodes and items are visited in order of distance, so
+	// the items come out of the queue nearest first.
+	x, y := p.X(), p.Y()
+	queue := &entriesQueue{}
+	for _, e := range t.root.entries {
+		heap.Push(queue, entriesQueueItem{e, e.rect.squaredDistance(x, y)})
+	}
+
+	result := make([]Bounder, 0, k)
+	for queue.Len() > 0 && len(result) < k {
+		next := heap.Pop(queue).(entriesQueueItem)
+		if next.distance > maxDistSquared {
+			break
+		}
+
+		if next.entry.child == nil {
+			result = append(result, next.entry.item)
+			continue
+		}
+
+		for _, e := range next.entry.child.entries {
+			if d := e.rect.squaredDistance(x, y); d <= maxDistSquared {
+				heap.Push(queue, entriesQueueItem{e, d})
+			}
+		}
+	}
+
+	return result
+}
+
+type entriesQueueItem struct {
+	entry    entry
+	distance float64 // squared distance to the point and priority inside the queue
+}
+
+type entriesQueue []entriesQueueItem
+
+func (q entriesQueue) Len() int           { return len(q) }
+func (q entriesQueue) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q entriesQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *entriesQueue) Push(x interface{}) {
+	*q = append(*q, x.(entriesQueueItem))
+}
+
+func (q *entriesQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+	return item
+}
+
+// bound returns the rect containing all the entries of the node.
+func (n *node) bound() rect {
+	return bound(n.entries)
+}
@@ -0,0 +1,410 @@
+package rtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/paulmach/go.geo"
+)
+
+func TestNew(t *testing.T) {
+	tree := New()
+	if tree.maxEntries != DefaultMaxEntries || tree.minEntries != 7 {
+		t.Errorf("should use default entries, got %d %d", tree.maxEntries, tree.minEntries)
+	}
+
+	if tree.Len() != 0 || tree.Bound() != nil {
+		t.Errorf("should be empty")
+	}
+
+	tree = New(2)
+	if tree.maxEntries != 4 || tree.minEntries != 2 {
+		t.Errorf("should have at least 4 entries, got %d %d", tree.maxEntries, tree.minEntries)
+	}
+
+	if tree = NewFromItems(nil); tree.Len() != 0 || tree.root != nil {
+		t.Errorf("should be empty from no items")
+	}
+}
+
+func TestRTreeInsert(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	items := randomLines(r, 1000)
+
+	tree := New(8)
+	for i, item := range items {
+		tree.Insert(item)
+
+		if tree.Len() != i+1 {
+			t.Fatalf("index: %d, expected length %d, got %d", i, i+1, tree.Len())
+		}
+	}
+
+	tree.Insert(nil)
+	if tree.Len() != len(items) {
+		t.Errorf("should ignore nil items")
+	}
+
+	checkTree(t, tree, true)
+
+	expected := geo.NewBoundFromPoints(items[0].A(), items[0].B())
+	for _, item := range items {
+		expected.Union(item.Bound())
+	}
+
+	if !tree.Bound().Equals(expected) {
+		t.Errorf("bound expected %v, got %v", expected, tree.Bound())
+	}
+}
+
+func TestNewFromItems(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	for _, count := range []int{1, 10, 16, 17, 1000, 5000} {
+		tree := NewFromItems(toBounders(randomLines(r, count)))
+		if tree.Len() != count {
+			t.Errorf("expected length %d, got %d", count, tree.Len())
+		}
+
+		checkTree(t, tree, false)
+
+		n := 0
+		tree.Each(func(Bounder) bool {
+			n++
+			return true
+		})
+
+		if n != count {
+			t.Errorf("expected %d items, got %d", count, n)
+		}
+	}
+}
+
+func TestRTreeSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+	items := randomLines(r, 1000)
+
+	inserted := New()
+	for _, item := range items {
+		inserted.Insert(item)
+	}
+
+	for name, tree := range map[string]*RTree{
+		"insert": inserted,
+		"bulk":   NewFromItems(toBounders(items)),
+	} {
+		for i := 0; i < 100; i++ {
+			p := geo.NewPoint(r.Float64(), r.Float64())
+			b := geo.NewBoundFromPoints(p, p).Pad(0.1)
+
+			var intersecting, inBound, containing int
+			for _, item := range items {
+				if item.Bound().Intersects(b) {
+					intersecting++
+				}
+
+				if ib := item.Bound(); b.Contains(ib.SouthWest()) && b.Contains(ib.NorthEast()) {
+					inBound++
+				}
+
+				if item.Bound().Contains(p) {
+					containing++
+				}
+			}
+
+			if l := len(tree.Intersecting(b)); l != intersecting {
+				t.Errorf("%s: index: %d, intersecting expected %d, got %d", name, i, intersecting, l)
+			}
+
+			if l := len(tree.InBound(b)); l != inBound {
+				t.Errorf("%s: index: %d, inBound expected %d, got %d", name, i, inBound, l)
+			}
+
+			if l := len(tree.Containing(p)); l != containing {
+				t.Errorf("%s: index: %d, containing expected %d, got %d", name, i, containing, l)
+			}
+
+			n := 0
+			tree.EachIntersecting(b, func(item Bounder) bool {
+				if !item.Bound().Intersects(b) {
+					t.Errorf("%s: index: %d, item does not intersect", name, i)
+				}
+				n++
+				return true
+			})
+
+			if n != intersecting {
+				t.Errorf("%s: index: %d, eachIntersecting expected %d, got %d", name, i, intersecting, n)
+			}
+		}
+	}
+
+	// reusing the buffer
+	buf := make([]Bounder, 0, 1000)
+	result := inserted.Intersecting(geo.NewBound(0, 1, 0, 1), buf)
+	if len(result) != len(items) || &result[0] != &buf[:1][0] {
+		t.Errorf("should use the buffer")
+	}
+}
+
+func TestRTreeFindKNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(45))
+	items := randomLines(r, 1000)
+	tree := NewFromItems(toBounders(items))
+
+	distance := func(p *geo.Point, item Bounder) float64 {
+		return math.Sqrt(newRect(item.Bound()).squaredDistance(p.X(), p.Y()))
+	}
+
+	for i := 0; i < 100; i++ {
+		p := geo.NewPoint(r.Float64(), r.Float64())
+
+		nearest := tree.FindKNearest(p, 5)
+		if len(nearest) != 5 {
+			t.Fatalf("index: %d, expected 5 items, got %d", i, len(nearest))
+		}
+
+		// find the right answer brute force
+		closer := 0
+		last := distance(p, nearest[4])
+		for _, item := range items {
+			if distance(p, item) < last {
+				closer++
+			}
+		}
+
+		if closer > 4 {
+			t.Errorf("index: %d, expected the 5 nearest, %d are closer", i, closer)
+		}
+
+		for j := 1; j < len(nearest); j++ {
+			if distance(p, nearest[j-1]) > distance(p, nearest[j]) {
+				t.Errorf("index: %d, expected nearest first", i)
+			}
+		}
+
+		if f := tree.Find(p); distance(p, f) != distance(p, nearest[0]) {
+			t.Errorf("index: %d, find should be the nearest", i)
+		}
+
+		for _, item := range tree.FindKNearest(p, 100, 0.05) {
+			if distance(p, item) > 0.05 {
+				t.Errorf("index: %d, item further than max distance", i)
+			}
+		}
+	}
+
+	if n := New().FindKNearest(geo.NewPoint(0, 0), 3); n != nil {
+		t.Errorf("empty tree should find nothing, got %v", n)
+	}
+
+	if n := tree.FindKNearest(geo.NewPoint(0, 0), 2000); len(n) != len(items) {
+		t.Errorf("expected all the items, got %d", len(n))
+	}
+}
+
+func TestRTreeRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(46))
+	items := randomLines(r, 1000)
+
+	inserted := New(6)
+	for _, item := range items {
+		inserted.Insert(item)
+	}
+
+	for name, tree := range map[string]*RTree{
+		"insert": inserted,
+		"bulk":   NewFromItems(toBounders(items), 6),
+	} {
+		if tree.Remove(geo.NewLine(geo.NewPoint(0.5, 0.5), geo.NewPoint(0.6, 0.6))) {
+			t.Errorf("%s: should not remove an item not in the tree", name)
+		}
+
+		for i := 0; i < len(items); i += 2 {
+			if !tree.Remove(items[i]) {
+				t.Fatalf("%s: index: %d, item not found", name, i)
+			}
+
+			if tree.Remove(items[i]) {
+				t.Errorf("%s: index: %d, item removed twice", name, i)
+			}
+		}
+
+		if tree.Len() != len(items)/2 {
+			t.Errorf("%s: expected length %d, got %d", name, len(items)/2, tree.Len())
+		}
+
+		checkTree(t, tree, name == "insert")
+
+		b := geo.NewBound(0.2, 0.6, 0.3, 0.5)
+		expected := 0
+		for i := 1; i < len(items); i += 2 {
+			if items[i].Bound().Intersects(b) {
+				expected++
+			}
+		}
+
+		if l := len(tree.Intersecting(b)); l != expected {
+			t.Errorf("%s: intersecting expected %d, got %d", name, expected, l)
+		}
+
+		for i := 1; i < len(items); i += 2 {
+			if !tree.Remove(items[i]) {
+				t.Fatalf("%s: index: %d, item not found", name, i)
+			}
+		}
+
+		if tree.Len() != 0 || tree.Bound() != nil || tree.root != nil {
+			t.Errorf("%s: should be empty", name)
+		}
+
+		// can be used again
+		tree.Insert(items[0])
+		if l := len(tree.Intersecting(items[0].Bound())); l != 1 {
+			t.Errorf("%s: expected 1 item, got %d", name, l)
+		}
+	}
+}
+
+func TestRTreeRemoveFunc(t *testing.T) {
+	// point sets are slices, so can not be compared with ==
+	items := []Bounder{
+		geo.PointSet{{0, 0}, {1, 1}},
+		geo.PointSet{{0, 0}, {1, 1}, {0.5, 0.2}},
+		geo.PointSet{{2, 2}, {3, 3}},
+	}
+
+	tree := NewFromItems(items)
+	if tree.Remove(items[0]) {
+		t.Errorf("should not remove uncomparable items")
+	}
+
+	equal := func(a, b Bounder) bool {
+		ps, ok := b.(geo.PointSet)
+		return ok && a.(geo.PointSet).Equals(&ps)
+	}
+
+	if !tree.RemoveFunc(geo.PointSet{{0, 0}, {1, 1}, {0.5, 0.2}}, equal) {
+		t.Fatalf("item not found")
+	}
+
+	if tree.Len() != 2 {
+		t.Errorf("expected length 2, got %d", tree.Len())
+	}
+
+	if tree.RemoveFunc(items[1], equal) {
+		t.Errorf("item removed twice")
+	}
+
+	if l := tree.Containing(geo.NewPoint(0.5, 0.5)); len(l) != 1 || !equal(items[0], l[0]) {
+		t.Errorf("expected the other item, got %v", l)
+	}
+}
+
+func TestRTreeBounds(t *testing.T) {
+	bounds := []*geo.Bound{
+		geo.NewBound(0, 1, 0, 1),
+		geo.NewBound(2, 3, 2, 3),
+		geo.NewBound(0.5, 2.5, 0.5, 2.5),
+	}
+
+	tree := New()
+	for _, b := range bounds {
+		tree.Insert(b)
+	}
+
+	if l := tree.Containing(geo.NewPoint(0.7, 0.7)); len(l) != 2 {
+		t.Errorf("expected 2 bounds, got %v", l)
+	}
+
+	if !tree.Remove(bounds[2]) {
+		t.Fatalf("bound not found")
+	}
+
+	if l := tree.Containing(geo.NewPoint(0.7, 0.7)); len(l) != 1 || l[0] != bounds[0] {
+		t.Errorf("expected the first bound, got %v", l)
+	}
+}
+
+func TestRTreeEach(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	tree := NewFromItems(toBounders(randomLines(r, 1000)))
+
+	n := 0
+	tree.Each(func(Bounder) bool {
+		n++
+		return n < 10
+	})
+
+	if n != 10 {
+		t.Errorf("should stop when returning false, got %d", n)
+	}
+
+	b := geo.NewBound(0.2, 0.6, 0.3, 0.5)
+	allocs := testing.AllocsPerRun(10, func() {
+		tree.Each(func(Bounder) bool { return true })
+		tree.EachIntersecting(b, func(Bounder) bool { return true })
+	})
+
+	if allocs != 0 {
+		t.Errorf("should not allocate, got %v", allocs)
+	}
+}
+
+// checkTree validates the structure of the tree. Bulk loaded trees
+// can have nodes with less than the minimum number of entries.
+func checkTree(t *testing.T, tree *RTree, minEntries bool) {
+	var check func(n *node)
+	check = func(n *node) {
+		if n != tree.root && len(n.entries) > tree.maxEntries {
+			t.Errorf("node has %d entries, max is %d", len(n.entries), tree.maxEntries)
+		}
+
+		if minEntries && n != tree.root && len(n.entries) < tree.minEntries {
+			t.Errorf("node has %d entries, min is %d", len(n.entries), tree.minEntries)
+		}
+
+		for _, e := range n.entries {
+			if n.level == 0 {
+				if e.child != nil || e.item == nil || e.rect != newRect(e.item.Bound()) {
+					t.Errorf("leaf entry incorrect")
+				}
+				continue
+			}
+
+			if e.child == nil || e.child.level != n.level-1 {
+				t.Fatalf("child entry incorrect")
+			}
+
+			if e.rect != e.child.bound() {
+				t.Errorf("entry rect should be the bound of the child")
+			}
+
+			check(e.child)
+		}
+	}
+
+	if tree.root != nil {
+		check(tree.root)
+	}
+}
+
+func randomLines(r *rand.Rand, count int) []*geo.Line {
+	lines := make([]*geo.Line, 0, count)
+	for i := 0; i < count; i++ {
+		a := geo.NewPoint(r.Float64(), r.Float64())
+		b := geo.NewPoint(a.X()+0.05*r.Float64(), a.Y()+0.05*r.Float64())
+		lines = append(lines, geo.NewLine(a, b))
+	}
+
+	return lines
+}
+
+func toBounders(lines []*geo.Line) []Bounder {
+	result := make([]Bounder, len(lines))
+	for i, l := range lines {
+		result[i] = l
+	}
+
+	return result
+}
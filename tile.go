@@ -0,0 +1,429 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// ErrInvalidQuadkey is returned when a quadkey string contains characters
+// other than the digits 0 to 3 or is longer than 31 levels.
+var ErrInvalidQuadkey = errors.New("go.geo: invalid quadkey")
+
+// A Tile is an online, slippy, map tile in the spherical Mercator projection
+// with the origin in the north west. At zoom Z there are 2^Z tiles in each
+// direction, so X and Y range from 0 to 2^Z - 1.
+type Tile struct {
+	X, Y, Z uint64
+}
+
+// NewTileFromPoint returns the tile containing the lng/lat point at the zoom.
+// Latitudes beyond the limits of the Mercator projection, about 85.05 degrees,
+// are mapped to the top or bottom row.
+func NewTileFromPoint(p *Point, zoom uint64) Tile {
+	fx, fy := tileFraction(p, zoom)
+	n := float64(uint64(1) << zoom)
+
+	return Tile{
+		X: tileIndex(fx, n),
+		Y: tileIndex(fy, n),
+		Z: zoom,
+	}
+}
+
+// NewTileFromQuadkey creates a tile from a quadkey and its level.
+// See http://msdn.microsoft.com/en-us/library/bb259689.aspx for more information
+// about this coordinate system.
+func NewTileFromQuadkey(key int64, level int) Tile {
+	var x, y uint64
+
+	var i uint
+	for i = 0; i < uint(level); i++ {
+		x |= uint64(key&(1<<(2*i))) >> i
+		y |= uint64(key&(1<<(2*i+1))) >> (i + 1)
+	}
+
+	return Tile{x, y, uint64(level)}
+}
+
+// NewTileFromQuadkeyString creates a tile from a quadkey string,
+// the level of the tile is the length of the string.
+// Returns ErrInvalidQuadkey if the string can not be parsed.
+func NewTileFromQuadkeyString(key string) (Tile, error) {
+	// the quadkey of a deeper tile does not fit in an int64, see Tile.Quadkey
+	if len(key) > 31 {
+		return Tile{}, ErrInvalidQuadkey
+	}
+
+	t := Tile{Z: uint64(len(key))}
+	for i := 0; i < len(key); i++ {
+		// characters before '0' wrap around to large values
+		d := key[i] - '0'
+		if d > 3 {
+			return Tile{}, ErrInvalidQuadkey
+		}
+
+		t.X = t.X<<1 | uint64(d&1)
+		t.Y = t.Y<<1 | uint64(d>>1)
+	}
+
+	return t, nil
+}
+
+// Quadkey returns the quadkey of the tile, see Point.Quadkey.
+func (t Tile) Quadkey() int64 {
+	var i uint
+	var result uint64
+	for i = 0; i < uint(t.Z); i++ {
+		result |= (t.X & (1 << i)) << i
+		result |= (t.Y & (1 << i)) << (i + 1)
+	}
+
+	return int64(result)
+}
+
+// QuadkeyString returns the quadkey of the tile in string form, see Point.QuadkeyString.
+func (t Tile) QuadkeyString() string {
+	if t.Z == 0 {
+		return ""
+	}
+
+	s := strconv.FormatInt(t.Quadkey(), 4)
+
+	// for zero padding
+	zeros := "0000000000000000000000000000000000000000000000000000000000000000"
+	return zeros[:int(t.Z)-len(s)] + s
+}
+
+// Valid returns true if the x and y indexes are in range for the zoom.
+func (t Tile) Valid() bool {
+	max := uint64(1) << t.Z
+	return t.X < max && t.Y < max
+}
+
+// Bound returns the lng/lat bound of the tile, see NewBoundFromMapTile.
+func (t Tile) Bound() *Bound {
+	return NewBoundFromMapTile(t.X, t.Y, t.Z)
+}
+
+// Center returns the lng/lat center of the tile in the Mercator projection.
+func (t Tile) Center() *Point {
+	return t.pixelInverse(0.5, 0.5, 1)
+}
+
+// Parent returns the tile at the previous zoom containing this tile.
+// The parent of the zoom 0 tile is itself.
+func (t Tile) Parent() Tile {
+	if t.Z == 0 {
+		return t
+	}
+
+	return Tile{t.X >> 1, t.Y >> 1, t.Z - 1}
+}
+
+// Children returns the four tiles at the next zoom within this tile,
+// in quadkey order: north west, north east, south west, south east.
+func (t Tile) Children() [4]Tile {
+	x, y, z := t.X<<1, t.Y<<1, t.Z+1
+	return [4]Tile{
+		{x, y, z},
+		{x + 1, y, z},
+		{x, y + 1, z},
+		{x + 1, y + 1, z},
+	}
+}
+
+// Siblings returns the other three tiles with the same parent.
+// The zoom 0 tile has no siblings.
+func (t Tile) Siblings() []Tile {
+	if t.Z == 0 {
+		return nil
+	}
+
+	siblings := make([]Tile, 0, 3)
+	for _, c := range t.Parent().Children() {
+		if c != t {
+			siblings = append(siblings, c)
+		}
+	}
+
+	return siblings
+}
+
+// Pixel returns the pixel coordinates of the lng/lat point within the tile,
+// with the origin in the north west corner of the tile and the tile being
+// extent pixels wide, for example 256 for images or 4096 for vector tiles.
+// Points outside of the tile will have coordinates outside [0, extent].
+func (t Tile) Pixel(p *Point, extent float64) *Point {
+	result := p.Clone()
+	t.PixelProjection(extent).Project(result)
+
+	return result
+}
+
+// PixelProjection returns a projection between lng/lat and the pixel
+// coordinates within the tile, see Tile.Pixel.
+func (t Tile) PixelProjection(extent float64) Projection {
+	return Projection{
+		Project: func(p *Point) {
+			n := float64(uint64(1) << t.Z)
+			fx, fy := tileFraction(p, t.Z)
+
+			p.SetX((fx*n - float64(t.X)) * extent)
+			p.SetY((fy*n - float64(t.Y)) * extent)
+		},
+		Inverse: func(p *Point) {
+			*p = *t.pixelInverse(p.X(), p.Y(), extent)
+		},
+	}
+}
+
+func (t Tile) pixelInverse(x, y, extent float64) *Point {
+	n := float64(uint64(1) << t.Z)
+	fx := (float64(t.X) + x/extent) / n
+	fy := (float64(t.Y) + y/extent) / n
+
+	return &Point{
+		360.0*fx - 180.0,
+		rad2deg(math.Atan(math.Sinh(math.Pi * (1 - 2*fy)))),
+	}
+}
+
+// TilesCoveringBound returns the tiles at the zoom intersecting the bound.
+// Tiles that only touch the east or south edges are not included.
+// Bounds crossing the anti-meridian are supported.
+func TilesCoveringBound(b *Bound, zoom uint64) []Tile {
+	x0, x1, y0, y1 := tileRange(b, zoom)
+
+	result := make([]Tile, 0, (x1-x0+1)*(y1-y0+1))
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			result = append(result, Tile{x & (1<<zoom - 1), y, zoom})
+		}
+	}
+
+	return result
+}
+
+// TilesCoveringPath returns the tiles at the zoom the path passes through,
+// in the order they are first visited. The segments are treated as straight
+// lines in the Mercator projection and are split where they cross the anti-meridian.
+func TilesCoveringPath(p *Path, zoom uint64) []Tile {
+	var result []Tile
+	seen := make(map[Tile]struct{})
+
+	add := func(x, y uint64) {
+		t := Tile{x, y, zoom}
+		if _, ok := seen[t]; !ok {
+			seen[t] = struct{}{}
+			result = append(result, t)
+		}
+	}
+
+	n := float64(uint64(1) << zoom)
+	for _, part := range p.SplitAtAntimeridian() {
+		points := part.PointSet
+		for i := range points {
+			if i == 0 && len(points) == 1 {
+				fx, fy := tileFraction(&points[0], zoom)
+				add(tileIndex(fx, n), tileIndex(fy, n))
+			}
+
+			if i == 0 {
+				continue
+			}
+
+			ax, ay := tileFraction(&points[i-1], zoom)
+			bx, by := tileFraction(&points[i], zoom)
+			tileTraverse(ax*n, ay*n, bx*n, by*n, n, add)
+		}
+	}
+
+	return result
+}
+
+// A TilePyramid iterates over the tiles in a range of zooms, zoom by zoom
+// and row by row, without allocating the tiles.
+//
+//	pyramid := geo.NewTilePyramid(bound, 0, 14)
+//	for pyramid.Next() {
+//		tile := pyramid.Tile()
+//	}
+type TilePyramid struct {
+	zoom, maxZoom uint64
+	ranges        func(zoom uint64) (x0, x1, y0, y1 uint64)
+
+	x, y           uint64
+	x0, x1, y0, y1 uint64
+	started        bool
+}
+
+// NewTilePyramid returns an iterator over the tiles covering the bound,
+// see TilesCoveringBound, from the minimum to the maximum zoom inclusive.
+func NewTilePyramid(b *Bound, minZoom, maxZoom uint64) *TilePyramid {
+	return &TilePyramid{
+		zoom:    minZoom,
+		maxZoom: maxZoom,
+		ranges: func(zoom uint64) (x0, x1, y0, y1 uint64) {
+			return tileRange(b, zoom)
+		},
+	}
+}
+
+// NewTilePyramidFromTile returns an iterator over the tile and all
+// its descendants down to, and including, the maximum zoom.
+func NewTilePyramidFromTile(t Tile, maxZoom uint64) *TilePyramid {
+	return &TilePyramid{
+		zoom:    t.Z,
+		maxZoom: maxZoom,
+		ranges: func(zoom uint64) (x0, x1, y0, y1 uint64) {
+			shift := zoom - t.Z
+			return t.X << shift, (t.X+1)<<shift - 1, t.Y << shift, (t.Y+1)<<shift - 1
+		},
+	}
+}
+
+// Next advances the iterator to the next tile, returning false when there are no more.
+func (tp *TilePyramid) Next() bool {
+	if tp.zoom > tp.maxZoom {
+		return false
+	}
+
+	if !tp.started {
+		tp.started = true
+		tp.startZoom()
+		return true
+	}
+
+	tp.x++
+	if tp.x <= tp.x1 {
+		return true
+	}
+
+	tp.x = tp.x0
+	tp.y++
+	if tp.y <= tp.y1 {
+		return true
+	}
+
+	tp.zoom++
+	if tp.zoom > tp.maxZoom {
+		return false
+	}
+
+	tp.startZoom()
+	return true
+}
+
+func (tp *TilePyramid) startZoom() {
+	tp.x0, tp.x1, tp.y0, tp.y1 = tp.ranges(tp.zoom)
+	tp.x, tp.y = tp.x0, tp.y0
+}
+
+// Tile returns the current tile of the iterator.
+func (tp *TilePyramid) Tile() Tile {
+	return Tile{tp.x & (1<<tp.zoom - 1), tp.y, tp.zoom}
+}
+
+// tileFraction returns the position of the point in the Mercator projection,
+// scaled to [0, 1] with the origin in the north west.
+func tileFraction(p *Point, zoom uint64) (fx, fy float64) {
+	fx = (p.Lng() + 180.0) / 360.0
+
+	sin := math.Sin(deg2rad(p.Lat()))
+	fy = 0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi)
+
+	return math.Max(0, math.Min(fx, 1)), math.Max(0, math.Min(fy, 1))
+}
+
+// tileIndex returns the index of the tile containing the fraction.
+func tileIndex(f, n float64) uint64 {
+	return uint64(math.Min(math.Floor(f*n), n-1))
+}
+
+// tileRange returns the range of tile indexes covering the bound. If the bound crosses
+// the anti-meridian x1 will be larger than 2^zoom and needs to be wrapped.
+func tileRange(b *Bound, zoom uint64) (x0, x1, y0, y1 uint64) {
+	n := float64(uint64(1) << zoom)
+
+	west, north := tileFraction(NewPoint(b.West(), b.North()), zoom)
+	east, south := tileFraction(NewPoint(b.East(), b.South()), zoom)
+
+	x0, y0 = tileIndex(west, n), tileIndex(north, n)
+	y1 = tileEndIndex(south, n, y0)
+
+	if b.CrossesAntimeridian() {
+		x1 = tileEndIndex(east, n, 0) + uint64(n)
+	} else {
+		x1 = tileEndIndex(east, n, x0)
+	}
+
+	return
+}
+
+// tileEndIndex returns the index of the last tile, so that tiles
+// only touching the end of the range are not included.
+func tileEndIndex(f, n float64, start uint64) uint64 {
+	end := math.Ceil(f*n) - 1
+	if end < float64(start) {
+		return start
+	}
+
+	return uint64(math.Min(end, n-1))
+}
+
+// tileTraverse calls add for every tile the segment, in tile coordinates,
+// passes through using the algorithm of Amanatides and Woo.
+func tileTraverse(ax, ay, bx, by, n float64, add func(x, y uint64)) {
+	x, y := tileIndex(ax/n, n), tileIndex(ay/n, n)
+	endX, endY := tileIndex(bx/n, n), tileIndex(by/n, n)
+
+	dx, dy := bx-ax, by-ay
+
+	var stepX, stepY int64
+	tMaxX, tMaxY := math.Inf(1), math.Inf(1)
+	tDeltaX, tDeltaY := math.Inf(1), math.Inf(1)
+
+	if dx > 0 {
+		stepX, tDeltaX = 1, 1/dx
+		tMaxX = (float64(x) + 1 - ax) / dx
+	} else if dx < 0 {
+		stepX, tDeltaX = -1, -1/dx
+		tMaxX = (float64(x) - ax) / dx
+	}
+
+	if dy > 0 {
+		stepY, tDeltaY = 1, 1/dy
+		tMaxY = (float64(y) + 1 - ay) / dy
+	} else if dy < 0 {
+		stepY, tDeltaY = -1, -1/dy
+		tMaxY = (float64(y) - ay) / dy
+	}
+
+	steps := tileDistance(x, endX) + tileDistance(y, endY)
+	add(x, y)
+
+	max := uint64(n)
+	for i := uint64(0); i < steps; i++ {
+		if tMaxX < tMaxY {
+			tMaxX += tDeltaX
+			x = uint64(int64(x) + stepX)
+		} else {
+			tMaxY += tDeltaY
+			y = uint64(int64(y) + stepY)
+		}
+
+		// rounding may step outside the world at the edges
+		if x < max && y < max {
+			add(x, y)
+		}
+	}
+}
+
+func tileDistance(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
@@ -0,0 +1,264 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewTileFromPoint(t *testing.T) {
+	cases := []struct {
+		point    *Point
+		zoom     uint64
+		expected Tile
+	}{
+		{NewPoint(-122.4167, 37.7833), 0, Tile{0, 0, 0}},
+		{NewPoint(-122.4167, 37.7833), 10, Tile{163, 395, 10}},
+		{NewPoint(-180, 85.1), 2, Tile{0, 0, 2}},
+		{NewPoint(180, -85.1), 2, Tile{3, 3, 2}},
+		{NewPoint(0, 0), 1, Tile{1, 1, 1}},
+	}
+
+	for i, tc := range cases {
+		if tile := NewTileFromPoint(tc.point, tc.zoom); tile != tc.expected {
+			t.Errorf("tile, fromPoint %d expected %v, got %v", i, tc.expected, tile)
+		}
+	}
+
+	// should match the existing quadkey
+	for _, city := range cities {
+		p := NewPoint(city[1], city[0])
+		if k, e := NewTileFromPoint(p, 16).Quadkey(), p.Quadkey(16); k != e {
+			t.Errorf("tile, quadkey expected %v, got %v", e, k)
+		}
+	}
+}
+
+func TestTileQuadkey(t *testing.T) {
+	tile := Tile{3, 5, 3}
+	if k := tile.QuadkeyString(); k != "213" {
+		t.Errorf("tile, quadkeyString expected 213, got %v", k)
+	}
+
+	if k := (Tile{0, 0, 3}).QuadkeyString(); k != "000" {
+		t.Errorf("tile, quadkeyString expected 000, got %v", k)
+	}
+
+	if k := (Tile{0, 0, 0}).QuadkeyString(); k != "" {
+		t.Errorf("tile, quadkeyString expected empty, got %v", k)
+	}
+
+	if tl, err := NewTileFromQuadkeyString("213"); err != nil || tl != tile {
+		t.Errorf("tile, fromQuadkeyString expected %v, got %v, %v", tile, tl, err)
+	}
+
+	if tl, err := NewTileFromQuadkeyString(""); err != nil || tl != (Tile{}) {
+		t.Errorf("tile, fromQuadkeyString expected the zoom 0 tile, got %v, %v", tl, err)
+	}
+
+	key := "3333333333333333333333333333333"
+	if tl, err := NewTileFromQuadkeyString(key); err != nil || tl.QuadkeyString() != key {
+		t.Errorf("tile, fromQuadkeyString expected %v, got %v, %v", key, tl, err)
+	}
+
+	for _, key := range []string{"12a", "4", "-1", "+1", "00000000000000000000000000000000"} {
+		if _, err := NewTileFromQuadkeyString(key); err != ErrInvalidQuadkey {
+			t.Errorf("tile, fromQuadkeyString %q expected invalid quadkey error, got %v", key, err)
+		}
+	}
+
+	if tl := NewTileFromQuadkey(tile.Quadkey(), 3); tl != tile {
+		t.Errorf("tile, fromQuadkey expected %v, got %v", tile, tl)
+	}
+}
+
+func TestTileHierarchy(t *testing.T) {
+	tile := Tile{3, 5, 3}
+
+	if p := tile.Parent(); p != (Tile{1, 2, 2}) {
+		t.Errorf("tile, parent expected {1 2 2}, got %v", p)
+	}
+
+	if p := (Tile{0, 0, 0}).Parent(); p != (Tile{0, 0, 0}) {
+		t.Errorf("tile, parent of root expected root, got %v", p)
+	}
+
+	for i, c := range tile.Children() {
+		if c.Parent() != tile {
+			t.Errorf("tile, child %d parent expected %v, got %v", i, tile, c.Parent())
+		}
+
+		if c.QuadkeyString() != tile.QuadkeyString()+string(rune('0'+i)) {
+			t.Errorf("tile, child %d expected in quadkey order, got %v", i, c.QuadkeyString())
+		}
+	}
+
+	siblings := tile.Siblings()
+	if len(siblings) != 3 {
+		t.Fatalf("tile, siblings expected 3, got %d", len(siblings))
+	}
+
+	for _, s := range siblings {
+		if s == tile || s.Parent() != tile.Parent() {
+			t.Errorf("tile, incorrect sibling %v", s)
+		}
+	}
+
+	if s := (Tile{0, 0, 0}).Siblings(); len(s) != 0 {
+		t.Errorf("tile, root should have no siblings, got %v", s)
+	}
+
+	if !tile.Valid() || (Tile{8, 0, 3}).Valid() {
+		t.Errorf("tile, valid incorrect")
+	}
+}
+
+func TestTilePixel(t *testing.T) {
+	tile := Tile{163, 395, 10}
+
+	// corners
+	b := tile.Bound()
+	if p := tile.Pixel(b.NorthWest(), 4096); math.Abs(p.X()) > 1e-6 || math.Abs(p.Y()) > 1e-6 {
+		t.Errorf("tile, pixel expected north west at origin, got %v", p)
+	}
+
+	if p := tile.Pixel(b.SouthEast(), 4096); math.Abs(p.X()-4096) > 1e-6 || math.Abs(p.Y()-4096) > 1e-6 {
+		t.Errorf("tile, pixel expected south east at extent, got %v", p)
+	}
+
+	if p := tile.Pixel(tile.Center(), 256); math.Abs(p.X()-128) > 1e-6 || math.Abs(p.Y()-128) > 1e-6 {
+		t.Errorf("tile, pixel expected center, got %v", p)
+	}
+
+	// projection round trip
+	point := NewPoint(-122.4167, 37.7833)
+	p := tile.Pixel(point, 256)
+	tile.PixelProjection(256).Inverse(p)
+	if math.Abs(p.Lng()-point.Lng()) > 1e-9 || math.Abs(p.Lat()-point.Lat()) > 1e-9 {
+		t.Errorf("tile, pixel round trip expected %v, got %v", point, p)
+	}
+}
+
+func TestTilesCoveringBound(t *testing.T) {
+	tiles := TilesCoveringBound(NewBound(-180, 180, -85, 85), 2)
+	if len(tiles) != 16 {
+		t.Errorf("tile, covering world expected 16 tiles, got %d", len(tiles))
+	}
+
+	// exactly one tile
+	tile := Tile{163, 395, 10}
+	tiles = TilesCoveringBound(tile.Bound().Pad(-1e-9), 10)
+	if len(tiles) != 1 || tiles[0] != tile {
+		t.Errorf("tile, covering tile bound expected %v, got %v", tile, tiles)
+	}
+
+	// a point
+	tiles = TilesCoveringBound(NewBoundFromPoints(tile.Center(), tile.Center()), 10)
+	if len(tiles) != 1 || tiles[0] != tile {
+		t.Errorf("tile, covering point expected %v, got %v", tile, tiles)
+	}
+
+	// crossing the anti-meridian
+	tiles = TilesCoveringBound(NewGeoBound(170, -170, -10, 10), 3)
+	expected := []Tile{{7, 3, 3}, {0, 3, 3}, {7, 4, 3}, {0, 4, 3}}
+	if len(tiles) != len(expected) {
+		t.Fatalf("tile, covering expected %v, got %v", expected, tiles)
+	}
+
+	for i := range expected {
+		if tiles[i] != expected[i] {
+			t.Errorf("tile, covering expected %v, got %v", expected, tiles)
+			break
+		}
+	}
+}
+
+func TestTilesCoveringPath(t *testing.T) {
+	path := NewPath()
+	path.Push(NewPoint(-60, 0.5))
+	path.Push(NewPoint(60, 0.5))
+
+	tiles := TilesCoveringPath(path, 3)
+	if len(tiles) != 4 {
+		t.Errorf("tile, covering path expected 4 tiles, got %v", tiles)
+	}
+
+	for i, tile := range tiles {
+		if tile.Y != 3 || tile.X != uint64(i+2) {
+			t.Errorf("tile, covering path expected tiles in order, got %v", tiles)
+			break
+		}
+	}
+
+	// diagonal, should have no gaps
+	path = NewPath()
+	path.Push(NewPoint(-80, -60))
+	path.Push(NewPoint(80, 60))
+
+	tiles = TilesCoveringPath(path, 4)
+	for i := 1; i < len(tiles); i++ {
+		dx := tileDistance(tiles[i].X, tiles[i-1].X)
+		dy := tileDistance(tiles[i].Y, tiles[i-1].Y)
+		if dx+dy != 1 {
+			t.Errorf("tile, covering path expected adjacent tiles, got %v %v", tiles[i-1], tiles[i])
+		}
+	}
+
+	// crossing the anti-meridian
+	path = NewPath()
+	path.Push(NewPoint(170, 1))
+	path.Push(NewPoint(-170, 1))
+
+	tiles = TilesCoveringPath(path, 1)
+	if len(tiles) != 2 || tiles[0] != (Tile{1, 0, 1}) || tiles[1] != (Tile{0, 0, 1}) {
+		t.Errorf("tile, covering path expected across the anti-meridian, got %v", tiles)
+	}
+
+	// single point
+	path = NewPath()
+	path.Push(NewPoint(1, 1))
+	if tiles := TilesCoveringPath(path, 1); len(tiles) != 1 || tiles[0] != (Tile{1, 0, 1}) {
+		t.Errorf("tile, covering point expected 1 tile, got %v", tiles)
+	}
+}
+
+func TestTilePyramid(t *testing.T) {
+	pyramid := NewTilePyramidFromTile(Tile{1, 1, 1}, 3)
+
+	count := 0
+	for pyramid.Next() {
+		tile := pyramid.Tile()
+		for tile.Z > 1 {
+			tile = tile.Parent()
+		}
+
+		if tile != (Tile{1, 1, 1}) {
+			t.Errorf("tile, pyramid expected descendants, got %v", pyramid.Tile())
+		}
+		count++
+	}
+
+	if count != 1+4+16 {
+		t.Errorf("tile, pyramid expected 21 tiles, got %d", count)
+	}
+
+	if pyramid.Next() {
+		t.Errorf("tile, pyramid should stay finished")
+	}
+
+	bound := NewGeoBound(170, -170, -10, 10)
+	pyramid = NewTilePyramid(bound, 2, 4)
+
+	count = 0
+	for pyramid.Next() {
+		count++
+	}
+
+	expected := 0
+	for z := uint64(2); z <= 4; z++ {
+		expected += len(TilesCoveringBound(bound, z))
+	}
+
+	if count != expected {
+		t.Errorf("tile, pyramid expected %d tiles, got %d", expected, count)
+	}
+}
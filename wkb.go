@@ -67,47 +67,37 @@ func NewPathFromWKB(wkb []byte) *Path {
 	return p
 }
 
-// Scan implements the sql.Scanner interface allowing
-// point structs to be passed into rows.Scan(...interface{})
-// The column must be of type Point and must be fetched in WKB format.
-// Will attempt to parse MySQL's SRID+WKB format if the data is of the right size.
-// If the column is empty (not null) an empty point (0, 0) will be returned.
-func (p *Point) Scan(value interface{}) error {
-	data, ok := value.([]byte)
-	if !ok {
-		return ErrUnsupportedDataType
-	}
-
-	//string of hex workaround
-	//[]byte passed is a string of hex hence the need to decode hex string to real []byte
-	if len(data) == 42 {
-		dst := make([]byte, 21)
-		_, err := hex.Decode(dst, data)
-		if err != nil {
-			return err
-		}
-		data = dst
-	}
-
-	if len(data) == 21 {
-		// the length of a point type in WKB
-		return p.unmarshalWKB(data)
+// NewPolygonFromWKB will take raw WKB and set the data for a new polygon.
+// The WKB data must be of type Polygon. Will return nil if invalid WKB.
+func NewPolygonFromWKB(wkb []byte) *Polygon {
+	p := &Polygon{}
+	if err := p.unmarshalWKB(wkb); err != nil {
+		return nil
 	}
 
-	if len(data) == 25 {
-		// Most likely MySQL's SRID+WKB format.
-		// However, could be a line string or multipoint with only one point.
-		// But those would be invalid for parsing a point.
-		return p.unmarshalWKB(data[4:])
-	}
+	return p
+}
 
-	if len(data) == 0 {
-		// empty data, return empty go struct which in this case
-		// would be [0,0]
+// NewMultiPolygonFromWKB will take raw WKB and set the data for a new multi polygon.
+// The WKB data must be of type MultiPolygon. Will return nil if invalid WKB.
+func NewMultiPolygonFromWKB(wkb []byte) *MultiPolygon {
+	mp := &MultiPolygon{}
+	if err := mp.unmarshalWKB(wkb); err != nil {
 		return nil
 	}
 
-	return ErrIncorrectGeometry
+	return mp
+}
+
+// Scan implements the sql.Scanner interface allowing
+// point structs to be passed into rows.Scan(...interface{})
+// The column must be of type Point and must be fetched in WKB or EWKB format,
+// raw or as a string of hex. Will attempt to parse MySQL's SRID+WKB format
+// if parsing as WKB fails.
+// If the column is empty (not null) an empty point (0, 0) will be returned.
+func (p *Point) Scan(value interface{}) error {
+	_, err := scanWKB(value, p)
+	return err
 }
 
 func (p *Point) unmarshalXY(data []byte, littleEndian bool) {
@@ -116,20 +106,20 @@ func (p *Point) unmarshalXY(data []byte, littleEndian bool) {
 }
 
 func (p *Point) unmarshalWKB(data []byte) error {
-	if len(data) != 21 {
-		return ErrNotWKB
-	}
-
-	littleEndian, typeCode, err := scanPrefix(data)
+	h, err := scanHeader(data)
 	if err != nil {
 		return err
 	}
 
-	if typeCode != 1 {
+	if len(data) != h.size+h.pointSize() {
+		return ErrNotWKB
+	}
+
+	if h.typeCode != wkbPointType {
 		return ErrIncorrectGeometry
 	}
 
-	p.unmarshalXY(data[5:], littleEndian)
+	p.unmarshalXY(data[h.size:], h.littleEndian)
 
 	return nil
 }
@@ -137,168 +127,596 @@ func (p *Point) unmarshalWKB(data []byte) error {
 // Scan implements the sql.Scanner interface allowing
 // line structs to be passed into rows.Scan(...interface{})
 // The column must be of type LineString and contain 2 points,
-// or an error will be returned. Data must be fetched in WKB format.
-// Will attempt to parse MySQL's SRID+WKB format if the data is of the right size.
+// or an error will be returned. Data must be fetched in WKB or EWKB format,
+// raw or as a string of hex. Will attempt to parse MySQL's SRID+WKB format
+// if parsing as WKB fails.
 // If the column is empty (not null) an empty line [(0, 0), (0, 0)] will be returned.
 func (l *Line) Scan(value interface{}) error {
-	data, ok := value.([]byte)
-	if !ok {
-		return ErrUnsupportedDataType
+	_, err := scanWKB(value, l)
+	return err
+}
+
+func (l *Line) unmarshalWKB(data []byte) error {
+	h, err := scanHeader(data)
+	if err != nil {
+		return err
 	}
 
-	if len(data) == 41 {
-		// the length of a 2 point linestring type in WKB
-		return l.unmarshalWKB(data)
+	pointSize := h.pointSize()
+	if len(data) != h.size+4+2*pointSize {
+		return ErrNotWKB
 	}
 
-	if len(data) == 45 {
-		// Most likely MySQL's SRID+WKB format.
-		// However, could be some encoding of another type.
-		// But those would be invalid for parsing a line.
-		return l.unmarshalWKB(data[4:])
+	if h.typeCode != wkbLineStringType {
+		return ErrIncorrectGeometry
 	}
 
-	if len(data) == 0 {
-		return nil
+	length := scanUint32(data[h.size:h.size+4], h.littleEndian)
+	if length != 2 {
+		return ErrIncorrectGeometry
 	}
 
-	return ErrIncorrectGeometry
+	l.a.unmarshalXY(data[h.size+4:], h.littleEndian)
+	l.b.unmarshalXY(data[h.size+4+pointSize:], h.littleEndian)
+
+	return nil
 }
 
-func (l *Line) unmarshalWKB(data []byte) error {
-	if len(data) != 41 {
-		return ErrNotWKB
-	}
+// Scan implements the sql.Scanner interface allowing
+// line structs to be passed into rows.Scan(...interface{})
+// The column must be of type LineString, Polygon or MultiPoint
+// or an error will be returned. Data must be fetched in WKB or EWKB format,
+// raw or as a string of hex. Will attempt to parse MySQL's SRID+WKB format
+// if parsing as WKB fails.
+// If the column is empty (not null) an empty point set will be returned.
+func (ps *PointSet) Scan(value interface{}) error {
+	_, err := scanWKB(value, ps)
+	return err
+}
 
-	littleEndian, typeCode, err := scanPrefix(data)
+func (ps *PointSet) unmarshalWKB(data []byte) error {
+	h, err := scanHeader(data)
 	if err != nil {
 		return err
 	}
 
-	if typeCode != 2 {
+	var points PointSet
+	var n int
+
+	switch h.typeCode {
+	case wkbLineStringType:
+		points, n, err = scanRing(data[h.size:], h)
+	case wkbPolygonType:
+		// For polygons there is a ring count.
+		// We only allow one ring here.
+		if len(data) < h.size+4 {
+			return ErrNotWKB
+		}
+
+		numRings := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
+		if numRings != 1 {
+			return ErrIncorrectGeometry
+		}
+
+		points, n, err = scanRing(data[h.size+4:], h)
+		n += 4
+	case wkbMultiPointType:
+		if len(data) < h.size+4 {
+			return ErrNotWKB
+		}
+
+		length := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
+		n = 4
+
+		if length*wkbMinPointSize > len(data)-h.size-n {
+			return ErrNotWKB
+		}
+
+		// each point has its own header
+		points = make(PointSet, length)
+		for i := 0; i < length; i++ {
+			ph, err := scanHeader(data[h.size+n:])
+			if err != nil {
+				return err
+			}
+
+			size := ph.size + ph.pointSize()
+			if len(data) < h.size+n+size {
+				return ErrNotWKB
+			}
+
+			if err := points[i].unmarshalWKB(data[h.size+n : h.size+n+size]); err != nil {
+				return err
+			}
+			n += size
+		}
+	default:
 		return ErrIncorrectGeometry
 	}
 
-	length := scanUint32(data[5:9], littleEndian)
-	if length != 2 {
-		return ErrIncorrectGeometry
+	if err != nil {
+		return err
 	}
 
-	l.a.unmarshalXY(data[9:], littleEndian)
-	l.b.unmarshalXY(data[25:], littleEndian)
+	if len(data) != h.size+n {
+		return ErrNotWKB
+	}
 
+	ps.SetPoints(points)
 	return nil
 }
 
 // Scan implements the sql.Scanner interface allowing
 // line structs to be passed into rows.Scan(...interface{})
 // The column must be of type LineString, Polygon or MultiPoint
-// or an error will be returned. Data must be fetched in WKB format.
-// Will attempt to parse MySQL's SRID+WKB format if obviously no WKB
-// or parsing as WKB fails.
-// If the column is empty (not null) an empty point set will be returned.
-func (ps *PointSet) Scan(value interface{}) error {
-	data, ok := value.([]byte)
-	if !ok {
-		return ErrUnsupportedDataType
-	}
+// or an error will be returned. Data must be fetched in WKB or EWKB format,
+// raw or as a string of hex. Will attempt to parse MySQL's SRID+WKB format
+// if parsing as WKB fails.
+// If the column is empty (not null) an empty path will be returned.
+func (p *Path) Scan(value interface{}) error {
+	return p.PointSet.Scan(value)
+}
 
-	if len(data) == 0 {
-		return nil
+// Scan implements the sql.Scanner interface allowing
+// polygon structs to be passed into rows.Scan(...interface{})
+// The column must be of type Polygon or an error will be returned.
+// Data must be fetched in WKB or EWKB format, raw or as a string of hex.
+// Will attempt to parse MySQL's SRID+WKB format if parsing as WKB fails.
+// If the column is empty (not null) an empty polygon will be returned.
+func (p *Polygon) Scan(value interface{}) error {
+	_, err := scanWKB(value, p)
+	return err
+}
+
+func (p *Polygon) unmarshalWKB(data []byte) error {
+	n, err := p.unmarshalWKBPrefix(data)
+	if err != nil {
+		return err
 	}
 
-	if len(data) < 6 {
+	if n != len(data) {
 		return ErrNotWKB
 	}
 
-	// first byte of real WKB data indicates endian and should 1 or 0.
-	if data[0] == 0 || data[0] == 1 {
-		return ps.unmarshalWKB(data)
+	return nil
+}
+
+// unmarshalWKBPrefix reads a polygon from the start of the data
+// and returns the number of bytes read.
+func (p *Polygon) unmarshalWKBPrefix(data []byte) (int, error) {
+	h, err := scanHeader(data)
+	if err != nil {
+		return 0, err
+	}
+
+	if h.typeCode != wkbPolygonType {
+		return 0, ErrIncorrectGeometry
+	}
+
+	if len(data) < h.size+4 {
+		return 0, ErrNotWKB
+	}
+
+	numRings := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
+	offset := h.size + 4
+
+	// each ring has at least its point count
+	if numRings*4 > len(data)-offset {
+		return 0, ErrNotWKB
+	}
+
+	rings := make(Polygon, 0, numRings)
+	for i := 0; i < numRings; i++ {
+		ring, n, err := scanRing(data[offset:], h)
+		if err != nil {
+			return 0, err
+		}
+
+		rings = append(rings, ring)
+		offset += n
 	}
 
-	return ps.unmarshalWKB(data[4:])
+	*p = rings
+	return offset, nil
 }
 
-func (ps *PointSet) unmarshalLinearRing(data []byte, littleEndian bool) error {
-	const pointSize = 16
-	length := int(scanUint32(data[:4], littleEndian))
-	if len(data) != 4+pointSize*length {
+// Scan implements the sql.Scanner interface allowing
+// multi polygon structs to be passed into rows.Scan(...interface{})
+// The column must be of type MultiPolygon or Polygon or an error will
+// be returned. Data must be fetched in WKB or EWKB format, raw or as a
+// string of hex. Will attempt to parse MySQL's SRID+WKB format
+// if parsing as WKB fails.
+// If the column is empty (not null) an empty multi polygon will be returned.
+func (mp *MultiPolygon) Scan(value interface{}) error {
+	_, err := scanWKB(value, mp)
+	return err
+}
+
+func (mp *MultiPolygon) unmarshalWKB(data []byte) error {
+	h, err := scanHeader(data)
+	if err != nil {
+		return err
+	}
+
+	if h.typeCode == wkbPolygonType {
+		// a single polygon is a valid multi polygon
+		p := Polygon{}
+		if err := p.unmarshalWKB(data); err != nil {
+			return err
+		}
+
+		*mp = MultiPolygon{p}
+		return nil
+	}
+
+	if h.typeCode != wkbMultiPolygonType {
+		return ErrIncorrectGeometry
+	}
+
+	if len(data) < h.size+4 {
 		return ErrNotWKB
 	}
-	points := make([]Point, length)
-	for i := 0; i < length; i++ {
-		points[i].unmarshalXY(data[4+pointSize*i:], littleEndian)
+
+	numPolygons := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
+	offset := h.size + 4
+
+	// each polygon has at least a header and a ring count
+	if numPolygons*(5+4) > len(data)-offset {
+		return ErrNotWKB
 	}
-	ps.SetPoints(points)
+
+	polygons := make(MultiPolygon, 0, numPolygons)
+	for i := 0; i < numPolygons; i++ {
+		p := Polygon{}
+		n, err := p.unmarshalWKBPrefix(data[offset:])
+		if err != nil {
+			return err
+		}
+
+		polygons = append(polygons, p)
+		offset += n
+	}
+
+	if offset != len(data) {
+		return ErrNotWKB
+	}
+
+	*mp = polygons
 	return nil
 }
 
-func (ps *PointSet) unmarshalWKB(data []byte) error {
-	if len(data) < 6 {
-		return ErrNotWKB
+// Scan implements the sql.Scanner interface allowing
+// bound structs to be passed into rows.Scan(...interface{})
+// The column must be of type Polygon, the bound will be that of its outer ring,
+// or a MultiPolygon of a bound crossing the anti-meridian as written by MarshalWKB.
+// Data must be fetched in WKB or EWKB format, raw or as a string of hex.
+// Will attempt to parse MySQL's SRID+WKB format if parsing as WKB fails.
+// If the column is empty (not null) an empty bound will be returned.
+func (b *Bound) Scan(value interface{}) error {
+	_, err := scanWKB(value, b)
+	return err
+}
+
+func (b *Bound) unmarshalWKB(data []byte) error {
+	p := Polygon{}
+	err := p.unmarshalWKB(data)
+	if err == ErrIncorrectGeometry {
+		mp := MultiPolygon{}
+		if mp.unmarshalWKB(data) != nil {
+			return err
+		}
+
+		bound := newBoundFromSplitPolygons(mp)
+		if bound == nil {
+			return err
+		}
+
+		*b = *bound
+		return nil
 	}
 
-	littleEndian, typeCode, err := scanPrefix(data)
 	if err != nil {
 		return err
 	}
 
-	switch typeCode {
-	case 2: // LineString
-		return ps.unmarshalLinearRing(data[5:], littleEndian)
-	case 3: // Polygon
-		// For polygons there is a ring count.
-		// We only allow one ring here.
-		numRings := int(scanUint32(data[5:9], littleEndian))
+	bound := p.Bound()
+	b.sw, b.ne = bound.sw, bound.ne
+
+	return nil
+}
+
+// Scan implements the sql.Scanner interface allowing
+// path structs with elevation and measure values to be passed into rows.Scan(...interface{})
+// The column must be of type LineString, Polygon, MultiPoint or Point,
+// with or without Z and M values, or an error will be returned.
+// The dimension of the path is set from the data.
+// Data must be fetched in WKB or EWKB format, raw or as a string of hex.
+// Will attempt to parse MySQL's SRID+WKB format if parsing as WKB fails.
+// If the column is empty (not null) an empty path will be returned.
+func (p *PathZM) Scan(value interface{}) error {
+	_, err := scanWKB(value, p)
+	return err
+}
+
+func (p *PathZM) unmarshalWKB(data []byte) error {
+	h, err := scanHeader(data)
+	if err != nil {
+		return err
+	}
+
+	var points []PointZM
+	var n int
+
+	switch h.typeCode {
+	case wkbPointType:
+		points = make([]PointZM, 1)
+		n = h.pointSize()
+		if len(data) < h.size+n {
+			return ErrNotWKB
+		}
+		points[0].unmarshalWKBCoordinates(data[h.size:], h)
+	case wkbLineStringType:
+		points, n, err = scanRingZM(data[h.size:], h)
+	case wkbPolygonType:
+		if len(data) < h.size+4 {
+			return ErrNotWKB
+		}
+
+		numRings := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
 		if numRings != 1 {
 			return ErrIncorrectGeometry
 		}
-		return ps.unmarshalLinearRing(data[9:], littleEndian)
-	case 4: // MultiPoint
-		const wkbPointSize = 1 + 4 + 16
-		length := int(scanUint32(data[5:9], littleEndian))
-		if len(data) != 9+wkbPointSize*length {
+
+		points, n, err = scanRingZM(data[h.size+4:], h)
+		n += 4
+	case wkbMultiPointType:
+		if len(data) < h.size+4 {
+			return ErrNotWKB
+		}
+
+		length := int(scanUint32(data[h.size:h.size+4], h.littleEndian))
+		n = 4
+
+		if length*wkbMinPointSize > len(data)-h.size-n {
 			return ErrNotWKB
 		}
-		points := make([]Point, length)
+
+		// each point has its own header
+		points = make([]PointZM, length)
 		for i := 0; i < length; i++ {
-			if err := points[i].unmarshalWKB(data[9+wkbPointSize*i : 9+wkbPointSize*i+wkbPointSize]); err != nil {
+			ph, err := scanHeader(data[h.size+n:])
+			if err != nil {
 				return err
 			}
+
+			if ph.typeCode != wkbPointType {
+				return ErrIncorrectGeometry
+			}
+
+			if len(data) < h.size+n+ph.size+ph.pointSize() {
+				return ErrNotWKB
+			}
+
+			points[i].unmarshalWKBCoordinates(data[h.size+n+ph.size:], ph)
+			n += ph.size + ph.pointSize()
 		}
-		ps.SetPoints(points)
 	default:
 		return ErrIncorrectGeometry
 	}
 
+	if err != nil {
+		return err
+	}
+
+	if len(data) != h.size+n {
+		return ErrNotWKB
+	}
+
+	p.Dim = dimensionOf(h.hasZ, h.hasM)
+	p.Points = points
 	return nil
 }
 
-// Scan implements the sql.Scanner interface allowing
-// line structs to be passed into rows.Scan(...interface{})
-// The column must be of type LineString, Polygon or MultiPoint
-// or an error will be returned. Data must be fetched in WKB format.
-// Will attempt to parse MySQL's SRID+WKB format if obviously no WKB
-// or parsing as WKB fails.
-// If the column is empty (not null) an empty path will be returned.
-func (p *Path) Scan(value interface{}) error {
-	return p.PointSet.Scan(value)
+// unmarshalWKBCoordinates reads the x, y and any z or m values of a point.
+func (p *PointZM) unmarshalWKBCoordinates(data []byte, h wkbHeader) {
+	p.unmarshalXY(data, h.littleEndian)
+
+	i := 16
+	if h.hasZ {
+		p.Z = scanFloat64(data[i:i+8], h.littleEndian)
+		i += 8
+	}
+
+	if h.hasM {
+		p.M = scanFloat64(data[i:i+8], h.littleEndian)
+	}
+}
+
+type wkbUnmarshaler interface {
+	unmarshalWKB(data []byte) error
 }
 
-func scanPrefix(data []byte) (bool, uint32, error) {
+// scanWKB unmarshals the database value into the geometry.
+// The value can be WKB or EWKB, raw or as a string of hex, or MySQL's SRID+WKB.
+// Returns the SRID if it was part of the data.
+func scanWKB(value interface{}, g wkbUnmarshaler) (int, error) {
+	data, ok := value.([]byte)
+	if !ok {
+		return 0, ErrUnsupportedDataType
+	}
+
+	if len(data) == 0 {
+		// empty data, leave the go struct empty
+		return 0, nil
+	}
+
+	data = decodeHexWKB(data)
+
+	err := g.unmarshalWKB(data)
+	if err == nil {
+		h, _ := scanHeader(data)
+		return h.srid, nil
+	}
+
+	// MySQL's SRID+WKB format, the SRID is always little endian.
+	// The first byte after the SRID is the WKB byte order.
+	if len(data) > 4 && (data[4] == 0 || data[4] == 1) {
+		if g.unmarshalWKB(data[4:]) == nil {
+			return int(scanUint32(data[:4], true)), nil
+		}
+	}
+
+	return 0, err
+}
+
+// decodeHexWKB returns the binary data if the given data is WKB
+// encoded as a string of hex. This is how PostGIS returns geometry columns
+// when not using ST_AsBinary. Returns the input if not hex.
+func decodeHexWKB(data []byte) []byte {
+	if len(data) < 2 || len(data)%2 != 0 || data[0] != '0' || (data[1] != '0' && data[1] != '1') {
+		return data
+	}
+
+	dst := make([]byte, len(data)/2)
+	if _, err := hex.Decode(dst, data); err != nil {
+		return data
+	}
+
+	return dst
+}
+
+// scanRing reads a linear ring, a point count followed by the points,
+// from the start of the data and returns the number of bytes read.
+func scanRing(data []byte, h wkbHeader) (PointSet, int, error) {
+	if len(data) < 4 {
+		return nil, 0, ErrNotWKB
+	}
+
+	pointSize := h.pointSize()
+	length := int(scanUint32(data[:4], h.littleEndian))
+	if len(data) < 4+pointSize*length {
+		return nil, 0, ErrNotWKB
+	}
+
+	points := make(PointSet, length)
+	for i := 0; i < length; i++ {
+		points[i].unmarshalXY(data[4+pointSize*i:], h.littleEndian)
+	}
+
+	return points, 4 + pointSize*length, nil
+}
+
+// scanRingZM reads a linear ring keeping any z and m values
+// and returns the number of bytes read.
+func scanRingZM(data []byte, h wkbHeader) ([]PointZM, int, error) {
+	if len(data) < 4 {
+		return nil, 0, ErrNotWKB
+	}
+
+	pointSize := h.pointSize()
+	length := int(scanUint32(data[:4], h.littleEndian))
+	if len(data) < 4+pointSize*length {
+		return nil, 0, ErrNotWKB
+	}
+
+	points := make([]PointZM, length)
+	for i := 0; i < length; i++ {
+		points[i].unmarshalWKBCoordinates(data[4+pointSize*i:], h)
+	}
+
+	return points, 4 + pointSize*length, nil
+}
+
+// wkbMinPointSize is the size of a point with a header and only x and y values.
+const wkbMinPointSize = 1 + 4 + 16
+
+const (
+	wkbPointType        = 1
+	wkbLineStringType   = 2
+	wkbPolygonType      = 3
+	wkbMultiPointType   = 4
+	wkbMultiPolygonType = 6
+
+	ewkbZFlag    = 0x80000000
+	ewkbMFlag    = 0x40000000
+	ewkbSRIDFlag = 0x20000000
+)
+
+// wkbHeader is the parsed byte order and geometry type
+// that start every WKB geometry.
+type wkbHeader struct {
+	littleEndian bool
+	typeCode     uint32
+	hasZ, hasM   bool
+	srid         int
+
+	// size is the length of the header in bytes
+	size int
+}
+
+// pointSize returns the number of bytes used by each point.
+// Extra Z and M values are skipped when decoding.
+func (h wkbHeader) pointSize() int {
+	size := 16
+	if h.hasZ {
+		size += 8
+	}
+
+	if h.hasM {
+		size += 8
+	}
+
+	return size
+}
+
+// scanHeader parses the WKB header. It supports the PostGIS EWKB
+// Z, M and SRID flags as well as the ISO WKB Z, M and ZM type codes.
+func scanHeader(data []byte) (wkbHeader, error) {
+	h := wkbHeader{size: 5}
 	if len(data) < 6 {
-		return false, 0, ErrNotWKB
+		return h, ErrNotWKB
 	}
 
-	if data[0] == 0 {
-		return false, scanUint32(data[1:5], false), nil
+	switch data[0] {
+	case 0:
+	case 1:
+		h.littleEndian = true
+	default:
+		return h, ErrNotWKB
+	}
+
+	typeCode := scanUint32(data[1:5], h.littleEndian)
+	h.hasZ = typeCode&ewkbZFlag != 0
+	h.hasM = typeCode&ewkbMFlag != 0
+
+	if typeCode&ewkbSRIDFlag != 0 {
+		if len(data) < 9 {
+			return h, ErrNotWKB
+		}
+
+		h.srid = int(scanUint32(data[5:9], h.littleEndian))
+		h.size = 9
+	}
+
+	typeCode &^= ewkbZFlag | ewkbMFlag | ewkbSRIDFlag
+
+	// ISO WKB adds 1000, 2000 or 3000 for Z, M and ZM geometries.
+	switch typeCode / 1000 {
+	case 1:
+		h.hasZ = true
+	case 2:
+		h.hasM = true
+	case 3:
+		h.hasZ = true
+		h.hasM = true
 	}
 
-	if data[0] == 1 {
-		return true, scanUint32(data[1:5], true), nil
+	if typeCode < 4000 {
+		typeCode %= 1000
 	}
 
-	return false, 0, ErrNotWKB
+	h.typeCode = typeCode
+	return h, nil
 }
 
 func scanUint32(data []byte, littleEndian bool) uint32 {
@@ -0,0 +1,243 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+)
+
+// DefaultWKBByteOrder is the byte order used when marshalling WKB
+// if one is not provided. It is also the byte order used by the driver.Valuer
+// implementations.
+var DefaultWKBByteOrder binary.ByteOrder = binary.LittleEndian
+
+// MarshalWKB returns the point in WKB format. The byte order
+// defaults to DefaultWKBByteOrder.
+func (p *Point) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	w := newWKBWriter(21, byteOrder)
+	w.writeHeader(wkbPointType)
+	w.writePoint(p)
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// points to be passed as query arguments. The value is the WKB of the point.
+func (p *Point) Value() (driver.Value, error) {
+	return p.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the line in WKB format as a 2 point LineString.
+// The byte order defaults to DefaultWKBByteOrder.
+func (l *Line) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	w := newWKBWriter(41, byteOrder)
+	w.writeHeader(wkbLineStringType)
+	w.writeUint32(2)
+	w.writePoint(&l.a)
+	w.writePoint(&l.b)
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// lines to be passed as query arguments. The value is the WKB of the line.
+func (l *Line) Value() (driver.Value, error) {
+	return l.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the point set in WKB format as a MultiPoint.
+// The byte order defaults to DefaultWKBByteOrder.
+func (ps PointSet) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	w := newWKBWriter(9+21*len(ps), byteOrder)
+	w.writeHeader(wkbMultiPointType)
+	w.writeUint32(uint32(len(ps)))
+	for i := range ps {
+		w.writeHeader(wkbPointType)
+		w.writePoint(&ps[i])
+	}
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// point sets to be passed as query arguments. The value is the WKB of the point set.
+func (ps PointSet) Value() (driver.Value, error) {
+	return ps.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the path in WKB format as a LineString.
+// The byte order defaults to DefaultWKBByteOrder.
+func (p *Path) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	w := newWKBWriter(9+16*len(p.PointSet), byteOrder)
+	w.writeHeader(wkbLineStringType)
+	w.writeRing(p.PointSet)
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// paths to be passed as query arguments. The value is the WKB of the path.
+func (p *Path) Value() (driver.Value, error) {
+	return p.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the bound in WKB format as a Polygon, with the same
+// ring as its WKT representation. Bounds crossing the anti-meridian are split
+// and returned as a MultiPolygon. The byte order defaults to DefaultWKBByteOrder.
+func (b *Bound) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	if b.CrossesAntimeridian() {
+		return b.splitPolygons().MarshalWKB(byteOrder...)
+	}
+
+	return NewPolygonFromBound(b).MarshalWKB(byteOrder...)
+}
+
+// Value implements the driver.Valuer interface allowing
+// bounds to be passed as query arguments. The value is the WKB of the bound polygon.
+func (b *Bound) Value() (driver.Value, error) {
+	return b.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the polygon in WKB format.
+// The byte order defaults to DefaultWKBByteOrder.
+func (p Polygon) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	w := newWKBWriter(p.wkbSize(), byteOrder)
+	w.writePolygon(p)
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// polygons to be passed as query arguments. The value is the WKB of the polygon.
+func (p Polygon) Value() (driver.Value, error) {
+	return p.MarshalWKB(), nil
+}
+
+func (p Polygon) wkbSize() int {
+	size := 9
+	for _, ring := range p {
+		size += 4 + 16*len(ring)
+	}
+
+	return size
+}
+
+// MarshalWKB returns the multi polygon in WKB format.
+// The byte order defaults to DefaultWKBByteOrder.
+func (mp MultiPolygon) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	size := 9
+	for _, p := range mp {
+		size += p.wkbSize()
+	}
+
+	w := newWKBWriter(size, byteOrder)
+	w.writeHeader(wkbMultiPolygonType)
+	w.writeUint32(uint32(len(mp)))
+	for _, p := range mp {
+		w.writePolygon(p)
+	}
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// multi polygons to be passed as query arguments. The value is the WKB of the multi polygon.
+func (mp MultiPolygon) Value() (driver.Value, error) {
+	return mp.MarshalWKB(), nil
+}
+
+// MarshalWKB returns the path in ISO WKB format as a LineString
+// with the Z and/or M values given by the dimension of the path.
+// The byte order defaults to DefaultWKBByteOrder.
+func (p *PathZM) MarshalWKB(byteOrder ...binary.ByteOrder) []byte {
+	pointSize := 16
+	if p.Dim.HasZ() {
+		pointSize += 8
+	}
+
+	if p.Dim.HasM() {
+		pointSize += 8
+	}
+
+	w := newWKBWriter(9+pointSize*len(p.Points), byteOrder)
+	w.writeHeader(wkbLineStringType + 1000*uint32(p.Dim))
+	w.writeUint32(uint32(len(p.Points)))
+	for i := range p.Points {
+		w.writePoint(&p.Points[i].Point)
+		if p.Dim.HasZ() {
+			w.writeFloat64(p.Points[i].Z)
+		}
+
+		if p.Dim.HasM() {
+			w.writeFloat64(p.Points[i].M)
+		}
+	}
+
+	return w.buf
+}
+
+// Value implements the driver.Valuer interface allowing
+// paths to be passed as query arguments. The value is the WKB of the path.
+func (p *PathZM) Value() (driver.Value, error) {
+	return p.MarshalWKB(), nil
+}
+
+// wkbWriter appends WKB encoded values to a buffer.
+type wkbWriter struct {
+	buf       []byte
+	byteOrder binary.ByteOrder
+	scratch   [8]byte
+}
+
+func newWKBWriter(size int, byteOrder []binary.ByteOrder) *wkbWriter {
+	w := &wkbWriter{
+		buf:       make([]byte, 0, size),
+		byteOrder: DefaultWKBByteOrder,
+	}
+
+	if len(byteOrder) != 0 {
+		w.byteOrder = byteOrder[0]
+	}
+
+	return w
+}
+
+func (w *wkbWriter) writeHeader(typeCode uint32) {
+	if w.byteOrder == binary.BigEndian {
+		w.buf = append(w.buf, 0)
+	} else {
+		w.buf = append(w.buf, 1)
+	}
+
+	w.writeUint32(typeCode)
+}
+
+func (w *wkbWriter) writeUint32(v uint32) {
+	w.byteOrder.PutUint32(w.scratch[:4], v)
+	w.buf = append(w.buf, w.scratch[:4]...)
+}
+
+func (w *wkbWriter) writeFloat64(v float64) {
+	w.byteOrder.PutUint64(w.scratch[:], math.Float64bits(v))
+	w.buf = append(w.buf, w.scratch[:]...)
+}
+
+func (w *wkbWriter) writePoint(p *Point) {
+	w.writeFloat64(p[0])
+	w.writeFloat64(p[1])
+}
+
+func (w *wkbWriter) writeRing(ring PointSet) {
+	w.writeUint32(uint32(len(ring)))
+	for i := range ring {
+		w.writePoint(&ring[i])
+	}
+}
+
+func (w *wkbWriter) writePolygon(p Polygon) {
+	w.writeHeader(wkbPolygonType)
+	w.writeUint32(uint32(len(p)))
+	for _, ring := range p {
+		w.writeRing(ring)
+	}
+}
@@ -0,0 +1,191 @@
+package geo
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"testing"
+)
+
+func TestPointMarshalWKB(t *testing.T) {
+	p := NewPoint(-122.4546440212, 37.7382859071)
+
+	little := []byte{1, 1, 0, 0, 0, 15, 152, 60, 227, 24, 157, 94, 192, 205, 11, 17, 39, 128, 222, 66, 64}
+	if data := p.MarshalWKB(); !bytes.Equal(data, little) {
+		t.Errorf("incorrect little endian data, got %v", data)
+	}
+
+	big := []byte{0, 0, 0, 0, 1, 192, 94, 157, 24, 227, 60, 152, 15, 64, 66, 222, 128, 39, 17, 11, 205}
+	if data := p.MarshalWKB(binary.BigEndian); !bytes.Equal(data, big) {
+		t.Errorf("incorrect big endian data, got %v", data)
+	}
+
+	var v driver.Valuer = p
+	value, err := v.Value()
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	scanned := &Point{}
+	if err := scanned.Scan(value); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(p) {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+}
+
+func TestLineMarshalWKB(t *testing.T) {
+	l := NewLine(NewPoint(1, 2), NewPoint(3, 4))
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		data := l.MarshalWKB(order)
+		if len(data) != 41 {
+			t.Errorf("incorrect length, got %d", len(data))
+		}
+
+		scanned := &Line{}
+		if err := scanned.Scan(data); err != nil {
+			t.Errorf("should not get error, got %v", err)
+		}
+
+		if !scanned.Equals(l) {
+			t.Errorf("incorrect round trip, got %v", scanned)
+		}
+	}
+}
+
+func TestPathMarshalWKB(t *testing.T) {
+	path := NewPathFromWKB(testPathWKB)
+
+	if data := path.MarshalWKB(); !bytes.Equal(data, testPathWKB) {
+		t.Errorf("incorrect data, got %v", data)
+	}
+
+	value, err := path.Value()
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	scanned := NewPath()
+	if err := scanned.Scan(value); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(path) {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+
+	// empty
+	if data := NewPath().MarshalWKB(); !bytes.Equal(data, []byte{1, 2, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("incorrect data, got %v", data)
+	}
+}
+
+func TestPointSetMarshalWKB(t *testing.T) {
+	data := []byte{1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0, 222, 90, 38, 195, 241, 110, 73, 64, 229, 179, 60, 15, 238, 190, 22, 64, 1, 1, 0, 0, 0, 94, 189, 138, 140, 14, 110, 73, 64, 24, 11, 67, 228, 244, 213, 22, 64}
+	ps := NewPointSetFromWKB(data)
+
+	if d := ps.MarshalWKB(); !bytes.Equal(d, data) {
+		t.Errorf("incorrect data, got %v", d)
+	}
+
+	scanned := NewPointSet()
+	if err := scanned.Scan(ps.MarshalWKB(binary.BigEndian)); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(ps) {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+}
+
+func TestBoundMarshalWKB(t *testing.T) {
+	b := NewBound(1, 2, 3, 4)
+
+	p := NewPolygonFromWKB(b.MarshalWKB())
+	if p == nil {
+		t.Fatalf("should be valid polygon wkb")
+	}
+
+	if s := p.String(); s != "POLYGON((1 3,1 4,2 4,2 3,1 3))" {
+		t.Errorf("incorrect polygon, got %v", s)
+	}
+
+	value, err := b.Value()
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	scanned := &Bound{}
+	if err := scanned.Scan(value); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(b) {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+
+	// crossing the anti-meridian is split in two
+	b = NewGeoBound(170, -170, -10, 10)
+
+	mp := NewMultiPolygonFromWKB(b.MarshalWKB())
+	if mp == nil {
+		t.Fatalf("should be valid multi polygon wkb")
+	}
+
+	if a := mp.Area(); a != 400 {
+		t.Errorf("incorrect multi polygon area, got %v", a)
+	}
+
+	value, _ = b.Value()
+	scanned = &Bound{}
+	if err := scanned.Scan(value); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(b) || !scanned.CrossesAntimeridian() {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+
+	// other multi polygons are not bounds
+	if err := scanned.Scan(NewMultiPolygon(clipSquare(0, 0, 1)).MarshalWKB()); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestPolygonMarshalWKB(t *testing.T) {
+	p := NewPolygonFromWKB(testPolygonWKB)
+
+	if data := p.MarshalWKB(); !bytes.Equal(data, testPolygonWKB) {
+		t.Errorf("incorrect data, got %v", data)
+	}
+
+	scanned := &Polygon{}
+	if err := scanned.Scan(p.MarshalWKB(binary.BigEndian)); err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !scanned.Equals(p) {
+		t.Errorf("incorrect round trip, got %v", scanned)
+	}
+}
+
+func TestMultiPolygonMarshalWKB(t *testing.T) {
+	mp := NewMultiPolygon(
+		testPolygon(),
+		NewPolygon(&PointSet{{10, 10}, {11, 10}, {10, 11}, {10, 10}}),
+	)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		scanned := &MultiPolygon{}
+		if err := scanned.Scan(mp.MarshalWKB(order)); err != nil {
+			t.Errorf("should not get error, got %v", err)
+		}
+
+		if !scanned.Equals(mp) {
+			t.Errorf("incorrect round trip, got %v", scanned)
+		}
+	}
+}
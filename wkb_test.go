@@ -266,6 +266,102 @@ func TestWKBPolygon(t *testing.T) {
 	}
 }
 
+var testPolygonWKB = []byte{1, 3, 0, 0, 0, 2, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63}
+
+func TestPolygonScan(t *testing.T) {
+	p := &Polygon{}
+
+	if err := p.Scan(123); err != ErrUnsupportedDataType {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	err := p.Scan(testPolygonWKB)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	expected := "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1))"
+	if p.String() != expected {
+		t.Errorf("incorrect polygon, got %v", p)
+	}
+
+	// mysql's SRID+WKB data
+	err = p.Scan(append([]byte{215, 15, 0, 0}, testPolygonWKB...))
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if p.String() != expected {
+		t.Errorf("incorrect polygon, got %v", p)
+	}
+
+	if p := NewPolygonFromWKB(testPolygonWKB); p == nil || p.String() != expected {
+		t.Errorf("incorrect polygon, got %v", p)
+	}
+
+	// error conditions
+	if err := p.Scan(testPolygonWKB[:len(testPolygonWKB)-1]); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if err := p.Scan(append(testPolygonWKB, 0)); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if err := p.Scan(testPathWKB); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	// ring count larger than the data
+	if err := p.Scan([]byte{1, 3, 0, 0, 0, 255, 255, 255, 127, 0, 0, 0, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestMultiPolygonScan(t *testing.T) {
+	data := append([]byte{1, 6, 0, 0, 0, 2, 0, 0, 0}, testPolygonWKB...)
+	data = append(data, 1, 3, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 36, 64, 0, 0, 0, 0, 0, 0, 36, 64, 0, 0, 0, 0, 0, 0, 38, 64, 0, 0, 0, 0, 0, 0, 36, 64, 0, 0, 0, 0, 0, 0, 36, 64, 0, 0, 0, 0, 0, 0, 38, 64, 0, 0, 0, 0, 0, 0, 36, 64, 0, 0, 0, 0, 0, 0, 36, 64)
+
+	mp := &MultiPolygon{}
+	err := mp.Scan(data)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	expected := "MULTIPOLYGON(((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1)),((10 10,11 10,10 11,10 10)))"
+	if mp.String() != expected {
+		t.Errorf("incorrect multi polygon, got %v", mp)
+	}
+
+	if mp := NewMultiPolygonFromWKB(data); mp == nil || mp.String() != expected {
+		t.Errorf("incorrect multi polygon, got %v", mp)
+	}
+
+	// a polygon is a valid multi polygon
+	err = mp.Scan(testPolygonWKB)
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if len(*mp) != 1 {
+		t.Errorf("should have one polygon, got %v", mp)
+	}
+
+	// error conditions
+	if err := mp.Scan(data[:len(data)-1]); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if err := mp.Scan(testPathWKB); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	// polygon count larger than the data
+	if err := mp.Scan([]byte{1, 6, 0, 0, 0, 255, 255, 255, 127, 0, 0, 0, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
 func TestWKBMultiPoint(t *testing.T) {
 	// raw WKB MultiPoint data
 	data := []byte{1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0, 222, 90, 38, 195, 241, 110, 73, 64, 229, 179, 60, 15, 238, 190, 22, 64, 1, 1, 0, 0, 0, 94, 189, 138, 140, 14, 110, 73, 64, 24, 11, 67, 228, 244, 213, 22, 64}
@@ -295,6 +391,11 @@ func TestWKBMultiPoint(t *testing.T) {
 	if path.String() != expected {
 		t.Errorf("incorrect path, got %v", path)
 	}
+
+	// point count larger than the data
+	if err := ps.Scan([]byte{1, 4, 0, 0, 0, 255, 255, 255, 127, 0, 0, 0, 0}); err != ErrNotWKB {
+		t.Errorf("incorrect error, got %v", err)
+	}
 }
 
 func TestScanUint32(t *testing.T) {
@@ -0,0 +1,573 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A WKTError is returned when parsing malformed WKT.
+// Pos is the byte offset in the input where the problem was found.
+type WKTError struct {
+	Pos int
+	Msg string
+}
+
+func (e *WKTError) Error() string {
+	return fmt.Sprintf("go.geo: invalid WKT at position %d: %s", e.Pos, e.Msg)
+}
+
+// ParseWKT parses the WKT and returns the concrete geometry type,
+// POINT as *Point, LINESTRING as *Path, MULTIPOINT as *PointSet,
+// POLYGON as *Polygon and MULTIPOLYGON as *MultiPolygon.
+// A plain 'EMPTY', as returned by the ToWKT methods for empty geometries,
+// will return a nil geometry and no error.
+func ParseWKT(wkt string) (interface{}, error) {
+	p := &wktParser{s: wkt}
+
+	tag, err := p.tag()
+	if err != nil {
+		return nil, err
+	}
+
+	var g interface{}
+	switch tag {
+	case "EMPTY":
+		return nil, p.end()
+	case "POINT":
+		point, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		g = &point.Point
+	case "LINESTRING":
+		points, err := p.pointList()
+		if err != nil {
+			return nil, err
+		}
+		g = &Path{pointSetFromZM(points)}
+	case "MULTIPOINT":
+		points, err := p.multiPointList()
+		if err != nil {
+			return nil, err
+		}
+		ps := pointSetFromZM(points)
+		g = &ps
+	case "POLYGON":
+		rings, err := p.polygon()
+		if err != nil {
+			return nil, err
+		}
+		polygon := polygonFromZM(rings)
+		g = &polygon
+	case "MULTIPOLYGON":
+		polygons, err := p.multiPolygon()
+		if err != nil {
+			return nil, err
+		}
+		mp := make(MultiPolygon, 0, len(polygons))
+		for _, rings := range polygons {
+			mp = append(mp, polygonFromZM(rings))
+		}
+		g = &mp
+	default:
+		return nil, &WKTError{Pos: 0, Msg: fmt.Sprintf("unsupported geometry type %q", tag)}
+	}
+
+	return g, p.end()
+}
+
+// NewPointFromWKT parses WKT of type POINT, eg. POINT(30.5 10.5)
+func NewPointFromWKT(wkt string) (*Point, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := g.(*Point); ok {
+		return p, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewLineFromWKT parses WKT of type LINESTRING that contains 2 points,
+// eg. LINESTRING(30 10,10 30)
+func NewLineFromWKT(wkt string) (*Line, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	if p, ok := g.(*Path); ok && p.Length() == 2 {
+		return NewLine(&p.PointSet[0], &p.PointSet[1]), nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewPointSetFromWKT parses WKT of type LINESTRING, POLYGON (with one ring)
+// or MULTIPOINT, eg. MULTIPOINT(30 10,10 30,40 40)
+// 'EMPTY' will return an empty point set.
+func NewPointSetFromWKT(wkt string) (*PointSet, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch g := g.(type) {
+	case nil:
+		return NewPointSet(), nil
+	case *PointSet:
+		return g, nil
+	case *Path:
+		return &g.PointSet, nil
+	case *Polygon:
+		if len(*g) == 1 {
+			return &(*g)[0], nil
+		}
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewPathFromWKT parses WKT of type LINESTRING, POLYGON (with one ring)
+// or MULTIPOINT, eg. LINESTRING(30 10,10 30,40 40)
+// 'EMPTY' will return an empty path.
+func NewPathFromWKT(wkt string) (*Path, error) {
+	ps, err := NewPointSetFromWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Path{*ps}, nil
+}
+
+// NewPolygonFromWKT parses WKT of type POLYGON,
+// eg. POLYGON((30 10,40 40,20 40,10 20,30 10))
+// 'EMPTY' will return an empty polygon.
+func NewPolygonFromWKT(wkt string) (*Polygon, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch g := g.(type) {
+	case nil:
+		return &Polygon{}, nil
+	case *Polygon:
+		return g, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewMultiPolygonFromWKT parses WKT of type MULTIPOLYGON or POLYGON,
+// eg. MULTIPOLYGON(((30 20,45 40,10 40,30 20)),((15 5,40 10,10 20,5 10,15 5)))
+// 'EMPTY' will return an empty multi polygon.
+func NewMultiPolygonFromWKT(wkt string) (*MultiPolygon, error) {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch g := g.(type) {
+	case nil:
+		return &MultiPolygon{}, nil
+	case *MultiPolygon:
+		return g, nil
+	case *Polygon:
+		return &MultiPolygon{*g}, nil
+	}
+
+	return nil, ErrIncorrectGeometry
+}
+
+// NewPathZMFromWKT parses WKT of type LINESTRING, POLYGON (with one ring),
+// MULTIPOINT or POINT keeping any elevation and measure values,
+// eg. LINESTRING Z (30 10 1,10 30 2). The dimension is taken from the Z, M
+// or ZM marker, or if there is none, from the number of values of the first point.
+// 'EMPTY' will return an empty 2D path.
+func NewPathZMFromWKT(wkt string) (*PathZM, error) {
+	p := &wktParser{s: wkt}
+
+	tag, err := p.tag()
+	if err != nil {
+		return nil, err
+	}
+
+	var points []PointZM
+	switch tag {
+	case "EMPTY":
+	case "POINT":
+		point, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		points = []PointZM{*point}
+	case "LINESTRING":
+		points, err = p.pointList()
+	case "MULTIPOINT":
+		points, err = p.multiPointList()
+	case "POLYGON":
+		var rings [][]PointZM
+		rings, err = p.polygon()
+		if err == nil && len(rings) != 1 {
+			return nil, ErrIncorrectGeometry
+		}
+
+		if err == nil {
+			points = rings[0]
+		}
+	default:
+		return nil, ErrIncorrectGeometry
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.end(); err != nil {
+		return nil, err
+	}
+
+	return &PathZM{Dim: p.dim, Points: points}, nil
+}
+
+func pointSetFromZM(points []PointZM) PointSet {
+	ps := make(PointSet, len(points))
+	for i := range points {
+		ps[i] = points[i].Point
+	}
+
+	return ps
+}
+
+func polygonFromZM(rings [][]PointZM) Polygon {
+	polygon := make(Polygon, 0, len(rings))
+	for _, ring := range rings {
+		polygon = append(polygon, pointSetFromZM(ring))
+	}
+
+	return polygon
+}
+
+// wktParser is a simple recursive descent parser of WKT text.
+// Points are read with any elevation and measure values.
+type wktParser struct {
+	s   string
+	pos int
+
+	// dim is set by a Z, M or ZM marker,
+	// or by the number of values of the first point.
+	dim    Dimension
+	dimSet bool
+}
+
+func (p *wktParser) errorf(format string, args ...interface{}) error {
+	return &WKTError{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+
+	return p.s[p.pos]
+}
+
+func (p *wktParser) expect(c byte) error {
+	if n := p.peek(); n != c {
+		if n == 0 {
+			return p.errorf("expected '%c', got end of input", c)
+		}
+		return p.errorf("expected '%c', got '%c'", c, n)
+	}
+
+	p.pos++
+	return nil
+}
+
+func (p *wktParser) end() error {
+	if p.peek() != 0 {
+		return p.errorf("unexpected trailing data")
+	}
+
+	return nil
+}
+
+// word reads the next run of letters in upper case.
+func (p *wktParser) word() string {
+	p.skipSpace()
+
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		p.pos++
+	}
+
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+// tag reads the geometry type and any Z, M or ZM dimension marker.
+func (p *wktParser) tag() (string, error) {
+	tag := p.word()
+	if tag == "" {
+		return "", p.errorf("expected geometry type")
+	}
+
+	start := p.pos
+	switch p.word() {
+	case "Z":
+		p.dim, p.dimSet = XYZ, true
+	case "M":
+		p.dim, p.dimSet = XYM, true
+	case "ZM":
+		p.dim, p.dimSet = XYZM, true
+	default:
+		p.pos = start
+	}
+
+	return tag, nil
+}
+
+// empty consumes an EMPTY keyword if it is next.
+func (p *wktParser) empty() bool {
+	start := p.pos
+	if p.word() == "EMPTY" {
+		return true
+	}
+
+	p.pos = start
+	return false
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if (c < '0' || c > '9') && c != '-' && c != '+' && c != '.' && c != 'e' && c != 'E' {
+			break
+		}
+		p.pos++
+	}
+
+	if start == p.pos {
+		return 0, p.errorf("expected number")
+	}
+
+	text := p.s[start:p.pos]
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid number %q", text)
+	}
+
+	return f, nil
+}
+
+// coordinates reads the x y values of a point followed by up to
+// two more values, for elevation and/or measure. All the points must
+// have the number of values of the dimension marker or the first point.
+func (p *wktParser) coordinates() (PointZM, error) {
+	var values [4]float64
+	var err error
+
+	p.skipSpace()
+	start := p.pos
+
+	n := 0
+	for ; n < 4; n++ {
+		if n >= 2 {
+			if c := p.peek(); c == ',' || c == ')' {
+				break
+			}
+		}
+
+		if values[n], err = p.number(); err != nil {
+			return PointZM{}, err
+		}
+	}
+
+	if !p.dimSet {
+		p.dim, p.dimSet = [5]Dimension{XY, XY, XY, XYZ, XYZM}[n], true
+	}
+
+	if expected := p.dim.count(); n != expected {
+		p.pos = start
+		return PointZM{}, p.errorf("expected %d values, got %d", expected, n)
+	}
+
+	return pointZMFromSlice(values[:n], p.dim), nil
+}
+
+func (p *wktParser) point() (*PointZM, error) {
+	if p.empty() {
+		return nil, p.errorf("empty point not supported")
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	point, err := p.coordinates()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return &point, nil
+}
+
+func (p *wktParser) pointList() ([]PointZM, error) {
+	points := []PointZM{}
+	if p.empty() {
+		return points, nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	for {
+		point, err := p.coordinates()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// multiPointList reads the points with or without parentheses
+// around each point, eg. MULTIPOINT(1 2,3 4) or MULTIPOINT((1 2),(3 4))
+func (p *wktParser) multiPointList() ([]PointZM, error) {
+	points := []PointZM{}
+	if p.empty() {
+		return points, nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	for {
+		var point PointZM
+		var err error
+
+		if p.peek() == '(' {
+			p.pos++
+			if point, err = p.coordinates(); err != nil {
+				return nil, err
+			}
+
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+		} else if point, err = p.coordinates(); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (p *wktParser) polygon() ([][]PointZM, error) {
+	rings := [][]PointZM{}
+	if p.empty() {
+		return rings, nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	for {
+		ring, err := p.pointList()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return rings, nil
+}
+
+func (p *wktParser) multiPolygon() ([][][]PointZM, error) {
+	polygons := [][][]PointZM{}
+	if p.empty() {
+		return polygons, nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	for {
+		rings, err := p.polygon()
+		if err != nil {
+			return nil, err
+		}
+		polygons = append(polygons, rings)
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return polygons, nil
+}
@@ -0,0 +1,229 @@
+package geo
+
+import "testing"
+
+func TestParseWKT(t *testing.T) {
+	type testData struct {
+		wkt      string
+		expected string
+	}
+
+	tests := []testData{
+		{"POINT(30.5 10.5)", "POINT(30.5 10.5)"},
+		{"point ( -1e3  2 )", "POINT(-1000 2)"},
+		{"POINT Z (1 2 3)", "POINT(1 2)"},
+		{"LINESTRING(30 10,10 30,40 40)", "LINESTRING(30 10,10 30,40 40)"},
+		{"LINESTRING EMPTY", "EMPTY"},
+		{"MULTIPOINT(30 10, 10 30)", "MULTIPOINT(30 10,10 30)"},
+		{"MULTIPOINT((30 10),(10 30))", "MULTIPOINT(30 10,10 30)"},
+		{"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,1 2,2 2,2 1,1 1))"},
+		{"MULTIPOLYGON(((30 20,45 40,10 40,30 20)),((15 5,40 10,10 20,5 10,15 5)))", "MULTIPOLYGON(((30 20,45 40,10 40,30 20)),((15 5,40 10,10 20,5 10,15 5)))"},
+		{"POLYGON ZM ((0 0 1 2,1 0 1 2,0 1 1 2,0 0 1 2))", "POLYGON((0 0,1 0,0 1,0 0))"},
+	}
+
+	for i, test := range tests {
+		g, err := ParseWKT(test.wkt)
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if s := g.(interface {
+			ToWKT() string
+		}).ToWKT(); s != test.expected {
+			t.Errorf("test %d incorrect geometry, got %v", i, s)
+		}
+	}
+
+	g, err := ParseWKT(" EMPTY ")
+	if err != nil || g != nil {
+		t.Errorf("empty should return nil geometry, got %v %v", g, err)
+	}
+}
+
+func TestParseWKTRoundTrip(t *testing.T) {
+	geometries := []interface {
+		ToWKT() string
+	}{
+		NewPoint(-122.4546440212, 37.7382859071),
+		NewPathFromWKB(testPathWKB),
+		&PointSet{{1.5, 2.5}, {3, 4}},
+		testPolygon(),
+		NewMultiPolygon(testPolygon(), testPolygon()),
+	}
+
+	for i, geometry := range geometries {
+		g, err := ParseWKT(geometry.ToWKT())
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if s := g.(interface {
+			ToWKT() string
+		}).ToWKT(); s != geometry.ToWKT() {
+			t.Errorf("test %d incorrect geometry, got %v", i, s)
+		}
+	}
+
+	// bounds
+	b := NewBound(1, 2, 3, 4)
+	p, err := NewPolygonFromWKT(b.String())
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !p.Bound().Equals(b) {
+		t.Errorf("incorrect bound, got %v", p.Bound())
+	}
+}
+
+func TestParseWKTErrors(t *testing.T) {
+	type testData struct {
+		wkt string
+		pos int
+	}
+
+	tests := []testData{
+		{"", 0},
+		{"CIRCLE(1 2)", 0},
+		{"POINT(1 2", 9},
+		{"POINT(1, 2)", 7},
+		{"POINT(1 2) x", 11},
+		{"POINT(1 2 3 4 5)", 14},
+		{"POINT EMPTY", 11},
+		{"LINESTRING(1 2,3 a)", 17},
+		{"LINESTRING(1 2,3 1-2)", 17},
+		{"POLYGON(1 2,3 4)", 8},
+		{"MULTIPOINT(1 2,(3 4)", 20},
+		{"LINESTRING(1 2,3 4 5)", 15},
+		{"LINESTRING(1 2 3,4 5)", 17},
+		{"POINT Z (1 2)", 9},
+		{"POINT ZM (1 2 3)", 10},
+		{"POINT M (1 2 3 4)", 9},
+	}
+
+	for i, test := range tests {
+		_, err := ParseWKT(test.wkt)
+		if err == nil {
+			t.Errorf("test %d should get error", i)
+			continue
+		}
+
+		e, ok := err.(*WKTError)
+		if !ok {
+			t.Errorf("test %d should be WKTError, got %v", i, err)
+			continue
+		}
+
+		if e.Pos != test.pos {
+			t.Errorf("test %d incorrect position, got %v", i, e)
+		}
+	}
+}
+
+func TestNewPointFromWKT(t *testing.T) {
+	p, err := NewPointFromWKT("POINT(1 2)")
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !p.Equals(NewPoint(1, 2)) {
+		t.Errorf("incorrect point, got %v", p)
+	}
+
+	if _, err := NewPointFromWKT("LINESTRING(1 2,3 4)"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := NewPointFromWKT("POINT(1 2"); err == nil {
+		t.Errorf("should get error")
+	}
+}
+
+func TestNewLineFromWKT(t *testing.T) {
+	l, err := NewLineFromWKT("LINESTRING(1 2,3 4)")
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !l.Equals(NewLine(NewPoint(1, 2), NewPoint(3, 4))) {
+		t.Errorf("incorrect line, got %v", l)
+	}
+
+	if _, err := NewLineFromWKT("LINESTRING(1 2,3 4,5 6)"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestNewPathFromWKT(t *testing.T) {
+	type testData struct {
+		wkt    string
+		length int
+	}
+
+	tests := []testData{
+		{"LINESTRING(1 2,3 4,5 6)", 3},
+		{"MULTIPOINT(1 2,3 4)", 2},
+		{"POLYGON((1 2,3 4,5 6,1 2))", 4},
+		{"EMPTY", 0},
+	}
+
+	for i, test := range tests {
+		p, err := NewPathFromWKT(test.wkt)
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if p.Length() != test.length {
+			t.Errorf("test %d incorrect path, got %v", i, p)
+		}
+
+		ps, err := NewPointSetFromWKT(test.wkt)
+		if err != nil {
+			t.Errorf("test %d should not get error, got %v", i, err)
+			continue
+		}
+
+		if !ps.Equals(&p.PointSet) {
+			t.Errorf("test %d incorrect point set, got %v", i, ps)
+		}
+	}
+
+	if _, err := NewPathFromWKT("POINT(1 2)"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	if _, err := NewPointSetFromWKT("POLYGON((0 0,4 0,4 4,0 0),(1 1,1 2,2 2,1 1))"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}
+
+func TestNewPolygonFromWKT(t *testing.T) {
+	p, err := NewPolygonFromWKT(testPolygon().ToWKT())
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !p.Equals(testPolygon()) {
+		t.Errorf("incorrect polygon, got %v", p)
+	}
+
+	if _, err := NewPolygonFromWKT("MULTIPOINT(1 2)"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+
+	mp, err := NewMultiPolygonFromWKT(testPolygon().ToWKT())
+	if err != nil {
+		t.Errorf("should not get error, got %v", err)
+	}
+
+	if !mp.Equals(NewMultiPolygon(testPolygon())) {
+		t.Errorf("incorrect multi polygon, got %v", mp)
+	}
+
+	if _, err := NewMultiPolygonFromWKT("POINT(1 2)"); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error, got %v", err)
+	}
+}